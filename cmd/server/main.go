@@ -18,16 +18,18 @@ import (
 	"github.com/Axontik/comin-time-service/internal/handler"
 	"github.com/Axontik/comin-time-service/internal/middleware"
 	"github.com/Axontik/comin-time-service/internal/repository"
+	"github.com/Axontik/comin-time-service/internal/scheduler"
 	"github.com/Axontik/comin-time-service/internal/service"
 	"github.com/Axontik/comin-time-service/pkg/auth"
-
-	// "github.com/Axontik/comin-time-service/pkg/employee"
+	"github.com/Axontik/comin-time-service/pkg/employee"
 	"github.com/Axontik/comin-time-service/pkg/organization"
 )
 
 type Application struct {
 	db          *gorm.DB
+	timeService service.TimeService
 	timeHandler *handler.TimeHandler
+	scheduler   *scheduler.Scheduler
 }
 
 func main() {
@@ -47,6 +49,10 @@ func main() {
 	// Initialize dependencies
 	app.initializeDependencies()
 
+	// Start background jobs
+	app.startJobs()
+	defer app.scheduler.Stop()
+
 	// Setup router
 	router := setupRouter(app)
 
@@ -83,6 +89,8 @@ func initDB() (*gorm.DB, error) {
 
 	config := &gorm.Config{
 		Logger: logger.Default.LogMode(logger.Info),
+		// Report unique violations as gorm.ErrDuplicatedKey
+		TranslateError: true,
 	}
 
 	return gorm.Open(postgres.Open(dbURL), config)
@@ -92,11 +100,45 @@ func (app *Application) initializeDependencies() {
 	// Initialize repositories
 	timeRepo := repository.NewTimeRepository(app.db)
 
+	// Initialize clients
+	employeeServiceURL := os.Getenv("EMPLOYEE_SERVICE_URL")
+	if employeeServiceURL == "" {
+		employeeServiceURL = "https://comin.kaveeshagimhana.com/api/v1"
+	}
+	employeeClient := employee.NewEmployeeClient(employeeServiceURL, os.Getenv("SERVICE_AUTH_TOKEN"))
+
 	// Initialize services
-	timeService := service.NewTimeService(timeRepo)
+	app.timeService = service.NewTimeService(timeRepo, employeeClient)
 
 	// Initialize handlers
-	app.timeHandler = handler.NewTimeHandler(timeService)
+	app.timeHandler = handler.NewTimeHandler(app.timeService)
+}
+
+func (app *Application) startJobs() {
+	app.scheduler = scheduler.NewScheduler()
+
+	app.scheduler.Register(scheduler.Job{
+		Name:     "approval-sla-escalation",
+		Interval: jobInterval("SLA_ESCALATION_INTERVAL", time.Hour),
+		Run: func(now time.Time) error {
+			escalated, err := app.timeService.EscalateOverdueTimesheets(now)
+			if err == nil && escalated > 0 {
+				log.Printf("Escalated %d overdue timesheet approvals", escalated)
+			}
+			return err
+		},
+	})
+
+	app.scheduler.Start()
+}
+
+// jobInterval reads a job interval such as "30m" from the environment
+func jobInterval(key string, fallback time.Duration) time.Duration {
+	interval, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || interval <= 0 {
+		return fallback
+	}
+	return interval
 }
 
 func (app *Application) healthHandler(c *gin.Context) {
@@ -131,11 +173,6 @@ func setupRouter(app *Application) *gin.Engine {
 	}
 	orgClient := organization.NewOrganizationClient(orgServiceURL)
 
-	// employeeClient := employee.NewEmployeeClient(os.Getenv("EMPLOYEE_SERVICE_URL"))
-	// if employeeClient == nil {
-	// 	employeeClient = employee.NewEmployeeClient("http://localhost:8082/api/v1")
-	// }
-
 	router := gin.New()
 	router.Use(gin.Logger())
 	router.Use(gin.Recovery())
@@ -160,6 +197,8 @@ func setupRouter(app *Application) *gin.Engine {
 		{
 			attendance.POST("/check-in", app.timeHandler.CheckIn)
 			attendance.POST("/check-out", app.timeHandler.CheckOut)
+			attendance.POST("/break-start", app.timeHandler.StartBreak)
+			attendance.POST("/break-end", app.timeHandler.EndBreak)
 		}
 
 		// Protected attendance routes
@@ -168,7 +207,7 @@ func setupRouter(app *Application) *gin.Engine {
 		{
 			orgAttendance.GET("/", app.timeHandler.ListAttendances)
 			// orgAttendance.GET("/:employee_id", app.timeHandler.GetEmployeeAttendance)
-			// orgAttendance.GET("/:employee_id/summary", app.timeHandler.GetAttendanceSummary)
+			orgAttendance.GET("/:employee_id/summary", app.timeHandler.GetAttendanceSummary)
 		}
 
 		// Timesheet routes
@@ -176,20 +215,332 @@ func setupRouter(app *Application) *gin.Engine {
 		timesheets.Use(organization.ValidateOrganizationAccess(authClient, orgClient))
 		{
 			timesheets.POST("/", app.timeHandler.CreateTimesheet)
-			// timesheets.GET("/", app.timeHandler.ListTimesheets)
+			timesheets.GET("/", app.timeHandler.ListTimesheets)
 			timesheets.GET("/:id", app.timeHandler.GetTimesheet)
 			timesheets.PUT("/:id", app.timeHandler.UpdateTimesheet)
 			timesheets.DELETE("/:id", app.timeHandler.DeleteTimesheet)
-			// timesheets.PUT("/:id/approve", app.timeHandler.ApproveTimesheet)
-			// timesheets.PUT("/:id/reject", app.timeHandler.RejectTimesheet)
+			timesheets.PUT("/:id/approve", app.timeHandler.ApproveTimesheet)
+			timesheets.PUT("/:id/reject", app.timeHandler.RejectTimesheet)
+			timesheets.GET("/:id/approvers", app.timeHandler.ListTimesheetApprovers)
+			timesheets.POST("/copy-previous-week", app.timeHandler.CopyPreviousWeek)
+			timesheets.POST("/submit", app.timeHandler.SubmitTimesheets)
+			timesheets.GET("/:id/comments", app.timeHandler.ListTimesheetComments)
+			timesheets.POST("/:id/comments", app.timeHandler.AddTimesheetComment)
+			timesheets.GET("/:id/history", app.timeHandler.ListTimesheetHistory)
+			timesheets.PUT("/:id/restore", app.timeHandler.RestoreTimesheet)
+			timesheets.GET("/period-comments", app.timeHandler.ListPeriodComments)
+			timesheets.POST("/period-comments", app.timeHandler.AddPeriodComment)
+		}
+
+		// Organization-wide timesheet routes
+		orgTimesheets := api.Group("/organizations/:organization_id/timesheets")
+		orgTimesheets.Use(organization.ValidateOrganizationAccess(authClient, orgClient))
+		orgTimesheets.Use(middleware.RequireRole("admin"))
+		{
+			orgTimesheets.POST("/purge", app.timeHandler.PurgeDeletedTimesheets)
+		}
+
+		// Payroll period routes
+		payrollPeriods := api.Group("/organizations/:organization_id/payroll-periods")
+		payrollPeriods.Use(organization.ValidateOrganizationAccess(authClient, orgClient))
+		payrollPeriods.Use(middleware.RequireRole("admin"))
+		{
+			payrollPeriods.GET("/", app.timeHandler.ListPayrollPeriods)
+			payrollPeriods.POST("/", app.timeHandler.ClosePayrollPeriod)
+			payrollPeriods.PUT("/:id/reopen", app.timeHandler.ReopenPayrollPeriod)
+			payrollPeriods.GET("/:id/events", app.timeHandler.ListPayrollPeriodEvents)
+		}
+
+		// Overtime routes
+		overtime := api.Group("/organizations/:organization_id/overtime")
+		overtime.Use(organization.ValidateOrganizationAccess(authClient, orgClient))
+		overtime.Use(middleware.RequireRole("admin"))
+		{
+			overtime.GET("/rules", app.timeHandler.ListOvertimeRules)
+			overtime.POST("/rules", app.timeHandler.CreateOvertimeRule)
+			overtime.PUT("/rules/:id", app.timeHandler.UpdateOvertimeRule)
+			overtime.DELETE("/rules/:id", app.timeHandler.DeleteOvertimeRule)
+			overtime.POST("/recompute", app.timeHandler.RecomputeOvertime)
+		}
+
+		// Compliance routes
+		compliance := api.Group("/organizations/:organization_id/compliance")
+		compliance.Use(organization.ValidateOrganizationAccess(authClient, orgClient))
+		compliance.Use(middleware.RequireRole("admin"))
+		{
+			compliance.GET("/rule-sets", app.timeHandler.ListComplianceRuleSets)
+			compliance.POST("/rule-sets", app.timeHandler.CreateComplianceRuleSet)
+			compliance.PUT("/rule-sets/:id", app.timeHandler.UpdateComplianceRuleSet)
+			compliance.DELETE("/rule-sets/:id", app.timeHandler.DeleteComplianceRuleSet)
+			compliance.PUT("/employees/:employee_id/jurisdiction", app.timeHandler.SetEmployeeJurisdiction)
+		}
+
+		// Holiday routes; listing is open to every member so kiosks can show them
+		holidays := api.Group("/organizations/:organization_id/holidays")
+		holidays.Use(organization.ValidateOrganizationAccess(authClient, orgClient))
+		{
+			holidays.GET("/", app.timeHandler.ListHolidays)
+			holidays.POST("/", middleware.RequireRole("admin"), app.timeHandler.CreateHoliday)
+			holidays.PUT("/:id", middleware.RequireRole("admin"), app.timeHandler.UpdateHoliday)
+			holidays.DELETE("/:id", middleware.RequireRole("admin"), app.timeHandler.DeleteHoliday)
+			holidays.POST("/import", middleware.RequireRole("admin"), app.timeHandler.ImportHolidays)
+		}
+
+		// Employee site routes
+		employeeSites := api.Group("/organizations/:organization_id/employees/:employee_id/site")
+		employeeSites.Use(organization.ValidateOrganizationAccess(authClient, orgClient))
+		employeeSites.Use(middleware.RequireRole("admin"))
+		{
+			employeeSites.PUT("/", app.timeHandler.SetEmployeeSite)
+		}
+
+		// Employee work week routes
+		workWeek := api.Group("/organizations/:organization_id/employees/:employee_id/work-week")
+		workWeek.Use(organization.ValidateOrganizationAccess(authClient, orgClient))
+		{
+			workWeek.GET("/", app.timeHandler.GetEmployeeWorkWeek)
+			workWeek.PUT("/", middleware.RequireRole("admin"), app.timeHandler.SetEmployeeWorkWeek)
+		}
+
+		// Leave type routes
+		leaveTypes := api.Group("/organizations/:organization_id/leave-types")
+		leaveTypes.Use(organization.ValidateOrganizationAccess(authClient, orgClient))
+		{
+			leaveTypes.GET("/", app.timeHandler.ListLeaveTypes)
+			leaveTypes.POST("/", middleware.RequireRole("admin"), app.timeHandler.CreateLeaveType)
+			leaveTypes.PUT("/:id", middleware.RequireRole("admin"), app.timeHandler.UpdateLeaveType)
+			leaveTypes.DELETE("/:id", middleware.RequireRole("admin"), app.timeHandler.DeleteLeaveType)
+		}
+
+		// Leave approval routes
+		leaveRequests := api.Group("/organizations/:organization_id/leave-requests")
+		leaveRequests.Use(organization.ValidateOrganizationAccess(authClient, orgClient))
+		leaveRequests.Use(middleware.RequireRole("admin"))
+		{
+			leaveRequests.GET("/", app.timeHandler.ListLeaveRequests)
+			leaveRequests.PUT("/:id/approve", app.timeHandler.ApproveLeaveRequest)
+			leaveRequests.PUT("/:id/reject", app.timeHandler.RejectLeaveRequest)
+		}
+
+		// Employee leave routes
+		employeeLeave := api.Group("/organizations/:organization_id/employees/:employee_id")
+		employeeLeave.Use(organization.ValidateOrganizationAccess(authClient, orgClient))
+		{
+			employeeLeave.POST("/leave-requests", app.timeHandler.CreateLeaveRequest)
+			employeeLeave.GET("/leave-requests", app.timeHandler.ListEmployeeLeaveRequests)
+			employeeLeave.PUT("/leave-requests/:id/cancel", app.timeHandler.CancelLeaveRequest)
+			employeeLeave.GET("/leave-balances", app.timeHandler.ListLeaveBalances)
+			employeeLeave.POST("/leave-balances/adjust", middleware.RequireRole("admin"), app.timeHandler.AdjustLeaveBalance)
+		}
+
+		// Team routes
+		teams := api.Group("/organizations/:organization_id/teams")
+		teams.Use(organization.ValidateOrganizationAccess(authClient, orgClient))
+		{
+			teams.GET("/", app.timeHandler.ListTeams)
+			teams.POST("/", middleware.RequireRole("admin"), app.timeHandler.CreateTeam)
+			teams.PUT("/:id", middleware.RequireRole("admin"), app.timeHandler.UpdateTeam)
+			teams.DELETE("/:id", middleware.RequireRole("admin"), app.timeHandler.DeleteTeam)
+		}
+
+		// Employee team routes
+		employeeTeam := api.Group("/organizations/:organization_id/employees/:employee_id/team")
+		employeeTeam.Use(organization.ValidateOrganizationAccess(authClient, orgClient))
+		employeeTeam.Use(middleware.RequireRole("admin"))
+		{
+			employeeTeam.PUT("/", app.timeHandler.SetEmployeeTeam)
+		}
+
+		// Remote work routes
+		remoteWork := api.Group("/organizations/:organization_id/remote-work")
+		remoteWork.Use(organization.ValidateOrganizationAccess(authClient, orgClient))
+		{
+			remoteWork.GET("/policy", app.timeHandler.GetRemoteWorkPolicy)
+			remoteWork.PUT("/policy", middleware.RequireRole("admin"), app.timeHandler.UpdateRemoteWorkPolicy)
+			remoteWork.GET("/requests", middleware.RequireRole("admin"), app.timeHandler.ListRemoteWorkRequests)
+			remoteWork.PUT("/requests/:id/approve", middleware.RequireRole("admin"), app.timeHandler.ApproveRemoteWorkRequest)
+			remoteWork.PUT("/requests/:id/reject", middleware.RequireRole("admin"), app.timeHandler.RejectRemoteWorkRequest)
+			remoteWork.GET("/violations", middleware.RequireRole("admin"), app.timeHandler.ListRemoteWorkViolations)
+		}
+
+		// Employee remote work routes
+		employeeRemoteWork := api.Group("/organizations/:organization_id/employees/:employee_id/remote-work-requests")
+		employeeRemoteWork.Use(organization.ValidateOrganizationAccess(authClient, orgClient))
+		{
+			employeeRemoteWork.POST("/", app.timeHandler.CreateRemoteWorkRequest)
+			employeeRemoteWork.GET("/", app.timeHandler.ListEmployeeRemoteWorkRequests)
+			employeeRemoteWork.PUT("/:id/cancel", app.timeHandler.CancelRemoteWorkRequest)
+		}
+
+		// Roster routes
+		rosters := api.Group("/organizations/:organization_id/rosters")
+		rosters.Use(organization.ValidateOrganizationAccess(authClient, orgClient))
+		rosters.Use(middleware.RequireRole("admin"))
+		{
+			rosters.GET("/", app.timeHandler.ListRosters)
+			rosters.POST("/", app.timeHandler.CreateRoster)
+			rosters.GET("/:id", app.timeHandler.GetRoster)
+			rosters.PUT("/:id", app.timeHandler.UpdateRoster)
+			rosters.DELETE("/:id", app.timeHandler.DeleteRoster)
+			rosters.PUT("/:id/publish", app.timeHandler.PublishRoster)
+			rosters.PUT("/:id/unpublish", app.timeHandler.UnpublishRoster)
+			rosters.POST("/:id/shifts", app.timeHandler.AddShift)
+			rosters.PUT("/:id/shifts/:shift_id", app.timeHandler.UpdateShift)
+			rosters.DELETE("/:id/shifts/:shift_id", app.timeHandler.DeleteShift)
+		}
+
+		// Open shift routes
+		openShifts := api.Group("/organizations/:organization_id/open-shifts")
+		openShifts.Use(organization.ValidateOrganizationAccess(authClient, orgClient))
+		{
+			openShifts.GET("/", app.timeHandler.ListOpenShifts)
+		}
+
+		// Shift swap approval routes
+		shiftSwaps := api.Group("/organizations/:organization_id/shift-swaps")
+		shiftSwaps.Use(organization.ValidateOrganizationAccess(authClient, orgClient))
+		shiftSwaps.Use(middleware.RequireRole("admin"))
+		{
+			shiftSwaps.GET("/", app.timeHandler.ListShiftSwapRequests)
+			shiftSwaps.PUT("/:id/approve", app.timeHandler.ApproveShiftSwapRequest)
+			shiftSwaps.PUT("/:id/reject", app.timeHandler.RejectShiftSwapRequest)
+		}
+
+		// Employee schedule routes
+		employeeShifts := api.Group("/organizations/:organization_id/employees/:employee_id")
+		employeeShifts.Use(organization.ValidateOrganizationAccess(authClient, orgClient))
+		{
+			employeeShifts.GET("/shifts", app.timeHandler.ListEmployeeShifts)
+			employeeShifts.POST("/shifts/:id/claim", app.timeHandler.ClaimOpenShift)
+			employeeShifts.POST("/shift-swaps", app.timeHandler.CreateShiftSwapRequest)
+			employeeShifts.GET("/shift-swaps", app.timeHandler.ListEmployeeShiftSwapRequests)
+			employeeShifts.PUT("/shift-swaps/:id/cancel", app.timeHandler.CancelShiftSwapRequest)
+		}
+
+		// On-call routes
+		onCall := api.Group("/organizations/:organization_id/on-call")
+		onCall.Use(organization.ValidateOrganizationAccess(authClient, orgClient))
+		{
+			onCall.GET("/rotations", app.timeHandler.ListOnCallRotations)
+			onCall.POST("/rotations", middleware.RequireRole("admin"), app.timeHandler.CreateOnCallRotation)
+			onCall.PUT("/rotations/:id", middleware.RequireRole("admin"), app.timeHandler.UpdateOnCallRotation)
+			onCall.DELETE("/rotations/:id", middleware.RequireRole("admin"), app.timeHandler.DeleteOnCallRotation)
+			onCall.GET("/rotations/:id/schedule", app.timeHandler.GetOnCallSchedule)
+			onCall.GET("/incidents", middleware.RequireRole("admin"), app.timeHandler.ListOnCallIncidents)
+		}
+
+		// Employee on-call incident routes
+		employeeOnCall := api.Group("/organizations/:organization_id/employees/:employee_id/on-call-incidents")
+		employeeOnCall.Use(organization.ValidateOrganizationAccess(authClient, orgClient))
+		{
+			employeeOnCall.POST("/", app.timeHandler.CreateOnCallIncident)
+			employeeOnCall.GET("/", app.timeHandler.ListEmployeeOnCallIncidents)
+		}
+
+		// Employee overtime routes
+		employeeOvertime := api.Group("/organizations/:organization_id/employees/:employee_id/overtime")
+		employeeOvertime.Use(organization.ValidateOrganizationAccess(authClient, orgClient))
+		{
+			employeeOvertime.GET("/", app.timeHandler.GetEmployeeOvertime)
+		}
+
+		// Timesheet template routes
+		templates := api.Group("/organizations/:organization_id/employees/:employee_id/timesheet-templates")
+		templates.Use(organization.ValidateOrganizationAccess(authClient, orgClient))
+		{
+			templates.GET("/", app.timeHandler.ListTimesheetTemplates)
+			templates.POST("/", app.timeHandler.CreateTimesheetTemplate)
+			templates.PUT("/:id", app.timeHandler.UpdateTimesheetTemplate)
+			templates.DELETE("/:id", app.timeHandler.DeleteTimesheetTemplate)
+			templates.POST("/:id/apply", app.timeHandler.ApplyTimesheetTemplate)
+		}
+
+		// Project routes
+		projects := api.Group("/organizations/:organization_id/projects")
+		projects.Use(organization.ValidateOrganizationAccess(authClient, orgClient))
+		{
+			projects.GET("/", app.timeHandler.ListProjects)
+			projects.POST("/", middleware.RequireRole("admin"), app.timeHandler.CreateProject)
+			projects.GET("/:id", app.timeHandler.GetProject)
+			projects.PUT("/:id", middleware.RequireRole("admin"), app.timeHandler.UpdateProject)
+			projects.GET("/:id/tasks", app.timeHandler.ListTasks)
+			projects.POST("/:id/tasks", middleware.RequireRole("admin"), app.timeHandler.CreateTask)
+			projects.PUT("/:id/tasks/:task_id", middleware.RequireRole("admin"), app.timeHandler.UpdateTask)
+			projects.POST("/:id/members", middleware.RequireRole("admin"), app.timeHandler.AddProjectMember)
+			projects.DELETE("/:id/members/:employee_id", middleware.RequireRole("admin"), app.timeHandler.RemoveProjectMember)
+			projects.GET("/:id/budgets", app.timeHandler.ListProjectBudgets)
+			projects.POST("/:id/budgets", middleware.RequireRole("admin"), app.timeHandler.CreateProjectBudget)
+			projects.PUT("/:id/budgets/:budget_id", middleware.RequireRole("admin"), app.timeHandler.UpdateProjectBudget)
+			projects.DELETE("/:id/budgets/:budget_id", middleware.RequireRole("admin"), app.timeHandler.DeleteProjectBudget)
+			projects.GET("/:id/burndown", app.timeHandler.GetProjectBurnDown)
+		}
+
+		// Rate card routes
+		rateCards := api.Group("/organizations/:organization_id/rate-cards")
+		rateCards.Use(organization.ValidateOrganizationAccess(authClient, orgClient))
+		rateCards.Use(middleware.RequireRole("admin"))
+		{
+			rateCards.GET("/", app.timeHandler.ListRateCards)
+			rateCards.POST("/", app.timeHandler.CreateRateCard)
+			rateCards.PUT("/:id", app.timeHandler.UpdateRateCard)
+			rateCards.DELETE("/:id", app.timeHandler.DeleteRateCard)
+		}
+
+		// Invoice routes
+		invoices := api.Group("/organizations/:organization_id/invoices")
+		invoices.Use(organization.ValidateOrganizationAccess(authClient, orgClient))
+		invoices.Use(middleware.RequireRole("admin"))
+		{
+			invoices.GET("/", app.timeHandler.ListInvoices)
+			invoices.POST("/", app.timeHandler.CreateInvoice)
+			invoices.GET("/:id", app.timeHandler.GetInvoice)
+			invoices.DELETE("/:id", app.timeHandler.DeleteInvoice)
+		}
+
+		// Timer routes
+		timers := api.Group("/organizations/:organization_id/employees/:employee_id/timers")
+		timers.Use(organization.ValidateOrganizationAccess(authClient, orgClient))
+		{
+			timers.POST("/", app.timeHandler.StartTimer)
+			timers.GET("/", app.timeHandler.ListActiveTimers)
+			timers.GET("/:id", app.timeHandler.GetTimer)
+			timers.PUT("/:id/pause", app.timeHandler.PauseTimer)
+			timers.PUT("/:id/resume", app.timeHandler.ResumeTimer)
+			timers.PUT("/:id/stop", app.timeHandler.StopTimer)
+			timers.PUT("/:id/discard", app.timeHandler.DiscardTimer)
+		}
+
+		// Timesheet policy routes
+		timesheetPolicy := api.Group("/organizations/:organization_id/timesheet-policy")
+		timesheetPolicy.Use(organization.ValidateOrganizationAccess(authClient, orgClient))
+		{
+			timesheetPolicy.GET("/", app.timeHandler.GetTimesheetPolicy)
+			timesheetPolicy.PUT("/", middleware.RequireRole("admin"), app.timeHandler.UpdateTimesheetPolicy)
+		}
+
+		// Approval SLA routes
+		slaRules := api.Group("/organizations/:organization_id/approval-sla-rules")
+		slaRules.Use(organization.ValidateOrganizationAccess(authClient, orgClient))
+		slaRules.Use(middleware.RequireRole("admin"))
+		{
+			slaRules.GET("/", app.timeHandler.ListApprovalSLARules)
+			slaRules.POST("/", app.timeHandler.CreateApprovalSLARule)
+			slaRules.PUT("/:id", app.timeHandler.UpdateApprovalSLARule)
+			slaRules.DELETE("/:id", app.timeHandler.DeleteApprovalSLARule)
 		}
 
 		// Reports
 		reports := api.Group("/organizations/:organization_id/reports")
 		reports.Use(organization.ValidateOrganizationAccess(authClient, orgClient))
+		reports.Use(middleware.RequireRole("admin"))
 		{
-			// reports.GET("/attendance", app.timeHandler.GetAttendanceReport)
+			reports.GET("/attendance", app.timeHandler.GetAttendanceReport)
 			// reports.GET("/timesheets", app.timeHandler.GetTimesheetReport)
+			reports.GET("/reconciliation", app.timeHandler.GetReconciliationReport)
+			reports.GET("/overtime", app.timeHandler.GetOvertimeReport)
+			reports.GET("/compliance", app.timeHandler.GetComplianceReport)
+			reports.GET("/on-call", app.timeHandler.GetOnCallReport)
+			reports.GET("/utilization", app.timeHandler.GetUtilizationReport)
 		}
 	}
 
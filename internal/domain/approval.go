@@ -0,0 +1,54 @@
+// internal/domain/approval.go
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ApprovalSLARule escalates pending timesheets that have waited longer than
+// BusinessDays. Rules of an organization are applied in order of Level.
+type ApprovalSLARule struct {
+	Base
+	OrganizationID   uuid.UUID  `json:"organization_id" gorm:"type:uuid;not null"`
+	Level            int        `json:"level" gorm:"not null"`
+	BusinessDays     int        `json:"business_days" gorm:"not null"`
+	EscalationTarget string     `json:"escalation_target" gorm:"not null"`
+	EscalationUserID *uuid.UUID `json:"escalation_user_id,omitempty" gorm:"type:uuid"`
+	Action           string     `json:"action" gorm:"default:'add_approver'"`
+	IsActive         bool       `json:"is_active"`
+}
+
+// TimesheetApprover assigns an approver to a pending timesheet
+type TimesheetApprover struct {
+	Base
+	TimesheetID uuid.UUID `json:"timesheet_id" gorm:"type:uuid;not null"`
+	ApproverID  uuid.UUID `json:"approver_id" gorm:"type:uuid;not null"`
+	Level       int       `json:"level" gorm:"not null;default:0"`
+	IsActive    bool      `json:"is_active"`
+	AssignedAt  time.Time `json:"assigned_at" gorm:"not null"`
+}
+
+// Request/Response types
+type ApprovalSLARuleRequest struct {
+	Level            int        `json:"level" binding:"required,min=1"`
+	BusinessDays     int        `json:"business_days" binding:"required,min=1"`
+	EscalationTarget string     `json:"escalation_target" binding:"required,oneof=manager org_admin"`
+	EscalationUserID *uuid.UUID `json:"escalation_user_id"`
+	Action           string     `json:"action" binding:"omitempty,oneof=reassign add_approver"`
+	IsActive         *bool      `json:"is_active"`
+}
+
+type RejectTimesheetRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// Constants
+const (
+	EscalationTargetManager  = "manager"
+	EscalationTargetOrgAdmin = "org_admin"
+
+	EscalationActionReassign    = "reassign"
+	EscalationActionAddApprover = "add_approver"
+)
@@ -0,0 +1,69 @@
+// internal/domain/attendance_report.go
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AttendanceAggregate counts a set of attendance records by status.
+// Headcount is the number of distinct employees who checked in;
+// AverageCheckIn is the mean check-in time of day ("HH:MM" in the policy
+// timezone) of the records with a check-in.
+type AttendanceAggregate struct {
+	Records        int    `json:"records"`
+	Headcount      int    `json:"headcount"`
+	Late           int    `json:"late"`
+	HalfDay        int    `json:"half_day"`
+	Absent         int    `json:"absent"`
+	Leave          int    `json:"leave"`
+	AverageCheckIn string `json:"average_check_in,omitempty"`
+}
+
+// AttendanceGroup aggregates the records of one day ("YYYY-MM-DD"),
+// employee or site; employees without a site share the empty key
+type AttendanceGroup struct {
+	Key string `json:"key"`
+	AttendanceAggregate
+}
+
+// Request/Response types
+type AttendanceFilter struct {
+	EmployeeID *uuid.UUID
+	Status     string
+	WorkMode   string
+	Site       string
+	StartDate  time.Time
+	EndDate    time.Time
+}
+
+type AttendanceReportFilter struct {
+	AttendanceFilter
+	GroupBy string
+	Cursor  string
+	Limit   int
+}
+
+// AttendanceReport pages through the matching records, or their groups when
+// grouped, in key order. Summary covers every matching record; NextCursor is
+// set while more pages follow.
+type AttendanceReport struct {
+	StartDate  time.Time           `json:"start_date"`
+	EndDate    time.Time           `json:"end_date"`
+	GroupBy    string              `json:"group_by,omitempty"`
+	Summary    AttendanceAggregate `json:"summary"`
+	Records    []Attendance        `json:"records,omitempty"`
+	Groups     []AttendanceGroup   `json:"groups,omitempty"`
+	NextCursor string              `json:"next_cursor,omitempty"`
+}
+
+// Constants
+const (
+	AttendanceByDay      = "day"
+	AttendanceByEmployee = "employee"
+	AttendanceBySite     = "site"
+
+	DefaultAttendanceReportLimit = 50
+	MaxAttendanceReportLimit     = 500
+)
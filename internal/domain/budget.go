@@ -0,0 +1,73 @@
+// internal/domain/budget.go
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProjectBudget caps the hours and/or money that may be spent on a project,
+// or on one of its tasks when TaskID is set. A zero budget means no limit.
+type ProjectBudget struct {
+	Base
+	ProjectID        uuid.UUID  `json:"project_id" gorm:"type:uuid;not null"`
+	TaskID           *uuid.UUID `json:"task_id,omitempty" gorm:"type:uuid"`
+	BudgetHours      float64    `json:"budget_hours" gorm:"type:decimal(10,2)"`
+	BudgetAmount     float64    `json:"budget_amount" gorm:"type:decimal(12,2)"`
+	Currency         string     `json:"currency,omitempty"`
+	HardCap          bool       `json:"hard_cap"`
+	HoursAlertLevel  int        `json:"hours_alert_level"`
+	AmountAlertLevel int        `json:"amount_alert_level"`
+}
+
+// TimesheetUsage sums timesheet hours and billable amounts by status
+type TimesheetUsage struct {
+	Status string  `json:"status"`
+	Hours  float64 `json:"hours"`
+	Amount float64 `json:"amount"`
+}
+
+// Request/Response types
+type ProjectBudgetRequest struct {
+	TaskID       *uuid.UUID `json:"task_id"`
+	BudgetHours  float64    `json:"budget_hours" binding:"min=0"`
+	BudgetAmount float64    `json:"budget_amount" binding:"min=0"`
+	Currency     string     `json:"currency" binding:"omitempty,len=3"`
+	HardCap      bool       `json:"hard_cap"`
+}
+
+type BudgetStatus struct {
+	ProjectBudget
+	ApprovedHours   float64 `json:"approved_hours"`
+	PendingHours    float64 `json:"pending_hours"`
+	RemainingHours  float64 `json:"remaining_hours"`
+	HoursPercent    float64 `json:"hours_percent"`
+	ApprovedAmount  float64 `json:"approved_amount"`
+	PendingAmount   float64 `json:"pending_amount"`
+	RemainingAmount float64 `json:"remaining_amount"`
+	AmountPercent   float64 `json:"amount_percent"`
+}
+
+type BurnDownPoint struct {
+	WeekStart        time.Time `json:"week_start"`
+	ApprovedHours    float64   `json:"approved_hours"`
+	PendingHours     float64   `json:"pending_hours"`
+	CumulativeHours  float64   `json:"cumulative_hours"`
+	RemainingHours   float64   `json:"remaining_hours"`
+	CumulativeAmount float64   `json:"cumulative_amount"`
+	RemainingAmount  float64   `json:"remaining_amount"`
+}
+
+type BurnDownResponse struct {
+	Budget BudgetStatus    `json:"budget"`
+	Points []BurnDownPoint `json:"points"`
+}
+
+// BudgetAlertThresholds are the percentages of a budget that trigger an alert
+var BudgetAlertThresholds = []int{50, 80, 100}
+
+// Constants
+const (
+	NotificationBudgetThreshold = "project.budget_threshold"
+)
@@ -0,0 +1,45 @@
+// internal/domain/comment.go
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TimesheetComment is a message on a timesheet entry, or on an employee's
+// timesheet period when TimesheetID is nil.
+type TimesheetComment struct {
+	Base
+	OrganizationID uuid.UUID  `json:"organization_id" gorm:"type:uuid;not null"`
+	EmployeeID     uuid.UUID  `json:"employee_id" gorm:"type:uuid;not null"`
+	TimesheetID    *uuid.UUID `json:"timesheet_id,omitempty" gorm:"type:uuid"`
+	PeriodStart    *time.Time `json:"period_start,omitempty" gorm:"type:date"`
+	AuthorID       uuid.UUID  `json:"author_id" gorm:"type:uuid;not null"`
+	Kind           string     `json:"kind" gorm:"default:'comment'"`
+	Body           string     `json:"body" gorm:"not null"`
+}
+
+// TimesheetChange records a change of one field of a timesheet entry
+type TimesheetChange struct {
+	ID          uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	TimesheetID uuid.UUID  `json:"timesheet_id" gorm:"type:uuid;not null"`
+	ChangedBy   *uuid.UUID `json:"changed_by,omitempty" gorm:"type:uuid"`
+	Field       string     `json:"field" gorm:"not null"`
+	OldValue    string     `json:"old_value"`
+	NewValue    string     `json:"new_value"`
+	ChangedAt   time.Time  `json:"changed_at" gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+// Request/Response types
+type TimesheetCommentRequest struct {
+	Body string `json:"body" binding:"required,max=4000"`
+}
+
+// Constants
+const (
+	CommentKindComment   = "comment"
+	CommentKindRejection = "rejection"
+
+	NotificationTimesheetComment = "timesheet.comment_added"
+)
@@ -0,0 +1,115 @@
+// internal/domain/compliance.go
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ComplianceRuleSet holds the labor-law limits of a jurisdiction. The set
+// without a jurisdiction applies to employees not assigned to one.
+type ComplianceRuleSet struct {
+	Base
+	OrganizationID uuid.UUID        `json:"organization_id" gorm:"type:uuid;not null"`
+	Name           string           `json:"name" gorm:"not null"`
+	Jurisdiction   string           `json:"jurisdiction"`
+	IsActive       bool             `json:"is_active"`
+	Rules          []ComplianceRule `json:"rules" gorm:"foreignKey:RuleSetID"`
+}
+
+// ComplianceRule is a limit of a rule set. Limit is the minimum rest in hours
+// between shifts for min_rest rules, the weekly maximum for max_weekly_hours
+// rules and the longest stretch worked without a break for break rules, where
+// BreakMinutes is the shortest pause that counts as a break.
+type ComplianceRule struct {
+	Base
+	RuleSetID    uuid.UUID `json:"rule_set_id" gorm:"type:uuid;not null"`
+	Kind         string    `json:"kind" gorm:"not null"`
+	Limit        float64   `json:"limit" gorm:"type:decimal(6,2);not null"`
+	BreakMinutes int       `json:"break_minutes,omitempty"`
+	Severity     string    `json:"severity" gorm:"default:'warning'"`
+}
+
+// EmployeeJurisdiction assigns an employee to the rule set of a jurisdiction
+type EmployeeJurisdiction struct {
+	Base
+	OrganizationID uuid.UUID `json:"organization_id" gorm:"type:uuid;not null"`
+	EmployeeID     uuid.UUID `json:"employee_id" gorm:"type:uuid;not null"`
+	Jurisdiction   string    `json:"jurisdiction" gorm:"not null"`
+}
+
+// Request/Response types
+type ComplianceRuleSetRequest struct {
+	Name         string                  `json:"name" binding:"required"`
+	Jurisdiction string                  `json:"jurisdiction"`
+	IsActive     *bool                   `json:"is_active"`
+	Rules        []ComplianceRuleRequest `json:"rules" binding:"required,min=1,dive"`
+}
+
+type ComplianceRuleRequest struct {
+	Kind         string  `json:"kind" binding:"required,oneof=min_rest max_weekly_hours break"`
+	Limit        float64 `json:"limit" binding:"required,gt=0,max=168"`
+	BreakMinutes int     `json:"break_minutes" binding:"min=0"`
+	Severity     string  `json:"severity" binding:"omitempty,oneof=warning critical"`
+}
+
+type EmployeeJurisdictionRequest struct {
+	Jurisdiction string `json:"jurisdiction"`
+}
+
+// ComplianceViolation is a breach of a compliance rule by an employee's
+// attendance or timesheets on a date
+type ComplianceViolation struct {
+	EmployeeID uuid.UUID `json:"employee_id"`
+	RuleSetID  uuid.UUID `json:"rule_set_id"`
+	Rule       string    `json:"rule"`
+	Severity   string    `json:"severity"`
+	Source     string    `json:"source"`
+	Date       time.Time `json:"date"`
+	Message    string    `json:"message"`
+	Limit      float64   `json:"limit"`
+	Actual     float64   `json:"actual"`
+}
+
+// AttendanceResult is a punch together with the compliance and remote-work
+// rules it breaks without being blocked
+type AttendanceResult struct {
+	Attendance
+	Violations           []ComplianceViolation `json:"violations,omitempty"`
+	RemoteWorkViolations []RemoteWorkViolation `json:"remote_work_violations,omitempty"`
+}
+
+type ComplianceFilter struct {
+	EmployeeID *uuid.UUID
+	StartDate  time.Time
+	EndDate    time.Time
+	Severity   string
+}
+
+type ComplianceSummary struct {
+	Total    int            `json:"total"`
+	Warnings int            `json:"warnings"`
+	Critical int            `json:"critical"`
+	ByRule   map[string]int `json:"by_rule"`
+}
+
+type ComplianceReport struct {
+	StartDate  time.Time             `json:"start_date"`
+	EndDate    time.Time             `json:"end_date"`
+	Summary    ComplianceSummary     `json:"summary"`
+	Violations []ComplianceViolation `json:"violations"`
+}
+
+// Constants
+const (
+	ComplianceRuleMinRest        = "min_rest"
+	ComplianceRuleMaxWeeklyHours = "max_weekly_hours"
+	ComplianceRuleBreak          = "break"
+
+	SeverityWarning  = "warning"
+	SeverityCritical = "critical"
+
+	ComplianceSourceAttendance = "attendance"
+	ComplianceSourceTimesheets = "timesheets"
+)
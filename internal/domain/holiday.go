@@ -0,0 +1,68 @@
+// internal/domain/holiday.go
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Holiday is a non-working day in an organization's calendar. Holidays
+// without a site apply to every employee. Recurring holidays repeat every
+// year on the month and day of Date, from its year on. Half-day holidays
+// only free the afternoon.
+type Holiday struct {
+	Base
+	OrganizationID uuid.UUID `json:"organization_id" gorm:"type:uuid;not null"`
+	Date           time.Time `json:"date" gorm:"not null;type:date"`
+	Name           string    `json:"name" gorm:"not null"`
+	Site           string    `json:"site"`
+	Recurring      bool      `json:"recurring"`
+	HalfDay        bool      `json:"half_day"`
+	Source         string    `json:"source" gorm:"default:'manual'"`
+	ExternalUID    string    `json:"external_uid,omitempty"`
+}
+
+// EmployeeSite assigns an employee to a site, whose holidays apply on top
+// of the organization-wide ones
+type EmployeeSite struct {
+	Base
+	OrganizationID uuid.UUID `json:"organization_id" gorm:"type:uuid;not null"`
+	EmployeeID     uuid.UUID `json:"employee_id" gorm:"type:uuid;not null"`
+	Site           string    `json:"site" gorm:"not null"`
+}
+
+// Request/Response types
+type HolidayRequest struct {
+	Date      string `json:"date" binding:"required"`
+	Name      string `json:"name" binding:"required"`
+	Site      string `json:"site"`
+	Recurring bool   `json:"recurring"`
+	HalfDay   bool   `json:"half_day"`
+}
+
+type EmployeeSiteRequest struct {
+	Site string `json:"site"`
+}
+
+// HolidayOccurrence is a holiday on a date, with recurring holidays
+// expanded to the year asked for
+type HolidayOccurrence struct {
+	HolidayID uuid.UUID `json:"holiday_id"`
+	Date      time.Time `json:"date"`
+	Name      string    `json:"name"`
+	Site      string    `json:"site,omitempty"`
+	HalfDay   bool      `json:"half_day"`
+	Recurring bool      `json:"recurring"`
+}
+
+type HolidayImportResult struct {
+	Created int `json:"created"`
+	Updated int `json:"updated"`
+}
+
+// Constants
+const (
+	HolidaySourceManual = "manual"
+	HolidaySourceICS    = "ics"
+)
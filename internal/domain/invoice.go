@@ -0,0 +1,74 @@
+// internal/domain/invoice.go
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Invoice is a draft built from approved billable timesheet entries. The
+// entries it includes are marked as invoiced and can no longer be changed.
+type Invoice struct {
+	Base
+	OrganizationID uuid.UUID     `json:"organization_id" gorm:"type:uuid;not null"`
+	Number         string        `json:"number" gorm:"not null"`
+	ProjectID      *uuid.UUID    `json:"project_id,omitempty" gorm:"type:uuid"`
+	Client         string        `json:"client,omitempty"`
+	PeriodStart    time.Time     `json:"period_start" gorm:"type:date;not null"`
+	PeriodEnd      time.Time     `json:"period_end" gorm:"type:date;not null"`
+	GroupBy        string        `json:"group_by" gorm:"not null"`
+	Currency       string        `json:"currency" gorm:"not null"`
+	Subtotal       float64       `json:"subtotal" gorm:"type:decimal(12,2)"`
+	TaxRate        float64       `json:"tax_rate" gorm:"type:decimal(5,2)"`
+	TaxAmount      float64       `json:"tax_amount" gorm:"type:decimal(12,2)"`
+	Total          float64       `json:"total" gorm:"type:decimal(12,2)"`
+	Status         string        `json:"status" gorm:"default:'draft'"`
+	Notes          string        `json:"notes,omitempty"`
+	CreatedBy      *uuid.UUID    `json:"created_by,omitempty" gorm:"type:uuid"`
+	Lines          []InvoiceLine `json:"lines" gorm:"foreignKey:InvoiceID"`
+}
+
+// InvoiceLine sums the entries of one task or employee billed at one rate
+type InvoiceLine struct {
+	Base
+	InvoiceID   uuid.UUID  `json:"invoice_id" gorm:"type:uuid;not null"`
+	ProjectID   *uuid.UUID `json:"project_id,omitempty" gorm:"type:uuid"`
+	TaskID      *uuid.UUID `json:"task_id,omitempty" gorm:"type:uuid"`
+	EmployeeID  *uuid.UUID `json:"employee_id,omitempty" gorm:"type:uuid"`
+	Description string     `json:"description"`
+	Hours       float64    `json:"hours" gorm:"type:decimal(10,2)"`
+	Rate        float64    `json:"rate" gorm:"type:decimal(10,2)"`
+	Amount      float64    `json:"amount" gorm:"type:decimal(12,2)"`
+	EntryCount  int        `json:"entry_count"`
+}
+
+// Request/Response types
+type CreateInvoiceRequest struct {
+	ProjectID *uuid.UUID `json:"project_id"`
+	Client    string     `json:"client"`
+	StartDate string     `json:"start_date" binding:"required"`
+	EndDate   string     `json:"end_date" binding:"required"`
+	GroupBy   string     `json:"group_by" binding:"omitempty,oneof=task employee"`
+	TaxRate   float64    `json:"tax_rate" binding:"min=0,max=100"`
+	Notes     string     `json:"notes"`
+}
+
+// InvoiceExport is an invoice rendered in one of the export formats
+type InvoiceExport struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// Constants
+const (
+	InvoiceStatusDraft = "draft"
+
+	InvoiceGroupByTask     = "task"
+	InvoiceGroupByEmployee = "employee"
+
+	InvoiceFormatJSON = "json"
+	InvoiceFormatCSV  = "csv"
+	InvoiceFormatPDF  = "pdf"
+)
@@ -0,0 +1,126 @@
+// internal/domain/leave.go
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LeaveType is a kind of leave an organization grants. Types that track a
+// balance accrue AccrualDaysPerMonth every month; at the turn of the year
+// at most CarryOverCapDays of the remaining balance is carried over (all of
+// it when the cap is nil).
+type LeaveType struct {
+	Base
+	OrganizationID      uuid.UUID `json:"organization_id" gorm:"type:uuid;not null"`
+	Name                string    `json:"name" gorm:"not null"`
+	Kind                string    `json:"kind" gorm:"not null"`
+	Paid                bool      `json:"paid"`
+	TrackBalance        bool      `json:"track_balance"`
+	AccrualDaysPerMonth float64   `json:"accrual_days_per_month" gorm:"type:decimal(5,2)"`
+	CarryOverCapDays    *float64  `json:"carry_over_cap_days" gorm:"type:decimal(5,2)"`
+	IsActive            bool      `json:"is_active"`
+}
+
+// LeaveBalance is an employee's balance of a leave type for a year.
+// AccruedMonths is the number of months of the year accrued so far.
+type LeaveBalance struct {
+	Base
+	OrganizationID uuid.UUID `json:"organization_id" gorm:"type:uuid;not null"`
+	EmployeeID     uuid.UUID `json:"employee_id" gorm:"type:uuid;not null"`
+	LeaveTypeID    uuid.UUID `json:"leave_type_id" gorm:"type:uuid;not null"`
+	Year           int       `json:"year" gorm:"not null"`
+	CarriedOver    float64   `json:"carried_over" gorm:"type:decimal(6,2)"`
+	Accrued        float64   `json:"accrued" gorm:"type:decimal(6,2)"`
+	Adjustment     float64   `json:"adjustment" gorm:"type:decimal(6,2)"`
+	Used           float64   `json:"used" gorm:"type:decimal(6,2)"`
+	AccruedMonths  int       `json:"accrued_months"`
+}
+
+// LeaveRequest asks for leave over a range of days, or for half of a day.
+// Days is the number of working days the request takes from the balance.
+type LeaveRequest struct {
+	Base
+	OrganizationID uuid.UUID  `json:"organization_id" gorm:"type:uuid;not null"`
+	EmployeeID     uuid.UUID  `json:"employee_id" gorm:"type:uuid;not null"`
+	LeaveTypeID    uuid.UUID  `json:"leave_type_id" gorm:"type:uuid;not null"`
+	StartDate      time.Time  `json:"start_date" gorm:"type:date;not null"`
+	EndDate        time.Time  `json:"end_date" gorm:"type:date;not null"`
+	HalfDay        bool       `json:"half_day"`
+	HalfDayPeriod  string     `json:"half_day_period,omitempty"`
+	Days           float64    `json:"days" gorm:"type:decimal(5,2);not null"`
+	Reason         string     `json:"reason"`
+	Status         string     `json:"status" gorm:"default:'pending'"`
+	ReviewedBy     *uuid.UUID `json:"reviewed_by,omitempty" gorm:"type:uuid"`
+	ReviewedAt     *time.Time `json:"reviewed_at,omitempty"`
+	ReviewNote     string     `json:"review_note,omitempty"`
+}
+
+// Request/Response types
+type LeaveTypeRequest struct {
+	Name                string   `json:"name" binding:"required"`
+	Kind                string   `json:"kind" binding:"required,oneof=annual sick unpaid"`
+	Paid                *bool    `json:"paid"`
+	TrackBalance        *bool    `json:"track_balance"`
+	AccrualDaysPerMonth float64  `json:"accrual_days_per_month" binding:"min=0,max=31"`
+	CarryOverCapDays    *float64 `json:"carry_over_cap_days" binding:"omitempty,min=0"`
+	IsActive            *bool    `json:"is_active"`
+}
+
+type CreateLeaveRequest struct {
+	LeaveTypeID   uuid.UUID `json:"leave_type_id" binding:"required"`
+	StartDate     string    `json:"start_date" binding:"required"`
+	EndDate       string    `json:"end_date"`
+	HalfDay       bool      `json:"half_day"`
+	HalfDayPeriod string    `json:"half_day_period" binding:"omitempty,oneof=morning afternoon"`
+	Reason        string    `json:"reason"`
+}
+
+type ReviewLeaveRequest struct {
+	Note string `json:"note"`
+}
+
+type LeaveRequestFilter struct {
+	EmployeeID *uuid.UUID
+	Status     string
+	StartDate  time.Time
+	EndDate    time.Time
+}
+
+type LeaveBalanceAdjustmentRequest struct {
+	LeaveTypeID uuid.UUID `json:"leave_type_id" binding:"required"`
+	Year        int       `json:"year"`
+	Days        float64   `json:"days" binding:"required"`
+}
+
+// LeaveBalanceResponse is a balance with the days still available, net of
+// pending requests
+type LeaveBalanceResponse struct {
+	LeaveBalance
+	LeaveTypeName string  `json:"leave_type_name"`
+	Pending       float64 `json:"pending"`
+	Available     float64 `json:"available"`
+}
+
+// Constants
+const (
+	LeaveKindAnnual = "annual"
+	LeaveKindSick   = "sick"
+	LeaveKindUnpaid = "unpaid"
+
+	LeaveStatusPending   = "pending"
+	LeaveStatusApproved  = "approved"
+	LeaveStatusRejected  = "rejected"
+	LeaveStatusCancelled = "cancelled"
+
+	HalfDayMorning   = "morning"
+	HalfDayAfternoon = "afternoon"
+
+	// AttendanceStatusLeave marks a day of approved full-day leave; half-day
+	// leave is recorded as AttendanceStatusHalfDay
+	AttendanceStatusLeave = "leave"
+
+	NotificationLeaveRequested = "leave.requested"
+	NotificationLeaveReviewed  = "leave.reviewed"
+)
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type Base struct {
@@ -25,6 +26,21 @@ type Attendance struct {
 	WorkMode       string     `json:"work_mode" gorm:"default:'office'"`
 	Location       string     `json:"location"`
 	DeviceInfo     string     `json:"device_info"`
+
+	// CheckIn and CheckOut keep the raw punches; the rounded punches, breaks
+	// and lunch deduction they were paid with are kept for auditing
+	RoundedCheckIn        *time.Time `json:"rounded_check_in,omitempty"`
+	RoundedCheckOut       *time.Time `json:"rounded_check_out,omitempty"`
+	BreakStartedAt        *time.Time `json:"break_started_at,omitempty"`
+	BreakMinutes          int        `json:"break_minutes"`
+	LunchDeductionMinutes int        `json:"lunch_deduction_minutes"`
+	WorkedHours           float64    `json:"worked_hours" gorm:"type:decimal(5,2)"`
+
+	// Set on the records approved leave creates
+	LeaveRequestID *uuid.UUID `json:"leave_request_id,omitempty" gorm:"type:uuid"`
+
+	// Rostered shift the check-in was held to for late detection
+	ShiftID *uuid.UUID `json:"shift_id,omitempty" gorm:"type:uuid"`
 }
 
 // Timesheet records work hours on projects/tasks
@@ -37,10 +53,28 @@ type Timesheet struct {
 	Description    string     `json:"description"`
 	Date           time.Time  `json:"date" gorm:"not null;type:date"`
 	Hours          float64    `json:"hours" gorm:"not null;type:decimal(5,2)"`
+	StartTime      *time.Time `json:"start_time,omitempty"`
+	EndTime        *time.Time `json:"end_time,omitempty"`
 	Status         string     `json:"status" gorm:"default:'pending'"`
 	Notes          string     `json:"notes"`
 	ApprovedBy     *uuid.UUID `json:"approved_by,omitempty" gorm:"type:uuid"`
 	ApprovedAt     *time.Time `json:"approved_at"`
+	IsBillable     bool       `json:"is_billable"`
+	RateCardID     *uuid.UUID `json:"rate_card_id,omitempty" gorm:"type:uuid"`
+	BillRate       float64    `json:"bill_rate" gorm:"type:decimal(10,2)"`
+	CostRate       float64    `json:"cost_rate" gorm:"type:decimal(10,2)"`
+	BillableAmount float64    `json:"billable_amount" gorm:"type:decimal(12,2)"`
+	CostAmount     float64    `json:"cost_amount" gorm:"type:decimal(12,2)"`
+	Currency       string     `json:"currency,omitempty"`
+	InvoiceID      *uuid.UUID `json:"invoice_id,omitempty" gorm:"type:uuid"`
+
+	// Call-out entries are created from on-call incidents and kept apart from
+	// regular work in attendance reconciliation and on-call reports
+	EntryType        string     `json:"entry_type" gorm:"default:'work'"`
+	OnCallIncidentID *uuid.UUID `json:"on_call_incident_id,omitempty" gorm:"type:uuid"`
+
+	// Deleted entries are kept for the policy's retention window so they can be restored
+	DeletedAt gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"`
 }
 
 // QRCode for employee check-in/check-out
@@ -59,7 +93,7 @@ type CheckInRequest struct {
 	QRCode     string    `json:"qr_code" binding:"required"`
 	Location   string    `json:"location"`
 	DeviceInfo string    `json:"device_info"`
-	WorkMode   string    `json:"work_mode" binding:"required"`
+	WorkMode   string    `json:"work_mode" binding:"required,oneof=office remote hybrid"`
 	Timestamp  time.Time `json:"timestamp"`
 }
 
@@ -70,12 +104,20 @@ type CheckOutRequest struct {
 	Timestamp  time.Time `json:"timestamp"`
 }
 
+type BreakRequest struct {
+	QRCode    string    `json:"qr_code" binding:"required"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
 type CreateTimesheetRequest struct {
 	ProjectID   *uuid.UUID `json:"project_id"`
 	TaskID      *uuid.UUID `json:"task_id"`
 	Description string     `json:"description" binding:"required"`
 	Date        string     `json:"date" binding:"required"`
-	Hours       float64    `json:"hours" binding:"required,min=0.1,max=24"`
+	Hours       float64    `json:"hours" binding:"omitempty,min=0.1,max=24"`
+	StartTime   string     `json:"start_time" binding:"required_with=EndTime"` // "15:04:05", derives hours with end_time
+	EndTime     string     `json:"end_time" binding:"required_with=StartTime"`
+	Billable    *bool      `json:"billable"` // defaults from the project
 	Notes       string     `json:"notes"`
 }
 
@@ -89,10 +131,24 @@ type TimesheetResponse struct {
 	Description string     `json:"description"`
 	Date        time.Time  `json:"date"`
 	Hours       float64    `json:"hours"`
+	StartTime   *time.Time `json:"start_time,omitempty"`
+	EndTime     *time.Time `json:"end_time,omitempty"`
 	Status      string     `json:"status"`
 	Notes       string     `json:"notes"`
 	ApprovedBy  *uuid.UUID `json:"approved_by,omitempty"`
 	ApprovedAt  *time.Time `json:"approved_at"`
+
+	IsBillable     bool       `json:"is_billable"`
+	BillRate       float64    `json:"bill_rate"`
+	CostRate       float64    `json:"cost_rate"`
+	BillableAmount float64    `json:"billable_amount"`
+	CostAmount     float64    `json:"cost_amount"`
+	Currency       string     `json:"currency,omitempty"`
+	InvoiceID      *uuid.UUID `json:"invoice_id,omitempty"`
+	DeletedAt      *time.Time `json:"deleted_at,omitempty"`
+
+	EntryType        string     `json:"entry_type"`
+	OnCallIncidentID *uuid.UUID `json:"on_call_incident_id,omitempty"`
 }
 
 type GenerateQRRequest struct {
@@ -111,7 +167,11 @@ const (
 	WorkModeRemote = "remote"
 	WorkModeHybrid = "hybrid"
 
+	TimesheetStatusDraft    = "draft"
 	TimesheetStatusPending  = "pending"
 	TimesheetStatusApproved = "approved"
 	TimesheetStatusRejected = "rejected"
+
+	TimesheetEntryWork    = "work"
+	TimesheetEntryCallOut = "call_out"
 )
@@ -0,0 +1,30 @@
+// internal/domain/notification.go
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NotificationEvent is written to an outbox table and picked up by the
+// notification service for delivery.
+type NotificationEvent struct {
+	Base
+	OrganizationID uuid.UUID  `json:"organization_id" gorm:"type:uuid;not null"`
+	RecipientID    *uuid.UUID `json:"recipient_id,omitempty" gorm:"type:uuid"`
+	Type           string     `json:"type" gorm:"not null"`
+	SubjectType    string     `json:"subject_type"`
+	SubjectID      *uuid.UUID `json:"subject_id,omitempty" gorm:"type:uuid"`
+	Payload        string     `json:"payload" gorm:"type:jsonb"`
+	Status         string     `json:"status" gorm:"default:'pending'"`
+	ProcessedAt    *time.Time `json:"processed_at"`
+}
+
+// Constants
+const (
+	NotificationStatusPending = "pending"
+	NotificationStatusSent    = "sent"
+
+	NotificationTimesheetEscalated = "timesheet.approval_escalated"
+)
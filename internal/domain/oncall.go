@@ -0,0 +1,105 @@
+// internal/domain/oncall.go
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OnCallRotation hands on-call duty for a team from member to member, in
+// order of position. Turns last PeriodDays and hand over at HandoverTime
+// ("HH:MM" in the policy timezone), the first one on StartDate.
+type OnCallRotation struct {
+	Base
+	OrganizationID uuid.UUID              `json:"organization_id" gorm:"type:uuid;not null"`
+	TeamID         uuid.UUID              `json:"team_id" gorm:"type:uuid;not null"`
+	Name           string                 `json:"name" gorm:"not null"`
+	StartDate      time.Time              `json:"start_date" gorm:"type:date;not null"`
+	HandoverTime   string                 `json:"handover_time" gorm:"not null"`
+	PeriodDays     int                    `json:"period_days" gorm:"not null;default:7"`
+	IsActive       bool                   `json:"is_active" gorm:"default:true"`
+	Members        []OnCallRotationMember `json:"members" gorm:"foreignKey:RotationID"`
+}
+
+type OnCallRotationMember struct {
+	Base
+	RotationID uuid.UUID `json:"rotation_id" gorm:"type:uuid;not null"`
+	EmployeeID uuid.UUID `json:"employee_id" gorm:"type:uuid;not null"`
+	Position   int       `json:"position" gorm:"not null"`
+}
+
+// OnCallIncident is a call-out worked while on call. Its time is logged as
+// call-out timesheet entries, one per day it spans.
+type OnCallIncident struct {
+	Base
+	OrganizationID uuid.UUID   `json:"organization_id" gorm:"type:uuid;not null"`
+	RotationID     uuid.UUID   `json:"rotation_id" gorm:"type:uuid;not null"`
+	EmployeeID     uuid.UUID   `json:"employee_id" gorm:"type:uuid;not null"`
+	ProjectID      *uuid.UUID  `json:"project_id,omitempty" gorm:"type:uuid"`
+	StartedAt      time.Time   `json:"started_at" gorm:"not null"`
+	EndedAt        time.Time   `json:"ended_at" gorm:"not null"`
+	Description    string      `json:"description" gorm:"not null"`
+	Timesheets     []Timesheet `json:"timesheets,omitempty" gorm:"foreignKey:OnCallIncidentID"`
+}
+
+// Request/Response types
+type OnCallRotationRequest struct {
+	TeamID       uuid.UUID   `json:"team_id" binding:"required"`
+	Name         string      `json:"name" binding:"required"`
+	StartDate    string      `json:"start_date" binding:"required"`
+	HandoverTime string      `json:"handover_time" binding:"required"`
+	PeriodDays   int         `json:"period_days" binding:"omitempty,min=1,max=28"`
+	MemberIDs    []uuid.UUID `json:"member_ids" binding:"required,min=1"`
+	IsActive     *bool       `json:"is_active"`
+}
+
+// OnCallTurn is a stretch of a rotation one employee is on call for
+type OnCallTurn struct {
+	RotationID uuid.UUID `json:"rotation_id"`
+	EmployeeID uuid.UUID `json:"employee_id"`
+	StartsAt   time.Time `json:"starts_at"`
+	EndsAt     time.Time `json:"ends_at"`
+}
+
+type CreateOnCallIncidentRequest struct {
+	RotationID  uuid.UUID  `json:"rotation_id" binding:"required"`
+	ProjectID   *uuid.UUID `json:"project_id"`
+	StartedAt   time.Time  `json:"started_at" binding:"required"`
+	EndedAt     time.Time  `json:"ended_at" binding:"required"`
+	Description string     `json:"description" binding:"required"`
+}
+
+type OnCallIncidentFilter struct {
+	EmployeeID *uuid.UUID
+	RotationID *uuid.UUID
+	From       time.Time
+	To         time.Time
+}
+
+// OnCallSummary splits an employee's on-call time in a rotation into
+// standby hours and active call-out hours
+type OnCallSummary struct {
+	EmployeeID   uuid.UUID `json:"employee_id"`
+	RotationID   uuid.UUID `json:"rotation_id"`
+	TeamID       uuid.UUID `json:"team_id"`
+	OnCallHours  float64   `json:"on_call_hours"`
+	StandbyHours float64   `json:"standby_hours"`
+	CallOutHours float64   `json:"call_out_hours"`
+	CallOuts     int       `json:"call_outs"`
+}
+
+type OnCallReport struct {
+	StartDate time.Time       `json:"start_date"`
+	EndDate   time.Time       `json:"end_date"`
+	Rows      []OnCallSummary `json:"rows"`
+	Totals    OnCallSummary   `json:"totals"`
+}
+
+// Constants
+const (
+	DefaultOnCallPeriodDays = 7
+
+	// MaxOnCallIncidentHours bounds a single call-out
+	MaxOnCallIncidentHours = 24
+)
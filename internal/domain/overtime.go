@@ -0,0 +1,99 @@
+// internal/domain/overtime.go
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OvertimeRule pays worked time at Multiplier. Daily and weekly rules apply
+// to the hours beyond ThresholdHours, weekend and holiday rules to every hour
+// worked on such a day and night rules to the hours between NightStart and
+// NightEnd ("HH:MM" in the policy timezone). Rules do not stack: each hour is
+// paid at the highest multiplier that applies to it.
+type OvertimeRule struct {
+	Base
+	OrganizationID uuid.UUID `json:"organization_id" gorm:"type:uuid;not null"`
+	Name           string    `json:"name" gorm:"not null"`
+	Kind           string    `json:"kind" gorm:"not null"`
+	ThresholdHours float64   `json:"threshold_hours" gorm:"type:decimal(5,2)"`
+	Multiplier     float64   `json:"multiplier" gorm:"type:decimal(4,2);not null"`
+	NightStart     string    `json:"night_start,omitempty"`
+	NightEnd       string    `json:"night_end,omitempty"`
+	IsActive       bool      `json:"is_active"`
+}
+
+// OvertimeEntry is the worked time of an employee on a day paid at one
+// multiplier. Entries are derived from attendance or approved timesheets
+// and are recomputed when those change.
+type OvertimeEntry struct {
+	Base
+	OrganizationID uuid.UUID `json:"organization_id" gorm:"type:uuid;not null"`
+	EmployeeID     uuid.UUID `json:"employee_id" gorm:"type:uuid;not null"`
+	Date           time.Time `json:"date" gorm:"type:date;not null"`
+	Multiplier     float64   `json:"multiplier" gorm:"type:decimal(4,2);not null"`
+	Hours          float64   `json:"hours" gorm:"type:decimal(5,2);not null"`
+	Source         string    `json:"source" gorm:"not null"`
+}
+
+// Request/Response types
+type OvertimeRuleRequest struct {
+	Name           string  `json:"name" binding:"required"`
+	Kind           string  `json:"kind" binding:"required,oneof=daily weekly weekend holiday night"`
+	ThresholdHours float64 `json:"threshold_hours" binding:"min=0,max=168"`
+	Multiplier     float64 `json:"multiplier" binding:"required,gt=1,max=10"`
+	NightStart     string  `json:"night_start"`
+	NightEnd       string  `json:"night_end"`
+	IsActive       *bool   `json:"is_active"`
+}
+
+type RecomputeOvertimeRequest struct {
+	EmployeeID *uuid.UUID `json:"employee_id"`
+	StartDate  string     `json:"start_date" binding:"required"`
+	EndDate    string     `json:"end_date" binding:"required"`
+}
+
+type RecomputeOvertimeResult struct {
+	Employees int `json:"employees"`
+}
+
+// OvertimeBucket totals the hours paid at one multiplier. Label is "regular"
+// for straight time and "ot_<multiplier>" otherwise, e.g. "ot_1.5".
+type OvertimeBucket struct {
+	Label      string  `json:"label"`
+	Multiplier float64 `json:"multiplier"`
+	Hours      float64 `json:"hours"`
+}
+
+type OvertimeDay struct {
+	Date       time.Time        `json:"date"`
+	TotalHours float64          `json:"total_hours"`
+	Buckets    []OvertimeBucket `json:"buckets"`
+}
+
+// OvertimeSummary is the classified worked time of an employee in a period
+type OvertimeSummary struct {
+	EmployeeID    uuid.UUID        `json:"employee_id"`
+	StartDate     time.Time        `json:"start_date"`
+	EndDate       time.Time        `json:"end_date"`
+	TotalHours    float64          `json:"total_hours"`
+	RegularHours  float64          `json:"regular_hours"`
+	OvertimeHours float64          `json:"overtime_hours"`
+	Buckets       []OvertimeBucket `json:"buckets"`
+	Days          []OvertimeDay    `json:"days,omitempty"`
+}
+
+// Constants
+const (
+	OvertimeRuleDaily   = "daily"
+	OvertimeRuleWeekly  = "weekly"
+	OvertimeRuleWeekend = "weekend"
+	OvertimeRuleHoliday = "holiday"
+	OvertimeRuleNight   = "night"
+
+	OvertimeSourceAttendance = "attendance"
+	OvertimeSourceTimesheets = "timesheets"
+
+	OvertimeLabelRegular = "regular"
+)
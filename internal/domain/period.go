@@ -0,0 +1,52 @@
+// internal/domain/period.go
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PayrollPeriod is a date range an organization has closed for payroll. No
+// attendance or timesheet in a closed period can change until it is reopened.
+type PayrollPeriod struct {
+	Base
+	OrganizationID uuid.UUID  `json:"organization_id" gorm:"type:uuid;not null"`
+	StartDate      time.Time  `json:"start_date" gorm:"type:date;not null"`
+	EndDate        time.Time  `json:"end_date" gorm:"type:date;not null"`
+	Status         string     `json:"status" gorm:"default:'closed'"`
+	ClosedBy       uuid.UUID  `json:"closed_by" gorm:"type:uuid;not null"`
+	ClosedAt       time.Time  `json:"closed_at" gorm:"not null"`
+	ReopenedBy     *uuid.UUID `json:"reopened_by,omitempty" gorm:"type:uuid"`
+	ReopenedAt     *time.Time `json:"reopened_at,omitempty"`
+	ReopenReason   string     `json:"reopen_reason,omitempty"`
+}
+
+// PayrollPeriodEvent audits a close or reopen of a payroll period
+type PayrollPeriodEvent struct {
+	Base
+	PeriodID uuid.UUID `json:"period_id" gorm:"type:uuid;not null"`
+	Action   string    `json:"action" gorm:"not null"`
+	ActorID  uuid.UUID `json:"actor_id" gorm:"type:uuid;not null"`
+	Reason   string    `json:"reason,omitempty"`
+}
+
+// Request/Response types
+type ClosePeriodRequest struct {
+	StartDate string `json:"start_date" binding:"required"`
+	EndDate   string `json:"end_date" binding:"required"`
+	Reason    string `json:"reason"`
+}
+
+type ReopenPeriodRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// Constants
+const (
+	PeriodStatusClosed   = "closed"
+	PeriodStatusReopened = "reopened"
+
+	PeriodActionClose  = "close"
+	PeriodActionReopen = "reopen"
+)
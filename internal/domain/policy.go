@@ -0,0 +1,158 @@
+// internal/domain/policy.go
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TimesheetPolicy holds the timesheet rules of an organization
+type TimesheetPolicy struct {
+	Base
+	OrganizationID      uuid.UUID  `json:"organization_id" gorm:"type:uuid;not null;unique"`
+	MaxBackdateDays     int        `json:"max_backdate_days" gorm:"not null"`
+	AllowFutureDates    bool       `json:"allow_future_dates"`
+	LockApprovedPeriods bool       `json:"lock_approved_periods"`
+	LockedUntil         *time.Time `json:"locked_until" gorm:"type:date"`
+
+	// Hour caps; zero means no limit
+	MaxDailyHours          float64 `json:"max_daily_hours" gorm:"type:decimal(5,2)"`
+	MaxWeeklyHours         float64 `json:"max_weekly_hours" gorm:"type:decimal(6,2)"`
+	RequireAttendanceMatch bool    `json:"require_attendance_match"`
+	AttendanceTolerance    float64 `json:"attendance_tolerance" gorm:"type:decimal(4,2)"`
+	HoursEnforcement       string  `json:"hours_enforcement" gorm:"default:'warn'"`
+
+	// Reject submitting drafts whose daily hours do not match attendance
+	BlockUnreconciledSubmission bool `json:"block_unreconciled_submission"`
+
+	// Whether overtime is computed from attendance or approved timesheets
+	OvertimeSource string `json:"overtime_source" gorm:"default:'attendance'"`
+
+	// Timer settings
+	Timezone             string `json:"timezone" gorm:"default:'UTC'"`
+	TimerRoundingMinutes int    `json:"timer_rounding_minutes"`
+	TimerRoundingMode    string `json:"timer_rounding_mode" gorm:"default:'nearest'"`
+
+	// Days a deleted timesheet is kept before it can be purged
+	DeletedRetentionDays int `json:"deleted_retention_days" gorm:"default:30"`
+
+	// Attendance punches are rounded to PunchRoundingMinutes (zero disables
+	// rounding), and LunchDeductionMinutes are deducted from days longer than
+	// LunchThresholdHours without a punched break
+	PunchRoundingMinutes  int     `json:"punch_rounding_minutes"`
+	PunchRoundingMode     string  `json:"punch_rounding_mode" gorm:"default:'nearest'"`
+	LunchDeductionMinutes int     `json:"lunch_deduction_minutes"`
+	LunchThresholdHours   float64 `json:"lunch_threshold_hours" gorm:"type:decimal(4,2);default:6"`
+
+	// First day of the week (0 = Sunday) and weekend days, used for weekly
+	// periods, caps and overtime and for telling working days apart
+	WeekStart   int      `json:"week_start"`
+	WeekendDays Weekdays `json:"weekend_days" gorm:"type:varchar(20);default:'6,0'"`
+
+	// Hours expected on a full working day, before holidays and leave
+	ExpectedDailyHours float64 `json:"expected_daily_hours" gorm:"type:decimal(4,2);default:8"`
+
+	// Minutes after the start of a rostered shift before a check-in is late
+	LateGraceMinutes int `json:"late_grace_minutes"`
+}
+
+// Request/Response types
+
+// TimesheetPolicyRequest changes the fields that are set and leaves the
+// others as they are. An empty locked_until clears the lock date; empty
+// modes and zero defaults-backed values restore the defaults.
+type TimesheetPolicyRequest struct {
+	MaxBackdateDays     *int    `json:"max_backdate_days" binding:"omitempty,min=0"`
+	AllowFutureDates    *bool   `json:"allow_future_dates"`
+	LockApprovedPeriods *bool   `json:"lock_approved_periods"`
+	LockedUntil         *string `json:"locked_until"`
+
+	MaxDailyHours          *float64 `json:"max_daily_hours" binding:"omitempty,min=0,max=24"`
+	MaxWeeklyHours         *float64 `json:"max_weekly_hours" binding:"omitempty,min=0,max=168"`
+	RequireAttendanceMatch *bool    `json:"require_attendance_match"`
+	AttendanceTolerance    *float64 `json:"attendance_tolerance" binding:"omitempty,min=0"`
+	HoursEnforcement       *string  `json:"hours_enforcement" binding:"omitempty,oneof=warn reject ''"`
+
+	BlockUnreconciledSubmission *bool `json:"block_unreconciled_submission"`
+
+	OvertimeSource *string `json:"overtime_source" binding:"omitempty,oneof=attendance timesheets ''"`
+
+	Timezone             *string `json:"timezone"`
+	TimerRoundingMinutes *int    `json:"timer_rounding_minutes" binding:"omitempty,min=0,max=60"`
+	TimerRoundingMode    *string `json:"timer_rounding_mode" binding:"omitempty,oneof=nearest up down ''"`
+
+	DeletedRetentionDays *int `json:"deleted_retention_days" binding:"omitempty,min=0"`
+
+	PunchRoundingMinutes  *int     `json:"punch_rounding_minutes" binding:"omitempty,min=0,max=60"`
+	PunchRoundingMode     *string  `json:"punch_rounding_mode" binding:"omitempty,oneof=nearest favor_employee ''"`
+	LunchDeductionMinutes *int     `json:"lunch_deduction_minutes" binding:"omitempty,min=0,max=120"`
+	LunchThresholdHours   *float64 `json:"lunch_threshold_hours" binding:"omitempty,min=0,max=24"`
+
+	WeekStart   *int  `json:"week_start" binding:"omitempty,min=0,max=6"`
+	WeekendDays []int `json:"weekend_days" binding:"omitempty,max=6,dive,min=0,max=6"`
+
+	ExpectedDailyHours *float64 `json:"expected_daily_hours" binding:"omitempty,min=0,max=24"`
+
+	LateGraceMinutes *int `json:"late_grace_minutes" binding:"omitempty,min=0,max=240"`
+}
+
+// TimesheetWarning describes a policy rule an entry breaks without being rejected
+type TimesheetWarning struct {
+	Rule    string  `json:"rule"`
+	Message string  `json:"message"`
+	Limit   float64 `json:"limit"`
+	Actual  float64 `json:"actual"`
+}
+
+// TimesheetResult is a saved timesheet together with any policy warnings
+type TimesheetResult struct {
+	TimesheetResponse
+	Warnings []TimesheetWarning `json:"warnings,omitempty"`
+}
+
+// Constants
+const (
+	// DefaultMaxBackdateDays applies to organizations without a timesheet policy
+	DefaultMaxBackdateDays = 30
+	DefaultMaxDailyHours   = 24
+
+	HoursEnforcementWarn   = "warn"
+	HoursEnforcementReject = "reject"
+
+	DefaultTimezone = "UTC"
+
+	RoundingModeNearest = "nearest"
+	RoundingModeUp      = "up"
+	RoundingModeDown    = "down"
+
+	DefaultDeletedRetentionDays = 30
+
+	PunchRoundingNearest       = "nearest"
+	PunchRoundingFavorEmployee = "favor_employee"
+
+	DefaultLunchThresholdHours = 6
+
+	DefaultExpectedDailyHours = 8
+)
+
+// DefaultTimesheetPolicy returns the policy used until an organization configures its own
+func DefaultTimesheetPolicy(orgID uuid.UUID) *TimesheetPolicy {
+	return &TimesheetPolicy{
+		OrganizationID:      orgID,
+		MaxBackdateDays:     DefaultMaxBackdateDays,
+		AllowFutureDates:    false,
+		LockApprovedPeriods: false,
+		MaxDailyHours:       DefaultMaxDailyHours,
+		HoursEnforcement:    HoursEnforcementWarn,
+		Timezone:            DefaultTimezone,
+		TimerRoundingMode:   RoundingModeNearest,
+		OvertimeSource:      OvertimeSourceAttendance,
+
+		DeletedRetentionDays: DefaultDeletedRetentionDays,
+		PunchRoundingMode:    PunchRoundingNearest,
+		LunchThresholdHours:  DefaultLunchThresholdHours,
+		WeekendDays:          DefaultWeekendDays,
+		ExpectedDailyHours:   DefaultExpectedDailyHours,
+	}
+}
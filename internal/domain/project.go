@@ -0,0 +1,71 @@
+// internal/domain/project.go
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Project is a unit of client or internal work that time is logged against
+type Project struct {
+	Base
+	OrganizationID uuid.UUID       `json:"organization_id" gorm:"type:uuid;not null"`
+	Name           string          `json:"name" gorm:"not null"`
+	Code           string          `json:"code" gorm:"not null"`
+	Client         string          `json:"client"`
+	Status         string          `json:"status" gorm:"default:'active'"`
+	StartDate      *time.Time      `json:"start_date" gorm:"type:date"`
+	EndDate        *time.Time      `json:"end_date" gorm:"type:date"`
+	IsBillable     bool            `json:"is_billable"`
+	Members        []ProjectMember `json:"members,omitempty" gorm:"foreignKey:ProjectID"`
+}
+
+// Task is a piece of work within a project
+type Task struct {
+	Base
+	ProjectID uuid.UUID `json:"project_id" gorm:"type:uuid;not null"`
+	Name      string    `json:"name" gorm:"not null"`
+	Code      string    `json:"code"`
+	Status    string    `json:"status" gorm:"default:'active'"`
+}
+
+// ProjectMember assigns an employee to a project
+type ProjectMember struct {
+	Base
+	ProjectID  uuid.UUID `json:"project_id" gorm:"type:uuid;not null"`
+	EmployeeID uuid.UUID `json:"employee_id" gorm:"type:uuid;not null"`
+	Role       string    `json:"role"`
+}
+
+// Request/Response types
+type ProjectRequest struct {
+	Name      string `json:"name" binding:"required"`
+	Code      string `json:"code" binding:"required"`
+	Client    string `json:"client"`
+	Status    string `json:"status" binding:"omitempty,oneof=active on_hold closed"`
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+	Billable  *bool  `json:"billable"` // defaults to true
+}
+
+type TaskRequest struct {
+	Name   string `json:"name" binding:"required"`
+	Code   string `json:"code"`
+	Status string `json:"status" binding:"omitempty,oneof=active closed"`
+}
+
+type ProjectMemberRequest struct {
+	EmployeeID uuid.UUID `json:"employee_id" binding:"required"`
+	Role       string    `json:"role"`
+}
+
+// Constants
+const (
+	ProjectStatusActive = "active"
+	ProjectStatusOnHold = "on_hold"
+	ProjectStatusClosed = "closed"
+
+	TaskStatusActive = "active"
+	TaskStatusClosed = "closed"
+)
@@ -0,0 +1,35 @@
+// internal/domain/rate.go
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RateCard sets the bill and cost rate for time logged by an employee, a
+// project role or on a project. Rate cards with no key are organization defaults.
+type RateCard struct {
+	Base
+	OrganizationID uuid.UUID  `json:"organization_id" gorm:"type:uuid;not null"`
+	ProjectID      *uuid.UUID `json:"project_id,omitempty" gorm:"type:uuid"`
+	Role           string     `json:"role,omitempty"`
+	EmployeeID     *uuid.UUID `json:"employee_id,omitempty" gorm:"type:uuid"`
+	BillRate       float64    `json:"bill_rate" gorm:"type:decimal(10,2);not null"`
+	CostRate       float64    `json:"cost_rate" gorm:"type:decimal(10,2);not null"`
+	Currency       string     `json:"currency" gorm:"not null"`
+	EffectiveFrom  time.Time  `json:"effective_from" gorm:"type:date;not null"`
+	EffectiveTo    *time.Time `json:"effective_to" gorm:"type:date"`
+}
+
+// Request/Response types
+type RateCardRequest struct {
+	ProjectID     *uuid.UUID `json:"project_id"`
+	Role          string     `json:"role"`
+	EmployeeID    *uuid.UUID `json:"employee_id"`
+	BillRate      float64    `json:"bill_rate" binding:"min=0"`
+	CostRate      float64    `json:"cost_rate" binding:"min=0"`
+	Currency      string     `json:"currency" binding:"required,len=3"`
+	EffectiveFrom string     `json:"effective_from" binding:"required"`
+	EffectiveTo   string     `json:"effective_to"`
+}
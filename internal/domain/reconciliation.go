@@ -0,0 +1,63 @@
+// internal/domain/reconciliation.go
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DailyTimesheetHours is the total logged by an employee on one day
+type DailyTimesheetHours struct {
+	EmployeeID uuid.UUID `json:"employee_id"`
+	Date       time.Time `json:"date"`
+	Hours      float64   `json:"hours"`
+}
+
+// ReconciliationDay compares an employee's logged hours on a day with the
+// time worked between check-in and check-out.
+type ReconciliationDay struct {
+	EmployeeID     uuid.UUID `json:"employee_id"`
+	Date           time.Time `json:"date"`
+	TimesheetHours float64   `json:"timesheet_hours"`
+	AttendedHours  float64   `json:"attended_hours"`
+	Difference     float64   `json:"difference"`
+	Status         string    `json:"status"`
+}
+
+type ReconciliationSummary struct {
+	Days              int     `json:"days"`
+	Matched           int     `json:"matched"`
+	OverLogged        int     `json:"over_logged"`
+	UnderLogged       int     `json:"under_logged"`
+	MissingAttendance int     `json:"missing_attendance"`
+	MissingTimesheet  int     `json:"missing_timesheet"`
+	TimesheetHours    float64 `json:"timesheet_hours"`
+	AttendedHours     float64 `json:"attended_hours"`
+}
+
+// Request/Response types
+type ReconciliationFilter struct {
+	EmployeeID        *uuid.UUID
+	StartDate         time.Time
+	EndDate           time.Time
+	Tolerance         *float64
+	DiscrepanciesOnly bool
+}
+
+type ReconciliationReport struct {
+	StartDate time.Time             `json:"start_date"`
+	EndDate   time.Time             `json:"end_date"`
+	Tolerance float64               `json:"tolerance"`
+	Summary   ReconciliationSummary `json:"summary"`
+	Days      []ReconciliationDay   `json:"days"`
+}
+
+// Constants
+const (
+	ReconciliationMatched           = "matched"
+	ReconciliationOverLogged        = "over_logged"
+	ReconciliationUnderLogged       = "under_logged"
+	ReconciliationMissingAttendance = "missing_attendance"
+	ReconciliationMissingTimesheet  = "missing_timesheet"
+)
@@ -0,0 +1,108 @@
+// internal/domain/remote.go
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RemoteWorkPolicy holds an organization's rules for remote check-ins.
+// MaxRemoteDaysPerWeek counts remote days in the employee's work week (zero
+// means no limit); remote days on PreApprovalDays, or on any day when
+// RequireApproval is set, need an approved request. Breaches are flagged
+// to managers, or reject the check-in when Enforcement is "reject".
+type RemoteWorkPolicy struct {
+	Base
+	OrganizationID       uuid.UUID `json:"organization_id" gorm:"type:uuid;not null;unique"`
+	MaxRemoteDaysPerWeek int       `json:"max_remote_days_per_week"`
+	PreApprovalDays      Weekdays  `json:"pre_approval_days" gorm:"type:varchar(20);default:''"`
+	RequireApproval      bool      `json:"require_approval"`
+	Enforcement          string    `json:"enforcement" gorm:"default:'flag'"`
+}
+
+// RemoteWorkRequest asks to work remotely on the days of a range
+type RemoteWorkRequest struct {
+	Base
+	OrganizationID uuid.UUID  `json:"organization_id" gorm:"type:uuid;not null"`
+	EmployeeID     uuid.UUID  `json:"employee_id" gorm:"type:uuid;not null"`
+	StartDate      time.Time  `json:"start_date" gorm:"type:date;not null"`
+	EndDate        time.Time  `json:"end_date" gorm:"type:date;not null"`
+	Reason         string     `json:"reason"`
+	Status         string     `json:"status" gorm:"default:'pending'"`
+	ReviewedBy     *uuid.UUID `json:"reviewed_by,omitempty" gorm:"type:uuid"`
+	ReviewedAt     *time.Time `json:"reviewed_at,omitempty"`
+	ReviewNote     string     `json:"review_note,omitempty"`
+}
+
+// RemoteWorkViolation flags a remote check-in that breaks the policy
+type RemoteWorkViolation struct {
+	Base
+	OrganizationID uuid.UUID  `json:"organization_id" gorm:"type:uuid;not null"`
+	EmployeeID     uuid.UUID  `json:"employee_id" gorm:"type:uuid;not null"`
+	AttendanceID   *uuid.UUID `json:"attendance_id,omitempty" gorm:"type:uuid"`
+	Date           time.Time  `json:"date" gorm:"type:date;not null"`
+	Rule           string     `json:"rule" gorm:"not null"`
+	Message        string     `json:"message"`
+	Limit          int        `json:"limit,omitempty"`
+	Actual         int        `json:"actual,omitempty"`
+}
+
+// Request/Response types
+type RemoteWorkPolicyRequest struct {
+	MaxRemoteDaysPerWeek int    `json:"max_remote_days_per_week" binding:"min=0,max=7"`
+	PreApprovalDays      []int  `json:"pre_approval_days" binding:"omitempty,max=7,dive,min=0,max=6"`
+	RequireApproval      bool   `json:"require_approval"`
+	Enforcement          string `json:"enforcement" binding:"omitempty,oneof=flag reject"`
+}
+
+type CreateRemoteWorkRequest struct {
+	StartDate string `json:"start_date" binding:"required"`
+	EndDate   string `json:"end_date"`
+	Reason    string `json:"reason"`
+}
+
+type ReviewRemoteWorkRequest struct {
+	Note string `json:"note"`
+}
+
+type RemoteWorkRequestFilter struct {
+	EmployeeID *uuid.UUID
+	Status     string
+	StartDate  time.Time
+	EndDate    time.Time
+}
+
+type RemoteWorkViolationFilter struct {
+	EmployeeID *uuid.UUID
+	StartDate  time.Time
+	EndDate    time.Time
+}
+
+// Constants
+const (
+	RemoteEnforcementFlag   = "flag"
+	RemoteEnforcementReject = "reject"
+
+	RemoteRequestPending   = "pending"
+	RemoteRequestApproved  = "approved"
+	RemoteRequestRejected  = "rejected"
+	RemoteRequestCancelled = "cancelled"
+
+	RemoteRuleMaxDays     = "max_remote_days"
+	RemoteRulePreApproval = "pre_approval_required"
+
+	NotificationRemoteWorkRequested = "remote_work.requested"
+	NotificationRemoteWorkReviewed  = "remote_work.reviewed"
+	NotificationRemoteWorkViolation = "remote_work.violation"
+)
+
+// DefaultRemoteWorkPolicy returns the policy used until an organization
+// configures its own: remote work is unrestricted.
+func DefaultRemoteWorkPolicy(orgID uuid.UUID) *RemoteWorkPolicy {
+	return &RemoteWorkPolicy{
+		OrganizationID:  orgID,
+		PreApprovalDays: Weekdays{},
+		Enforcement:     RemoteEnforcementFlag,
+	}
+}
@@ -0,0 +1,111 @@
+// internal/domain/roster.go
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Roster is a week of shifts of an organization, optionally for a single
+// team. Managers edit rosters as drafts; once published, employees see the
+// shifts and check-ins are held to their start times.
+type Roster struct {
+	Base
+	OrganizationID uuid.UUID  `json:"organization_id" gorm:"type:uuid;not null"`
+	TeamID         *uuid.UUID `json:"team_id,omitempty" gorm:"type:uuid"`
+	Name           string     `json:"name" gorm:"not null"`
+	WeekStart      time.Time  `json:"week_start" gorm:"type:date;not null"`
+	Status         string     `json:"status" gorm:"default:'draft'"`
+	PublishedAt    *time.Time `json:"published_at,omitempty"`
+	PublishedBy    *uuid.UUID `json:"published_by,omitempty" gorm:"type:uuid"`
+	Shifts         []Shift    `json:"shifts,omitempty" gorm:"foreignKey:RosterID"`
+}
+
+// Shift is a scheduled stretch of work starting on Date. Shifts without an
+// employee are open and can be claimed once their roster is published.
+type Shift struct {
+	Base
+	OrganizationID uuid.UUID  `json:"organization_id" gorm:"type:uuid;not null"`
+	RosterID       uuid.UUID  `json:"roster_id" gorm:"type:uuid;not null"`
+	EmployeeID     *uuid.UUID `json:"employee_id,omitempty" gorm:"type:uuid"`
+	Date           time.Time  `json:"date" gorm:"type:date;not null"`
+	StartsAt       time.Time  `json:"starts_at" gorm:"not null"`
+	EndsAt         time.Time  `json:"ends_at" gorm:"not null"`
+	Role           string     `json:"role"`
+	Notes          string     `json:"notes"`
+}
+
+// ShiftSwapRequest asks a manager to hand the requester's shift over to a
+// colleague, or to exchange it for one of the colleague's shifts when
+// TargetShiftID is set
+type ShiftSwapRequest struct {
+	Base
+	OrganizationID   uuid.UUID  `json:"organization_id" gorm:"type:uuid;not null"`
+	ShiftID          uuid.UUID  `json:"shift_id" gorm:"type:uuid;not null"`
+	RequesterID      uuid.UUID  `json:"requester_id" gorm:"type:uuid;not null"`
+	TargetEmployeeID uuid.UUID  `json:"target_employee_id" gorm:"type:uuid;not null"`
+	TargetShiftID    *uuid.UUID `json:"target_shift_id,omitempty" gorm:"type:uuid"`
+	Reason           string     `json:"reason"`
+	Status           string     `json:"status" gorm:"default:'pending'"`
+	ReviewedBy       *uuid.UUID `json:"reviewed_by,omitempty" gorm:"type:uuid"`
+	ReviewedAt       *time.Time `json:"reviewed_at,omitempty"`
+	ReviewNote       string     `json:"review_note,omitempty"`
+}
+
+// Request/Response types
+type RosterRequest struct {
+	Name      string     `json:"name" binding:"required"`
+	TeamID    *uuid.UUID `json:"team_id"`
+	WeekStart string     `json:"week_start" binding:"required"`
+}
+
+// ShiftRequest schedules a shift; times are "HH:MM" in the policy timezone
+// and a shift ending at or before its start ends on the next day
+type ShiftRequest struct {
+	EmployeeID *uuid.UUID `json:"employee_id"`
+	Date       string     `json:"date" binding:"required"`
+	StartTime  string     `json:"start_time" binding:"required"`
+	EndTime    string     `json:"end_time" binding:"required"`
+	Role       string     `json:"role"`
+	Notes      string     `json:"notes"`
+}
+
+type CreateShiftSwapRequest struct {
+	ShiftID          uuid.UUID  `json:"shift_id" binding:"required"`
+	TargetEmployeeID uuid.UUID  `json:"target_employee_id" binding:"required"`
+	TargetShiftID    *uuid.UUID `json:"target_shift_id"`
+	Reason           string     `json:"reason"`
+}
+
+type ReviewShiftSwapRequest struct {
+	Note string `json:"note"`
+}
+
+type ShiftFilter struct {
+	EmployeeID    *uuid.UUID
+	OpenOnly      bool
+	PublishedOnly bool
+	StartDate     time.Time
+	EndDate       time.Time
+}
+
+type ShiftSwapFilter struct {
+	EmployeeID *uuid.UUID
+	Status     string
+}
+
+// Constants
+const (
+	RosterStatusDraft     = "draft"
+	RosterStatusPublished = "published"
+
+	ShiftSwapPending   = "pending"
+	ShiftSwapApproved  = "approved"
+	ShiftSwapRejected  = "rejected"
+	ShiftSwapCancelled = "cancelled"
+
+	NotificationRosterPublished    = "roster.published"
+	NotificationShiftSwapRequested = "shift_swap.requested"
+	NotificationShiftSwapReviewed  = "shift_swap.reviewed"
+)
@@ -0,0 +1,31 @@
+// internal/domain/team.go
+package domain
+
+import "github.com/google/uuid"
+
+// Team groups employees of an organization. Members of teams that are
+// always remote may check in remotely without a request.
+type Team struct {
+	Base
+	OrganizationID uuid.UUID `json:"organization_id" gorm:"type:uuid;not null"`
+	Name           string    `json:"name" gorm:"not null"`
+	AlwaysRemote   bool      `json:"always_remote"`
+}
+
+// EmployeeTeam assigns an employee to a team
+type EmployeeTeam struct {
+	Base
+	OrganizationID uuid.UUID `json:"organization_id" gorm:"type:uuid;not null"`
+	EmployeeID     uuid.UUID `json:"employee_id" gorm:"type:uuid;not null"`
+	TeamID         uuid.UUID `json:"team_id" gorm:"type:uuid;not null"`
+}
+
+// Request/Response types
+type TeamRequest struct {
+	Name         string `json:"name" binding:"required"`
+	AlwaysRemote bool   `json:"always_remote"`
+}
+
+type EmployeeTeamRequest struct {
+	TeamID *uuid.UUID `json:"team_id"`
+}
@@ -0,0 +1,74 @@
+// internal/domain/template.go
+package domain
+
+import (
+	"github.com/google/uuid"
+)
+
+// TimesheetTemplate is a named set of recurring entries an employee can
+// apply to a week, e.g. daily stand-ups or a support rotation.
+type TimesheetTemplate struct {
+	Base
+	OrganizationID uuid.UUID               `json:"organization_id" gorm:"type:uuid;not null"`
+	EmployeeID     uuid.UUID               `json:"employee_id" gorm:"type:uuid;not null"`
+	Name           string                  `json:"name" gorm:"not null"`
+	Lines          []TimesheetTemplateLine `json:"lines" gorm:"foreignKey:TemplateID"`
+}
+
+// TimesheetTemplateLine is an entry of a template on a day of the week
+// (0 = Sunday). Times use the "15:04:05" format.
+type TimesheetTemplateLine struct {
+	Base
+	TemplateID  uuid.UUID  `json:"template_id" gorm:"type:uuid;not null"`
+	Weekday     int        `json:"weekday" gorm:"not null"`
+	ProjectID   *uuid.UUID `json:"project_id,omitempty" gorm:"type:uuid"`
+	TaskID      *uuid.UUID `json:"task_id,omitempty" gorm:"type:uuid"`
+	Description string     `json:"description" gorm:"not null"`
+	Hours       float64    `json:"hours" gorm:"type:decimal(5,2)"`
+	StartTime   string     `json:"start_time,omitempty"`
+	EndTime     string     `json:"end_time,omitempty"`
+	Billable    *bool      `json:"billable,omitempty"`
+	Notes       string     `json:"notes"`
+}
+
+// Request/Response types
+type TimesheetTemplateRequest struct {
+	Name  string                         `json:"name" binding:"required"`
+	Lines []TimesheetTemplateLineRequest `json:"lines" binding:"required,min=1,dive"`
+}
+
+type TimesheetTemplateLineRequest struct {
+	Weekday     int        `json:"weekday" binding:"min=0,max=6"`
+	ProjectID   *uuid.UUID `json:"project_id"`
+	TaskID      *uuid.UUID `json:"task_id"`
+	Description string     `json:"description" binding:"required"`
+	Hours       float64    `json:"hours" binding:"omitempty,min=0.1,max=24"`
+	StartTime   string     `json:"start_time" binding:"required_with=EndTime"`
+	EndTime     string     `json:"end_time" binding:"required_with=StartTime"`
+	Billable    *bool      `json:"billable"`
+	Notes       string     `json:"notes"`
+}
+
+// ApplyTemplateRequest applies a template to the week containing WeekStart
+type ApplyTemplateRequest struct {
+	WeekStart string `json:"week_start" binding:"required"`
+}
+
+// CopyPreviousWeekRequest copies the entries of the week before the week
+// containing WeekStart, optionally without their hours.
+type CopyPreviousWeekRequest struct {
+	WeekStart string `json:"week_start" binding:"required"`
+	ZeroHours bool   `json:"zero_hours"`
+}
+
+// BulkTimesheetResult reports the entries created from a template or a
+// previous week, and the ones that failed validation.
+type BulkTimesheetResult struct {
+	Created []TimesheetResult `json:"created"`
+	Failed  []FailedTimesheet `json:"failed"`
+}
+
+type FailedTimesheet struct {
+	Request CreateTimesheetRequest `json:"request"`
+	Error   interface{}            `json:"error"`
+}
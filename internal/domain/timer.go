@@ -0,0 +1,52 @@
+// internal/domain/timer.go
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Timer tracks live work on a project/task until it is stopped and turned into timesheet entries
+type Timer struct {
+	Base
+	OrganizationID uuid.UUID      `json:"organization_id" gorm:"type:uuid;not null"`
+	EmployeeID     uuid.UUID      `json:"employee_id" gorm:"type:uuid;not null"`
+	ProjectID      *uuid.UUID     `json:"project_id,omitempty" gorm:"type:uuid"`
+	TaskID         *uuid.UUID     `json:"task_id,omitempty" gorm:"type:uuid"`
+	Description    string         `json:"description"`
+	Status         string         `json:"status" gorm:"default:'running'"`
+	StartedAt      time.Time      `json:"started_at" gorm:"not null"`
+	StoppedAt      *time.Time     `json:"stopped_at"`
+	Segments       []TimerSegment `json:"segments" gorm:"foreignKey:TimerID"`
+}
+
+// TimerSegment is one uninterrupted run of a timer between start/resume and pause/stop
+type TimerSegment struct {
+	Base
+	TimerID   uuid.UUID  `json:"timer_id" gorm:"type:uuid;not null"`
+	StartedAt time.Time  `json:"started_at" gorm:"not null"`
+	EndedAt   *time.Time `json:"ended_at"`
+}
+
+// Request/Response types
+type StartTimerRequest struct {
+	ProjectID   *uuid.UUID `json:"project_id"`
+	TaskID      *uuid.UUID `json:"task_id"`
+	Description string     `json:"description" binding:"required"`
+}
+
+type StopTimerResponse struct {
+	Timer      *Timer            `json:"timer"`
+	Timesheets []TimesheetResult `json:"timesheets"`
+}
+
+// Constants
+const (
+	TimerStatusRunning = "running"
+	TimerStatusPaused  = "paused"
+	TimerStatusStopped = "stopped"
+
+	// Discarded timers were stopped without logging their time
+	TimerStatusDiscarded = "discarded"
+)
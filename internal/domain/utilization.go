@@ -0,0 +1,61 @@
+// internal/domain/utilization.go
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TimesheetHoursTotal is the regular work an employee logged on a project,
+// nil for entries without one
+type TimesheetHoursTotal struct {
+	EmployeeID    uuid.UUID  `json:"employee_id"`
+	ProjectID     *uuid.UUID `json:"project_id,omitempty"`
+	Hours         float64    `json:"hours"`
+	BillableHours float64    `json:"billable_hours"`
+}
+
+// UtilizationRow compares the hours expected of a group of employees with
+// the hours they attended, logged and could bill. Rates are percentages and
+// null when there is nothing to compare against. Project rows get each
+// employee's expected and attended hours in proportion to the share of the
+// employee's logged hours that went to the project.
+type UtilizationRow struct {
+	EmployeeID          *uuid.UUID `json:"employee_id,omitempty"`
+	TeamID              *uuid.UUID `json:"team_id,omitempty"`
+	ProjectID           *uuid.UUID `json:"project_id,omitempty"`
+	Employees           int        `json:"employees"`
+	ExpectedHours       float64    `json:"expected_hours"`
+	AttendedHours       float64    `json:"attended_hours"`
+	TimesheetHours      float64    `json:"timesheet_hours"`
+	BillableHours       float64    `json:"billable_hours"`
+	AttendanceRate      *float64   `json:"attendance_rate"`
+	Utilization         *float64   `json:"utilization"`
+	BillableUtilization *float64   `json:"billable_utilization"`
+	BillableRatio       *float64   `json:"billable_ratio"`
+}
+
+// Request/Response types
+type UtilizationFilter struct {
+	GroupBy    string
+	EmployeeID *uuid.UUID
+	TeamID     *uuid.UUID
+	StartDate  time.Time
+	EndDate    time.Time
+}
+
+type UtilizationReport struct {
+	StartDate time.Time        `json:"start_date"`
+	EndDate   time.Time        `json:"end_date"`
+	GroupBy   string           `json:"group_by"`
+	Rows      []UtilizationRow `json:"rows"`
+	Totals    UtilizationRow   `json:"totals"`
+}
+
+// Constants
+const (
+	UtilizationByEmployee = "employee"
+	UtilizationByTeam     = "team"
+	UtilizationByProject  = "project"
+)
@@ -0,0 +1,91 @@
+// internal/domain/workweek.go
+package domain
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Weekdays is a set of days of the week (0 = Sunday ... 6 = Saturday),
+// stored as a comma-separated list. A nil set is stored as NULL.
+type Weekdays []int
+
+func (w Weekdays) Value() (driver.Value, error) {
+	if w == nil {
+		return nil, nil
+	}
+	parts := make([]string, len(w))
+	for i, day := range w {
+		parts[i] = strconv.Itoa(day)
+	}
+	return strings.Join(parts, ","), nil
+}
+
+func (w *Weekdays) Scan(value interface{}) error {
+	var text string
+	switch v := value.(type) {
+	case nil:
+		*w = nil
+		return nil
+	case string:
+		text = v
+	case []byte:
+		text = string(v)
+	default:
+		return fmt.Errorf("cannot scan %T into Weekdays", value)
+	}
+
+	days := Weekdays{}
+	for _, part := range strings.Split(text, ",") {
+		if part = strings.TrimSpace(part); part == "" {
+			continue
+		}
+		day, err := strconv.Atoi(part)
+		if err != nil {
+			return fmt.Errorf("invalid weekday %q", part)
+		}
+		days = append(days, day)
+	}
+	*w = days
+	return nil
+}
+
+// EmployeeWorkWeek overrides the organization's week start and weekend days
+// for an employee. Nil fields fall back to the organization's policy.
+type EmployeeWorkWeek struct {
+	Base
+	OrganizationID uuid.UUID `json:"organization_id" gorm:"type:uuid;not null"`
+	EmployeeID     uuid.UUID `json:"employee_id" gorm:"type:uuid;not null"`
+	WeekStart      *int      `json:"week_start"`
+	WeekendDays    Weekdays  `json:"weekend_days" gorm:"type:varchar(20)"`
+}
+
+// Request/Response types
+
+// EmployeeWorkWeekRequest sets an employee's override; omitted fields follow
+// the organization, and omitting both removes the override
+type EmployeeWorkWeekRequest struct {
+	WeekStart   *int  `json:"week_start" binding:"omitempty,min=0,max=6"`
+	WeekendDays []int `json:"weekend_days" binding:"omitempty,max=6,dive,min=0,max=6"`
+}
+
+// WorkWeek is the week definition in effect for an employee
+type WorkWeek struct {
+	EmployeeID  uuid.UUID `json:"employee_id"`
+	WeekStart   int       `json:"week_start"`
+	WeekendDays Weekdays  `json:"weekend_days"`
+	Source      string    `json:"source"`
+}
+
+// Constants
+const (
+	WorkWeekSourceOrganization = "organization"
+	WorkWeekSourceEmployee     = "employee"
+)
+
+// DefaultWeekendDays are Saturday and Sunday
+var DefaultWeekendDays = Weekdays{6, 0}
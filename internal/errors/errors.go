@@ -23,6 +23,44 @@ const (
 	ErrOrganizationInactive ErrorCode = "ORGANIZATION_INACTIVE"
 	ErrInvalidStatus        ErrorCode = "INVALID_STATUS"
 	ErrLimitExceeded        ErrorCode = "LIMIT_EXCEEDED"
+
+	// Timesheet Rules
+	ErrInvalidDate    ErrorCode = "INVALID_DATE"
+	ErrFutureDate     ErrorCode = "FUTURE_DATE_NOT_ALLOWED"
+	ErrBackdateLimit  ErrorCode = "BACKDATE_LIMIT_EXCEEDED"
+	ErrPeriodLocked   ErrorCode = "PERIOD_LOCKED"
+	ErrPeriodApproved ErrorCode = "PERIOD_APPROVED"
+	ErrPeriodClosed   ErrorCode = "PERIOD_CLOSED"
+	ErrPendingItems   ErrorCode = "PENDING_ITEMS"
+	ErrInvalidTime    ErrorCode = "INVALID_TIME"
+	ErrTimeOverlap    ErrorCode = "TIME_OVERLAP"
+
+	ErrAttendanceMismatch  ErrorCode = "ATTENDANCE_MISMATCH"
+	ErrComplianceViolation ErrorCode = "COMPLIANCE_VIOLATION"
+
+	// Leave Rules
+	ErrInsufficientLeaveBalance ErrorCode = "INSUFFICIENT_LEAVE_BALANCE"
+	ErrOnLeave                  ErrorCode = "ON_LEAVE"
+
+	// Remote Work Rules
+	ErrRemoteWorkViolation ErrorCode = "REMOTE_WORK_VIOLATION"
+
+	// On-call Rules
+	ErrNotOnCall ErrorCode = "NOT_ON_CALL"
+
+	// Project Rules
+	ErrProjectNotFound    ErrorCode = "PROJECT_NOT_FOUND"
+	ErrProjectClosed      ErrorCode = "PROJECT_CLOSED"
+	ErrProjectInactive    ErrorCode = "PROJECT_INACTIVE"
+	ErrProjectNotAssigned ErrorCode = "PROJECT_NOT_ASSIGNED"
+	ErrTaskNotFound       ErrorCode = "TASK_NOT_FOUND"
+	ErrTaskClosed         ErrorCode = "TASK_CLOSED"
+	ErrBudgetExceeded     ErrorCode = "BUDGET_EXCEEDED"
+
+	// Billing Rules
+	ErrNothingToInvoice  ErrorCode = "NOTHING_TO_INVOICE"
+	ErrCurrencyMismatch  ErrorCode = "CURRENCY_MISMATCH"
+	ErrTimesheetInvoiced ErrorCode = "TIMESHEET_INVOICED"
 )
 
 type AppError struct {
@@ -62,6 +100,41 @@ func NewNotFoundError(message string) *AppError {
 	}
 }
 
+func NewForbiddenError(message string) *AppError {
+	return &AppError{
+		Code:       ErrForbidden,
+		Message:    message,
+		HTTPStatus: 403,
+	}
+}
+
+func NewConflictError(message string) *AppError {
+	return &AppError{
+		Code:       ErrConflict,
+		Message:    message,
+		HTTPStatus: 409,
+	}
+}
+
+func NewInvalidStatusError(message string) *AppError {
+	return &AppError{
+		Code:       ErrInvalidStatus,
+		Message:    message,
+		HTTPStatus: 409,
+	}
+}
+
+// NewBusinessRuleError reports a request that is well-formed but breaks an
+// organization rule; code identifies the rule that failed.
+func NewBusinessRuleError(code ErrorCode, message string, details interface{}) *AppError {
+	return &AppError{
+		Code:       code,
+		Message:    message,
+		Details:    details,
+		HTTPStatus: 422,
+	}
+}
+
 func NewInternalServerError(message string) *AppError {
 	return &AppError{
 		Code:       ErrInternalServer,
@@ -0,0 +1,223 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Axontik/comin-time-service/internal/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// @Summary Approve timesheet
+// @Description Admins and the entry's active approvers may approve it, never its own employee
+// @Tags timesheets
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param employee_id path string true "Employee ID"
+// @Param id path string true "Timesheet ID"
+// @Success 204
+// @Router /organizations/{organization_id}/employees/{employee_id}/timesheets/{id}/approve [put]
+func (h *TimeHandler) ApproveTimesheet(c *gin.Context) {
+	orgID, employeeID, id, approverID, ok := timesheetReviewParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.timeService.ApproveTimesheet(orgID, employeeID, id, approverID, c.GetString("role") == "admin"); err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// @Summary Reject timesheet
+// @Description Admins and the entry's active approvers may reject it, never its own employee
+// @Tags timesheets
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param employee_id path string true "Employee ID"
+// @Param id path string true "Timesheet ID"
+// @Param request body domain.RejectTimesheetRequest true "Rejection reason"
+// @Success 204
+// @Router /organizations/{organization_id}/employees/{employee_id}/timesheets/{id}/reject [put]
+func (h *TimeHandler) RejectTimesheet(c *gin.Context) {
+	orgID, employeeID, id, approverID, ok := timesheetReviewParams(c)
+	if !ok {
+		return
+	}
+
+	var req domain.RejectTimesheetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.timeService.RejectTimesheet(orgID, employeeID, id, approverID, c.GetString("role") == "admin", req.Reason); err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// @Summary List timesheet approvers
+// @Tags timesheets
+// @Accept json
+// @Produce json
+// @Param id path string true "Timesheet ID"
+// @Success 200 {array} domain.TimesheetApprover
+// @Router /organizations/{organization_id}/employees/{employee_id}/timesheets/{id}/approvers [get]
+func (h *TimeHandler) ListTimesheetApprovers(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid timesheet id"})
+		return
+	}
+
+	approvers, err := h.timeService.ListTimesheetApprovers(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, approvers)
+}
+
+// @Summary List approval SLA rules
+// @Tags approval-sla
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Success 200 {array} domain.ApprovalSLARule
+// @Router /organizations/{organization_id}/approval-sla-rules [get]
+func (h *TimeHandler) ListApprovalSLARules(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return
+	}
+
+	rules, err := h.timeService.ListApprovalSLARules(orgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, rules)
+}
+
+// @Summary Create approval SLA rule
+// @Tags approval-sla
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param request body domain.ApprovalSLARuleRequest true "SLA rule details"
+// @Success 201 {object} domain.ApprovalSLARule
+// @Router /organizations/{organization_id}/approval-sla-rules [post]
+func (h *TimeHandler) CreateApprovalSLARule(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return
+	}
+
+	var req domain.ApprovalSLARuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rule, err := h.timeService.CreateApprovalSLARule(orgID, &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, rule)
+}
+
+// @Summary Update approval SLA rule
+// @Tags approval-sla
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param id path string true "SLA rule ID"
+// @Param request body domain.ApprovalSLARuleRequest true "SLA rule details"
+// @Success 200 {object} domain.ApprovalSLARule
+// @Router /organizations/{organization_id}/approval-sla-rules/{id} [put]
+func (h *TimeHandler) UpdateApprovalSLARule(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid SLA rule id"})
+		return
+	}
+
+	var req domain.ApprovalSLARuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rule, err := h.timeService.UpdateApprovalSLARule(orgID, id, &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+// @Summary Delete approval SLA rule
+// @Tags approval-sla
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param id path string true "SLA rule ID"
+// @Success 204
+// @Router /organizations/{organization_id}/approval-sla-rules/{id} [delete]
+func (h *TimeHandler) DeleteApprovalSLARule(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid SLA rule id"})
+		return
+	}
+
+	if err := h.timeService.DeleteApprovalSLARule(orgID, id); err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// timesheetReviewParams reads the employee path parameters, the timesheet
+// id and the reviewing user
+func timesheetReviewParams(c *gin.Context) (uuid.UUID, uuid.UUID, uuid.UUID, uuid.UUID, bool) {
+	orgID, employeeID, id, ok := timesheetParams(c)
+	if !ok {
+		return uuid.Nil, uuid.Nil, uuid.Nil, uuid.Nil, false
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid user"})
+		return uuid.Nil, uuid.Nil, uuid.Nil, uuid.Nil, false
+	}
+
+	return orgID, employeeID, id, userID, true
+}
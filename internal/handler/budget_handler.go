@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Axontik/comin-time-service/internal/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// @Summary List project budgets
+// @Tags budgets
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param id path string true "Project ID"
+// @Success 200 {array} domain.BudgetStatus
+// @Router /organizations/{organization_id}/projects/{id}/budgets [get]
+func (h *TimeHandler) ListProjectBudgets(c *gin.Context) {
+	orgID, projectID, ok := projectParams(c)
+	if !ok {
+		return
+	}
+
+	budgets, err := h.timeService.ListProjectBudgets(orgID, projectID)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, budgets)
+}
+
+// @Summary Create project budget
+// @Tags budgets
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param id path string true "Project ID"
+// @Param request body domain.ProjectBudgetRequest true "Budget details"
+// @Success 201 {object} domain.ProjectBudget
+// @Router /organizations/{organization_id}/projects/{id}/budgets [post]
+func (h *TimeHandler) CreateProjectBudget(c *gin.Context) {
+	orgID, projectID, ok := projectParams(c)
+	if !ok {
+		return
+	}
+
+	var req domain.ProjectBudgetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	budget, err := h.timeService.CreateProjectBudget(orgID, projectID, &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, budget)
+}
+
+// @Summary Update project budget
+// @Tags budgets
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param id path string true "Project ID"
+// @Param budget_id path string true "Budget ID"
+// @Param request body domain.ProjectBudgetRequest true "Budget details"
+// @Success 200 {object} domain.ProjectBudget
+// @Router /organizations/{organization_id}/projects/{id}/budgets/{budget_id} [put]
+func (h *TimeHandler) UpdateProjectBudget(c *gin.Context) {
+	orgID, projectID, ok := projectParams(c)
+	if !ok {
+		return
+	}
+
+	budgetID, err := uuid.Parse(c.Param("budget_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid budget id"})
+		return
+	}
+
+	var req domain.ProjectBudgetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	budget, err := h.timeService.UpdateProjectBudget(orgID, projectID, budgetID, &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, budget)
+}
+
+// @Summary Delete project budget
+// @Tags budgets
+// @Param organization_id path string true "Organization ID"
+// @Param id path string true "Project ID"
+// @Param budget_id path string true "Budget ID"
+// @Success 204
+// @Router /organizations/{organization_id}/projects/{id}/budgets/{budget_id} [delete]
+func (h *TimeHandler) DeleteProjectBudget(c *gin.Context) {
+	orgID, projectID, ok := projectParams(c)
+	if !ok {
+		return
+	}
+
+	budgetID, err := uuid.Parse(c.Param("budget_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid budget id"})
+		return
+	}
+
+	if err := h.timeService.DeleteProjectBudget(orgID, projectID, budgetID); err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Get project burn-down
+// @Tags budgets
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param id path string true "Project ID"
+// @Param task_id query string false "Task ID"
+// @Success 200 {object} domain.BurnDownResponse
+// @Router /organizations/{organization_id}/projects/{id}/burndown [get]
+func (h *TimeHandler) GetProjectBurnDown(c *gin.Context) {
+	orgID, projectID, ok := projectParams(c)
+	if !ok {
+		return
+	}
+
+	var taskID *uuid.UUID
+	if value := c.Query("task_id"); value != "" {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task id"})
+			return
+		}
+		taskID = &id
+	}
+
+	burnDown, err := h.timeService.GetProjectBurnDown(orgID, projectID, taskID)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, burnDown)
+}
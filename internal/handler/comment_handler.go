@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Axontik/comin-time-service/internal/domain"
+	"github.com/Axontik/comin-time-service/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary List timesheet comments
+// @Tags timesheets
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param employee_id path string true "Employee ID"
+// @Param id path string true "Timesheet ID"
+// @Success 200 {array} domain.TimesheetComment
+// @Router /organizations/{organization_id}/employees/{employee_id}/timesheets/{id}/comments [get]
+func (h *TimeHandler) ListTimesheetComments(c *gin.Context) {
+	orgID, employeeID, id, ok := timesheetParams(c)
+	if !ok {
+		return
+	}
+
+	comments, err := h.timeService.ListTimesheetComments(orgID, employeeID, id)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, comments)
+}
+
+// @Summary Comment on timesheet
+// @Tags timesheets
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param employee_id path string true "Employee ID"
+// @Param id path string true "Timesheet ID"
+// @Param request body domain.TimesheetCommentRequest true "Comment"
+// @Success 201 {object} domain.TimesheetComment
+// @Router /organizations/{organization_id}/employees/{employee_id}/timesheets/{id}/comments [post]
+func (h *TimeHandler) AddTimesheetComment(c *gin.Context) {
+	orgID, employeeID, id, ok := timesheetParams(c)
+	if !ok {
+		return
+	}
+
+	authorID, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid user"})
+		return
+	}
+
+	var req domain.TimesheetCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	comment, err := h.timeService.AddTimesheetComment(orgID, employeeID, id, authorID, &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, comment)
+}
+
+// @Summary Get timesheet change history
+// @Tags timesheets
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param employee_id path string true "Employee ID"
+// @Param id path string true "Timesheet ID"
+// @Success 200 {array} domain.TimesheetChange
+// @Router /organizations/{organization_id}/employees/{employee_id}/timesheets/{id}/history [get]
+func (h *TimeHandler) ListTimesheetHistory(c *gin.Context) {
+	orgID, employeeID, id, ok := timesheetParams(c)
+	if !ok {
+		return
+	}
+
+	changes, err := h.timeService.ListTimesheetHistory(orgID, employeeID, id)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, changes)
+}
+
+// @Summary List timesheet period comments
+// @Tags timesheets
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param employee_id path string true "Employee ID"
+// @Param period query string false "Any date of the period (YYYY-MM-DD), defaults to today"
+// @Success 200 {array} domain.TimesheetComment
+// @Router /organizations/{organization_id}/employees/{employee_id}/timesheets/period-comments [get]
+func (h *TimeHandler) ListPeriodComments(c *gin.Context) {
+	orgID, employeeID, ok := employeeParams(c)
+	if !ok {
+		return
+	}
+
+	period, ok := periodQuery(c)
+	if !ok {
+		return
+	}
+
+	comments, err := h.timeService.ListPeriodComments(orgID, employeeID, period)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, comments)
+}
+
+// @Summary Comment on timesheet period
+// @Tags timesheets
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param employee_id path string true "Employee ID"
+// @Param period query string false "Any date of the period (YYYY-MM-DD), defaults to today"
+// @Param request body domain.TimesheetCommentRequest true "Comment"
+// @Success 201 {object} domain.TimesheetComment
+// @Router /organizations/{organization_id}/employees/{employee_id}/timesheets/period-comments [post]
+func (h *TimeHandler) AddPeriodComment(c *gin.Context) {
+	orgID, employeeID, ok := employeeParams(c)
+	if !ok {
+		return
+	}
+
+	authorID, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid user"})
+		return
+	}
+
+	period, ok := periodQuery(c)
+	if !ok {
+		return
+	}
+
+	var req domain.TimesheetCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	comment, err := h.timeService.AddPeriodComment(orgID, employeeID, authorID, period, &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, comment)
+}
+
+// periodQuery reads the period query parameter, defaulting to today
+func periodQuery(c *gin.Context) (time.Time, bool) {
+	value := c.Query("period")
+	if value == "" {
+		return time.Now().Truncate(24 * time.Hour), true
+	}
+
+	date, err := utils.ParseDate(value)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid period, expected YYYY-MM-DD"})
+		return time.Time{}, false
+	}
+	return date, true
+}
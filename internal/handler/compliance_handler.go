@@ -0,0 +1,213 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Axontik/comin-time-service/internal/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// @Summary List compliance rule sets
+// @Tags compliance
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Success 200 {array} domain.ComplianceRuleSet
+// @Router /organizations/{organization_id}/compliance/rule-sets [get]
+func (h *TimeHandler) ListComplianceRuleSets(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return
+	}
+
+	ruleSets, err := h.timeService.ListComplianceRuleSets(orgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, ruleSets)
+}
+
+// @Summary Create compliance rule set
+// @Tags compliance
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param request body domain.ComplianceRuleSetRequest true "Rule set details"
+// @Success 201 {object} domain.ComplianceRuleSet
+// @Router /organizations/{organization_id}/compliance/rule-sets [post]
+func (h *TimeHandler) CreateComplianceRuleSet(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return
+	}
+
+	var req domain.ComplianceRuleSetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ruleSet, err := h.timeService.CreateComplianceRuleSet(orgID, &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, ruleSet)
+}
+
+// @Summary Update compliance rule set
+// @Tags compliance
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param id path string true "Rule set ID"
+// @Param request body domain.ComplianceRuleSetRequest true "Rule set details"
+// @Success 200 {object} domain.ComplianceRuleSet
+// @Router /organizations/{organization_id}/compliance/rule-sets/{id} [put]
+func (h *TimeHandler) UpdateComplianceRuleSet(c *gin.Context) {
+	orgID, id, ok := complianceRuleSetParams(c)
+	if !ok {
+		return
+	}
+
+	var req domain.ComplianceRuleSetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ruleSet, err := h.timeService.UpdateComplianceRuleSet(orgID, id, &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ruleSet)
+}
+
+// @Summary Delete compliance rule set
+// @Tags compliance
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param id path string true "Rule set ID"
+// @Success 204
+// @Router /organizations/{organization_id}/compliance/rule-sets/{id} [delete]
+func (h *TimeHandler) DeleteComplianceRuleSet(c *gin.Context) {
+	orgID, id, ok := complianceRuleSetParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.timeService.DeleteComplianceRuleSet(orgID, id); err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// @Summary Set employee jurisdiction
+// @Tags compliance
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param employee_id path string true "Employee ID"
+// @Param request body domain.EmployeeJurisdictionRequest true "Jurisdiction, empty for the default rule set"
+// @Success 200 {object} domain.EmployeeJurisdiction
+// @Router /organizations/{organization_id}/compliance/employees/{employee_id}/jurisdiction [put]
+func (h *TimeHandler) SetEmployeeJurisdiction(c *gin.Context) {
+	orgID, employeeID, ok := employeeParams(c)
+	if !ok {
+		return
+	}
+
+	var req domain.EmployeeJurisdictionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	jurisdiction, err := h.timeService.SetEmployeeJurisdiction(orgID, employeeID, &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, jurisdiction)
+}
+
+// @Summary Compliance report
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param employee_id query string false "Employee ID"
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Param severity query string false "warning or critical"
+// @Success 200 {object} domain.ComplianceReport
+// @Router /organizations/{organization_id}/reports/compliance [get]
+func (h *TimeHandler) GetComplianceReport(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return
+	}
+
+	startDate, endDate, err := dateRangeQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	filter := &domain.ComplianceFilter{StartDate: startDate, EndDate: endDate}
+
+	if value := c.Query("employee_id"); value != "" {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid employee id"})
+			return
+		}
+		filter.EmployeeID = &id
+	}
+
+	switch severity := c.Query("severity"); severity {
+	case "", domain.SeverityWarning, domain.SeverityCritical:
+		filter.Severity = severity
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid severity"})
+		return
+	}
+
+	report, err := h.timeService.GetComplianceReport(orgID, filter)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// complianceRuleSetParams reads the organization_id and rule set id path parameters
+func complianceRuleSetParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid rule set id"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return orgID, id, true
+}
@@ -0,0 +1,213 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/Axontik/comin-time-service/internal/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// maxCalendarSize caps uploaded iCalendar files
+const maxCalendarSize = 1 << 20
+
+// @Summary List holidays
+// @Description Holidays in the range with recurring ones expanded, for calendars and kiosks
+// @Tags holidays
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Param site query string false "Only organization-wide holidays and those of the site"
+// @Success 200 {array} domain.HolidayOccurrence
+// @Router /organizations/{organization_id}/holidays [get]
+func (h *TimeHandler) ListHolidays(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return
+	}
+
+	startDate, endDate, err := dateRangeQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	holidays, err := h.timeService.ListHolidayOccurrences(orgID, startDate, endDate, c.Query("site"))
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, holidays)
+}
+
+// @Summary Create holiday
+// @Tags holidays
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param request body domain.HolidayRequest true "Holiday details"
+// @Success 201 {object} domain.Holiday
+// @Router /organizations/{organization_id}/holidays [post]
+func (h *TimeHandler) CreateHoliday(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return
+	}
+
+	var req domain.HolidayRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	holiday, err := h.timeService.CreateHoliday(orgID, &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, holiday)
+}
+
+// @Summary Update holiday
+// @Tags holidays
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param id path string true "Holiday ID"
+// @Param request body domain.HolidayRequest true "Holiday details"
+// @Success 200 {object} domain.Holiday
+// @Router /organizations/{organization_id}/holidays/{id} [put]
+func (h *TimeHandler) UpdateHoliday(c *gin.Context) {
+	orgID, id, ok := holidayParams(c)
+	if !ok {
+		return
+	}
+
+	var req domain.HolidayRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	holiday, err := h.timeService.UpdateHoliday(orgID, id, &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, holiday)
+}
+
+// @Summary Delete holiday
+// @Tags holidays
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param id path string true "Holiday ID"
+// @Success 204
+// @Router /organizations/{organization_id}/holidays/{id} [delete]
+func (h *TimeHandler) DeleteHoliday(c *gin.Context) {
+	orgID, id, ok := holidayParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.timeService.DeleteHoliday(orgID, id); err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// @Summary Import holidays from an iCalendar file
+// @Description Accepts a multipart "file" field or a text/calendar request body
+// @Tags holidays
+// @Accept multipart/form-data
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param site query string false "Site the holidays apply to"
+// @Param file formData file false "iCalendar (.ics) file"
+// @Success 200 {object} domain.HolidayImportResult
+// @Router /organizations/{organization_id}/holidays/import [post]
+func (h *TimeHandler) ImportHolidays(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return
+	}
+
+	var body io.Reader = c.Request.Body
+	if file, err := c.FormFile("file"); err == nil {
+		f, err := file.Open()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "could not read uploaded file"})
+			return
+		}
+		defer f.Close()
+		body = f
+	}
+
+	result, err := h.timeService.ImportHolidays(orgID, c.Query("site"), io.LimitReader(body, maxCalendarSize))
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// @Summary Set employee site
+// @Description Site whose holidays apply to the employee; an empty site removes the assignment
+// @Tags holidays
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param employee_id path string true "Employee ID"
+// @Param request body domain.EmployeeSiteRequest true "Site"
+// @Success 200 {object} domain.EmployeeSite
+// @Router /organizations/{organization_id}/employees/{employee_id}/site [put]
+func (h *TimeHandler) SetEmployeeSite(c *gin.Context) {
+	orgID, employeeID, ok := employeeParams(c)
+	if !ok {
+		return
+	}
+
+	var req domain.EmployeeSiteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	site, err := h.timeService.SetEmployeeSite(orgID, employeeID, &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, site)
+}
+
+// holidayParams reads the organization_id and holiday id path parameters
+func holidayParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid holiday id"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return orgID, id, true
+}
@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Axontik/comin-time-service/internal/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// @Summary List invoice drafts
+// @Tags invoices
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Success 200 {array} domain.Invoice
+// @Router /organizations/{organization_id}/invoices [get]
+func (h *TimeHandler) ListInvoices(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return
+	}
+
+	invoices, err := h.timeService.ListInvoices(orgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, invoices)
+}
+
+// @Summary Create invoice draft from approved billable time
+// @Tags invoices
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param request body domain.CreateInvoiceRequest true "Invoice scope"
+// @Success 201 {object} domain.Invoice
+// @Router /organizations/{organization_id}/invoices [post]
+func (h *TimeHandler) CreateInvoice(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req domain.CreateInvoiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	invoice, err := h.timeService.CreateInvoice(orgID, userID, &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, invoice)
+}
+
+// @Summary Get invoice draft
+// @Tags invoices
+// @Produce json
+// @Produce text/csv
+// @Produce application/pdf
+// @Param organization_id path string true "Organization ID"
+// @Param id path string true "Invoice ID"
+// @Param format query string false "Export format: json (default), csv or pdf"
+// @Success 200 {object} domain.Invoice
+// @Router /organizations/{organization_id}/invoices/{id} [get]
+func (h *TimeHandler) GetInvoice(c *gin.Context) {
+	orgID, id, ok := invoiceParams(c)
+	if !ok {
+		return
+	}
+
+	format := c.DefaultQuery("format", domain.InvoiceFormatJSON)
+	if format == domain.InvoiceFormatJSON {
+		invoice, err := h.timeService.GetInvoice(orgID, id)
+		if err != nil {
+			respondError(c, http.StatusInternalServerError, err)
+			return
+		}
+		c.JSON(http.StatusOK, invoice)
+		return
+	}
+
+	export, err := h.timeService.ExportInvoice(orgID, id, format)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", export.Filename))
+	c.Data(http.StatusOK, export.ContentType, export.Data)
+}
+
+// @Summary Delete invoice draft and release its entries
+// @Tags invoices
+// @Param organization_id path string true "Organization ID"
+// @Param id path string true "Invoice ID"
+// @Success 204
+// @Router /organizations/{organization_id}/invoices/{id} [delete]
+func (h *TimeHandler) DeleteInvoice(c *gin.Context) {
+	orgID, id, ok := invoiceParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.timeService.DeleteInvoice(orgID, id); err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+func invoiceParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid invoice id"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return orgID, id, true
+}
@@ -0,0 +1,420 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Axontik/comin-time-service/internal/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// @Summary List leave types
+// @Tags leave
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Success 200 {array} domain.LeaveType
+// @Router /organizations/{organization_id}/leave-types [get]
+func (h *TimeHandler) ListLeaveTypes(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return
+	}
+
+	leaveTypes, err := h.timeService.ListLeaveTypes(orgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, leaveTypes)
+}
+
+// @Summary Create leave type
+// @Tags leave
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param request body domain.LeaveTypeRequest true "Leave type details"
+// @Success 201 {object} domain.LeaveType
+// @Router /organizations/{organization_id}/leave-types [post]
+func (h *TimeHandler) CreateLeaveType(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return
+	}
+
+	var req domain.LeaveTypeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	leaveType, err := h.timeService.CreateLeaveType(orgID, &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, leaveType)
+}
+
+// @Summary Update leave type
+// @Tags leave
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param id path string true "Leave type ID"
+// @Param request body domain.LeaveTypeRequest true "Leave type details"
+// @Success 200 {object} domain.LeaveType
+// @Router /organizations/{organization_id}/leave-types/{id} [put]
+func (h *TimeHandler) UpdateLeaveType(c *gin.Context) {
+	orgID, id, ok := leaveParams(c)
+	if !ok {
+		return
+	}
+
+	var req domain.LeaveTypeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	leaveType, err := h.timeService.UpdateLeaveType(orgID, id, &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, leaveType)
+}
+
+// @Summary Delete leave type
+// @Tags leave
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param id path string true "Leave type ID"
+// @Success 204
+// @Router /organizations/{organization_id}/leave-types/{id} [delete]
+func (h *TimeHandler) DeleteLeaveType(c *gin.Context) {
+	orgID, id, ok := leaveParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.timeService.DeleteLeaveType(orgID, id); err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// @Summary Request leave
+// @Tags leave
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param employee_id path string true "Employee ID"
+// @Param request body domain.CreateLeaveRequest true "Leave details"
+// @Success 201 {object} domain.LeaveRequest
+// @Router /organizations/{organization_id}/employees/{employee_id}/leave-requests [post]
+func (h *TimeHandler) CreateLeaveRequest(c *gin.Context) {
+	orgID, employeeID, ok := employeeParams(c)
+	if !ok {
+		return
+	}
+
+	var req domain.CreateLeaveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	request, err := h.timeService.CreateLeaveRequest(orgID, employeeID, &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, request)
+}
+
+// @Summary List employee leave requests
+// @Tags leave
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param employee_id path string true "Employee ID"
+// @Param status query string false "pending, approved, rejected or cancelled"
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Success 200 {array} domain.LeaveRequest
+// @Router /organizations/{organization_id}/employees/{employee_id}/leave-requests [get]
+func (h *TimeHandler) ListEmployeeLeaveRequests(c *gin.Context) {
+	orgID, employeeID, ok := employeeParams(c)
+	if !ok {
+		return
+	}
+
+	filter, ok := leaveRequestFilter(c)
+	if !ok {
+		return
+	}
+	filter.EmployeeID = &employeeID
+
+	requests, err := h.timeService.ListLeaveRequests(orgID, filter)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, requests)
+}
+
+// @Summary Cancel leave request
+// @Tags leave
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param employee_id path string true "Employee ID"
+// @Param id path string true "Leave request ID"
+// @Success 200 {object} domain.LeaveRequest
+// @Router /organizations/{organization_id}/employees/{employee_id}/leave-requests/{id}/cancel [put]
+func (h *TimeHandler) CancelLeaveRequest(c *gin.Context) {
+	orgID, employeeID, ok := employeeParams(c)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid leave request id"})
+		return
+	}
+
+	request, err := h.timeService.CancelLeaveRequest(orgID, employeeID, id)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, request)
+}
+
+// @Summary List leave requests
+// @Tags leave
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param employee_id query string false "Employee ID"
+// @Param status query string false "pending, approved, rejected or cancelled"
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Success 200 {array} domain.LeaveRequest
+// @Router /organizations/{organization_id}/leave-requests [get]
+func (h *TimeHandler) ListLeaveRequests(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return
+	}
+
+	filter, ok := leaveRequestFilter(c)
+	if !ok {
+		return
+	}
+	if value := c.Query("employee_id"); value != "" {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid employee id"})
+			return
+		}
+		filter.EmployeeID = &id
+	}
+
+	requests, err := h.timeService.ListLeaveRequests(orgID, filter)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, requests)
+}
+
+// @Summary Approve leave request
+// @Tags leave
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param id path string true "Leave request ID"
+// @Param request body domain.ReviewLeaveRequest false "Review note"
+// @Success 200 {object} domain.LeaveRequest
+// @Router /organizations/{organization_id}/leave-requests/{id}/approve [put]
+func (h *TimeHandler) ApproveLeaveRequest(c *gin.Context) {
+	orgID, id, userID, req, ok := leaveReviewParams(c)
+	if !ok {
+		return
+	}
+
+	request, err := h.timeService.ApproveLeaveRequest(orgID, id, userID, req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, request)
+}
+
+// @Summary Reject leave request
+// @Tags leave
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param id path string true "Leave request ID"
+// @Param request body domain.ReviewLeaveRequest false "Review note"
+// @Success 200 {object} domain.LeaveRequest
+// @Router /organizations/{organization_id}/leave-requests/{id}/reject [put]
+func (h *TimeHandler) RejectLeaveRequest(c *gin.Context) {
+	orgID, id, userID, req, ok := leaveReviewParams(c)
+	if !ok {
+		return
+	}
+
+	request, err := h.timeService.RejectLeaveRequest(orgID, id, userID, req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, request)
+}
+
+// @Summary List employee leave balances
+// @Tags leave
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param employee_id path string true "Employee ID"
+// @Param year query int false "Year, defaults to the current year"
+// @Success 200 {array} domain.LeaveBalanceResponse
+// @Router /organizations/{organization_id}/employees/{employee_id}/leave-balances [get]
+func (h *TimeHandler) ListLeaveBalances(c *gin.Context) {
+	orgID, employeeID, ok := employeeParams(c)
+	if !ok {
+		return
+	}
+
+	year := time.Now().Year()
+	if value := c.Query("year"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid year"})
+			return
+		}
+		year = parsed
+	}
+
+	balances, err := h.timeService.ListLeaveBalances(orgID, employeeID, year)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, balances)
+}
+
+// @Summary Adjust employee leave balance
+// @Tags leave
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param employee_id path string true "Employee ID"
+// @Param request body domain.LeaveBalanceAdjustmentRequest true "Adjustment"
+// @Success 200 {object} domain.LeaveBalanceResponse
+// @Router /organizations/{organization_id}/employees/{employee_id}/leave-balances/adjust [post]
+func (h *TimeHandler) AdjustLeaveBalance(c *gin.Context) {
+	orgID, employeeID, ok := employeeParams(c)
+	if !ok {
+		return
+	}
+
+	var req domain.LeaveBalanceAdjustmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	balance, err := h.timeService.AdjustLeaveBalance(orgID, employeeID, &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, balance)
+}
+
+// leaveParams reads the organization_id and id path parameters
+func leaveParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return orgID, id, true
+}
+
+// leaveReviewParams reads the path parameters, the reviewing user and the
+// optional review note of an approval or rejection
+func leaveReviewParams(c *gin.Context) (uuid.UUID, uuid.UUID, uuid.UUID, *domain.ReviewLeaveRequest, bool) {
+	orgID, id, ok := leaveParams(c)
+	if !ok {
+		return uuid.Nil, uuid.Nil, uuid.Nil, nil, false
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid user"})
+		return uuid.Nil, uuid.Nil, uuid.Nil, nil, false
+	}
+
+	req := &domain.ReviewLeaveRequest{}
+	if err := c.ShouldBindJSON(req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return uuid.Nil, uuid.Nil, uuid.Nil, nil, false
+	}
+
+	return orgID, id, userID, req, true
+}
+
+// leaveRequestFilter reads the status and date range query parameters
+func leaveRequestFilter(c *gin.Context) (*domain.LeaveRequestFilter, bool) {
+	startDate, endDate, err := dateRangeQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+
+	filter := &domain.LeaveRequestFilter{StartDate: startDate, EndDate: endDate}
+	switch status := c.Query("status"); status {
+	case "", domain.LeaveStatusPending, domain.LeaveStatusApproved, domain.LeaveStatusRejected, domain.LeaveStatusCancelled:
+		filter.Status = status
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid status"})
+		return nil, false
+	}
+	return filter, true
+}
@@ -0,0 +1,341 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Axontik/comin-time-service/internal/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// @Summary List on-call rotations
+// @Tags on-call
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param team_id query string false "Team ID"
+// @Success 200 {array} domain.OnCallRotation
+// @Router /organizations/{organization_id}/on-call/rotations [get]
+func (h *TimeHandler) ListOnCallRotations(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return
+	}
+
+	var teamID *uuid.UUID
+	if value := c.Query("team_id"); value != "" {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid team id"})
+			return
+		}
+		teamID = &id
+	}
+
+	rotations, err := h.timeService.ListOnCallRotations(orgID, teamID)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rotations)
+}
+
+// @Summary Create on-call rotation
+// @Description Members take turns in the order of member_ids
+// @Tags on-call
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param request body domain.OnCallRotationRequest true "Rotation details"
+// @Success 201 {object} domain.OnCallRotation
+// @Router /organizations/{organization_id}/on-call/rotations [post]
+func (h *TimeHandler) CreateOnCallRotation(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return
+	}
+
+	var req domain.OnCallRotationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rotation, err := h.timeService.CreateOnCallRotation(orgID, &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, rotation)
+}
+
+// @Summary Update on-call rotation
+// @Tags on-call
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param id path string true "Rotation ID"
+// @Param request body domain.OnCallRotationRequest true "Rotation details"
+// @Success 200 {object} domain.OnCallRotation
+// @Router /organizations/{organization_id}/on-call/rotations/{id} [put]
+func (h *TimeHandler) UpdateOnCallRotation(c *gin.Context) {
+	orgID, id, ok := onCallRotationParams(c)
+	if !ok {
+		return
+	}
+
+	var req domain.OnCallRotationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rotation, err := h.timeService.UpdateOnCallRotation(orgID, id, &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rotation)
+}
+
+// @Summary Delete on-call rotation
+// @Tags on-call
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param id path string true "Rotation ID"
+// @Success 204
+// @Router /organizations/{organization_id}/on-call/rotations/{id} [delete]
+func (h *TimeHandler) DeleteOnCallRotation(c *gin.Context) {
+	orgID, id, ok := onCallRotationParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.timeService.DeleteOnCallRotation(orgID, id); err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// @Summary Get on-call schedule
+// @Description Who is on call for the rotation between two dates
+// @Tags on-call
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param id path string true "Rotation ID"
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Success 200 {array} domain.OnCallTurn
+// @Router /organizations/{organization_id}/on-call/rotations/{id}/schedule [get]
+func (h *TimeHandler) GetOnCallSchedule(c *gin.Context) {
+	orgID, id, ok := onCallRotationParams(c)
+	if !ok {
+		return
+	}
+
+	startDate, endDate, err := dateRangeQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	turns, err := h.timeService.GetOnCallSchedule(orgID, id, startDate, endDate)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, turns)
+}
+
+// @Summary Log on-call incident
+// @Description Logs a call-out worked while on call as call-out timesheet entries
+// @Tags on-call
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param employee_id path string true "Employee ID"
+// @Param request body domain.CreateOnCallIncidentRequest true "Incident details"
+// @Success 201 {object} domain.OnCallIncident
+// @Router /organizations/{organization_id}/employees/{employee_id}/on-call-incidents [post]
+func (h *TimeHandler) CreateOnCallIncident(c *gin.Context) {
+	orgID, employeeID, ok := employeeParams(c)
+	if !ok {
+		return
+	}
+
+	var req domain.CreateOnCallIncidentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	incident, err := h.timeService.CreateOnCallIncident(orgID, employeeID, &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, incident)
+}
+
+// @Summary List employee on-call incidents
+// @Tags on-call
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param employee_id path string true "Employee ID"
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Success 200 {array} domain.OnCallIncident
+// @Router /organizations/{organization_id}/employees/{employee_id}/on-call-incidents [get]
+func (h *TimeHandler) ListEmployeeOnCallIncidents(c *gin.Context) {
+	orgID, employeeID, ok := employeeParams(c)
+	if !ok {
+		return
+	}
+
+	filter, ok := onCallIncidentFilter(c)
+	if !ok {
+		return
+	}
+	filter.EmployeeID = &employeeID
+
+	incidents, err := h.timeService.ListOnCallIncidents(orgID, filter)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, incidents)
+}
+
+// @Summary List on-call incidents
+// @Tags on-call
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param employee_id query string false "Employee ID"
+// @Param rotation_id query string false "Rotation ID"
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Success 200 {array} domain.OnCallIncident
+// @Router /organizations/{organization_id}/on-call/incidents [get]
+func (h *TimeHandler) ListOnCallIncidents(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return
+	}
+
+	filter, ok := onCallIncidentFilter(c)
+	if !ok {
+		return
+	}
+
+	if value := c.Query("employee_id"); value != "" {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid employee id"})
+			return
+		}
+		filter.EmployeeID = &id
+	}
+
+	if value := c.Query("rotation_id"); value != "" {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid rotation id"})
+			return
+		}
+		filter.RotationID = &id
+	}
+
+	incidents, err := h.timeService.ListOnCallIncidents(orgID, filter)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, incidents)
+}
+
+// @Summary On-call compensation report
+// @Description Splits on-call time per employee and rotation into standby and call-out hours
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param employee_id query string false "Employee ID"
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Success 200 {object} domain.OnCallReport
+// @Router /organizations/{organization_id}/reports/on-call [get]
+func (h *TimeHandler) GetOnCallReport(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return
+	}
+
+	startDate, endDate, err := dateRangeQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var employeeID *uuid.UUID
+	if value := c.Query("employee_id"); value != "" {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid employee id"})
+			return
+		}
+		employeeID = &id
+	}
+
+	report, err := h.timeService.GetOnCallReport(orgID, employeeID, startDate, endDate)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// onCallRotationParams reads the organization_id and rotation id path parameters
+func onCallRotationParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid rotation id"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return orgID, id, true
+}
+
+// onCallIncidentFilter reads the date range query parameters; incidents
+// overlapping any of its days match
+func onCallIncidentFilter(c *gin.Context) (*domain.OnCallIncidentFilter, bool) {
+	startDate, endDate, err := dateRangeQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	return &domain.OnCallIncidentFilter{From: startDate, To: endDate.AddDate(0, 0, 1)}, true
+}
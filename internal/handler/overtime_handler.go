@@ -0,0 +1,223 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Axontik/comin-time-service/internal/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// @Summary List overtime rules
+// @Tags overtime
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Success 200 {array} domain.OvertimeRule
+// @Router /organizations/{organization_id}/overtime/rules [get]
+func (h *TimeHandler) ListOvertimeRules(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return
+	}
+
+	rules, err := h.timeService.ListOvertimeRules(orgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, rules)
+}
+
+// @Summary Create overtime rule
+// @Tags overtime
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param request body domain.OvertimeRuleRequest true "Rule details"
+// @Success 201 {object} domain.OvertimeRule
+// @Router /organizations/{organization_id}/overtime/rules [post]
+func (h *TimeHandler) CreateOvertimeRule(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return
+	}
+
+	var req domain.OvertimeRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rule, err := h.timeService.CreateOvertimeRule(orgID, &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, rule)
+}
+
+// @Summary Update overtime rule
+// @Tags overtime
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param id path string true "Rule ID"
+// @Param request body domain.OvertimeRuleRequest true "Rule details"
+// @Success 200 {object} domain.OvertimeRule
+// @Router /organizations/{organization_id}/overtime/rules/{id} [put]
+func (h *TimeHandler) UpdateOvertimeRule(c *gin.Context) {
+	orgID, id, ok := overtimeRuleParams(c)
+	if !ok {
+		return
+	}
+
+	var req domain.OvertimeRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rule, err := h.timeService.UpdateOvertimeRule(orgID, id, &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+// @Summary Delete overtime rule
+// @Tags overtime
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param id path string true "Rule ID"
+// @Success 204
+// @Router /organizations/{organization_id}/overtime/rules/{id} [delete]
+func (h *TimeHandler) DeleteOvertimeRule(c *gin.Context) {
+	orgID, id, ok := overtimeRuleParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.timeService.DeleteOvertimeRule(orgID, id); err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// @Summary Recompute overtime
+// @Tags overtime
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param request body domain.RecomputeOvertimeRequest true "Range to recompute"
+// @Success 200 {object} domain.RecomputeOvertimeResult
+// @Router /organizations/{organization_id}/overtime/recompute [post]
+func (h *TimeHandler) RecomputeOvertime(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return
+	}
+
+	var req domain.RecomputeOvertimeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.timeService.RecomputeOvertime(orgID, &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// @Summary Get employee overtime
+// @Tags overtime
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param employee_id path string true "Employee ID"
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Success 200 {object} domain.OvertimeSummary
+// @Router /organizations/{organization_id}/employees/{employee_id}/overtime [get]
+func (h *TimeHandler) GetEmployeeOvertime(c *gin.Context) {
+	orgID, employeeID, ok := employeeParams(c)
+	if !ok {
+		return
+	}
+
+	startDate, endDate, err := dateRangeQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	summary, err := h.timeService.GetEmployeeOvertime(orgID, employeeID, startDate, endDate)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
+
+// @Summary Overtime report
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Success 200 {array} domain.OvertimeSummary
+// @Router /organizations/{organization_id}/reports/overtime [get]
+func (h *TimeHandler) GetOvertimeReport(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return
+	}
+
+	startDate, endDate, err := dateRangeQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := h.timeService.GetOvertimeReport(orgID, startDate, endDate)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// overtimeRuleParams reads the organization_id and rule id path parameters
+func overtimeRuleParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid overtime rule id"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return orgID, id, true
+}
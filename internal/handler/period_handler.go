@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Axontik/comin-time-service/internal/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// @Summary List payroll periods
+// @Tags payroll-periods
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Success 200 {array} domain.PayrollPeriod
+// @Router /organizations/{organization_id}/payroll-periods [get]
+func (h *TimeHandler) ListPayrollPeriods(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return
+	}
+
+	periods, err := h.timeService.ListPayrollPeriods(orgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, periods)
+}
+
+// @Summary Close payroll period
+// @Tags payroll-periods
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param request body domain.ClosePeriodRequest true "Period range"
+// @Success 201 {object} domain.PayrollPeriod
+// @Router /organizations/{organization_id}/payroll-periods [post]
+func (h *TimeHandler) ClosePayrollPeriod(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid user"})
+		return
+	}
+
+	var req domain.ClosePeriodRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	period, err := h.timeService.ClosePayrollPeriod(orgID, userID, &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, period)
+}
+
+// @Summary Reopen payroll period
+// @Tags payroll-periods
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param id path string true "Period ID"
+// @Param request body domain.ReopenPeriodRequest true "Reopen reason"
+// @Success 200 {object} domain.PayrollPeriod
+// @Router /organizations/{organization_id}/payroll-periods/{id}/reopen [put]
+func (h *TimeHandler) ReopenPayrollPeriod(c *gin.Context) {
+	orgID, id, ok := payrollPeriodParams(c)
+	if !ok {
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid user"})
+		return
+	}
+
+	var req domain.ReopenPeriodRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	period, err := h.timeService.ReopenPayrollPeriod(orgID, id, userID, &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, period)
+}
+
+// @Summary List payroll period audit events
+// @Tags payroll-periods
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param id path string true "Period ID"
+// @Success 200 {array} domain.PayrollPeriodEvent
+// @Router /organizations/{organization_id}/payroll-periods/{id}/events [get]
+func (h *TimeHandler) ListPayrollPeriodEvents(c *gin.Context) {
+	orgID, id, ok := payrollPeriodParams(c)
+	if !ok {
+		return
+	}
+
+	events, err := h.timeService.ListPayrollPeriodEvents(orgID, id)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, events)
+}
+
+func payrollPeriodParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid period id"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return orgID, id, true
+}
@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Axontik/comin-time-service/internal/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// @Summary Get timesheet policy
+// @Tags policies
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Success 200 {object} domain.TimesheetPolicy
+// @Router /organizations/{organization_id}/timesheet-policy [get]
+func (h *TimeHandler) GetTimesheetPolicy(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return
+	}
+
+	policy, err := h.timeService.GetTimesheetPolicy(orgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, policy)
+}
+
+// @Summary Update timesheet policy
+// @Description Changes the fields sent; omitted fields keep their current values
+// @Tags policies
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param request body domain.TimesheetPolicyRequest true "Policy details"
+// @Success 200 {object} domain.TimesheetPolicy
+// @Router /organizations/{organization_id}/timesheet-policy [put]
+func (h *TimeHandler) UpdateTimesheetPolicy(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return
+	}
+
+	var req domain.TimesheetPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	policy, err := h.timeService.UpdateTimesheetPolicy(orgID, &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, policy)
+}
+
+// @Summary Get employee work week
+// @Description Week start and weekend days in effect for the employee
+// @Tags policies
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param employee_id path string true "Employee ID"
+// @Success 200 {object} domain.WorkWeek
+// @Router /organizations/{organization_id}/employees/{employee_id}/work-week [get]
+func (h *TimeHandler) GetEmployeeWorkWeek(c *gin.Context) {
+	orgID, employeeID, ok := employeeParams(c)
+	if !ok {
+		return
+	}
+
+	workWeek, err := h.timeService.GetEmployeeWorkWeek(orgID, employeeID)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, workWeek)
+}
+
+// @Summary Set employee work week
+// @Description Overrides the organization's week start and weekend days; an empty body removes the override
+// @Tags policies
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param employee_id path string true "Employee ID"
+// @Param request body domain.EmployeeWorkWeekRequest true "Work week"
+// @Success 200 {object} domain.WorkWeek
+// @Router /organizations/{organization_id}/employees/{employee_id}/work-week [put]
+func (h *TimeHandler) SetEmployeeWorkWeek(c *gin.Context) {
+	orgID, employeeID, ok := employeeParams(c)
+	if !ok {
+		return
+	}
+
+	var req domain.EmployeeWorkWeekRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	workWeek, err := h.timeService.SetEmployeeWorkWeek(orgID, employeeID, &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, workWeek)
+}
@@ -0,0 +1,281 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Axontik/comin-time-service/internal/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// @Summary List projects
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param status query string false "Project status"
+// @Success 200 {array} domain.Project
+// @Router /organizations/{organization_id}/projects [get]
+func (h *TimeHandler) ListProjects(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return
+	}
+
+	projects, err := h.timeService.ListProjects(orgID, c.Query("status"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, projects)
+}
+
+// @Summary Create project
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param request body domain.ProjectRequest true "Project details"
+// @Success 201 {object} domain.Project
+// @Router /organizations/{organization_id}/projects [post]
+func (h *TimeHandler) CreateProject(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return
+	}
+
+	var req domain.ProjectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	project, err := h.timeService.CreateProject(orgID, &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, project)
+}
+
+// @Summary Get project
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param id path string true "Project ID"
+// @Success 200 {object} domain.Project
+// @Router /organizations/{organization_id}/projects/{id} [get]
+func (h *TimeHandler) GetProject(c *gin.Context) {
+	orgID, projectID, ok := projectParams(c)
+	if !ok {
+		return
+	}
+
+	project, err := h.timeService.GetProject(orgID, projectID)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, project)
+}
+
+// @Summary Update project
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param id path string true "Project ID"
+// @Param request body domain.ProjectRequest true "Project details"
+// @Success 200 {object} domain.Project
+// @Router /organizations/{organization_id}/projects/{id} [put]
+func (h *TimeHandler) UpdateProject(c *gin.Context) {
+	orgID, projectID, ok := projectParams(c)
+	if !ok {
+		return
+	}
+
+	var req domain.ProjectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	project, err := h.timeService.UpdateProject(orgID, projectID, &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, project)
+}
+
+// @Summary List project tasks
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param id path string true "Project ID"
+// @Success 200 {array} domain.Task
+// @Router /organizations/{organization_id}/projects/{id}/tasks [get]
+func (h *TimeHandler) ListTasks(c *gin.Context) {
+	orgID, projectID, ok := projectParams(c)
+	if !ok {
+		return
+	}
+
+	tasks, err := h.timeService.ListTasks(orgID, projectID)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tasks)
+}
+
+// @Summary Create project task
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param id path string true "Project ID"
+// @Param request body domain.TaskRequest true "Task details"
+// @Success 201 {object} domain.Task
+// @Router /organizations/{organization_id}/projects/{id}/tasks [post]
+func (h *TimeHandler) CreateTask(c *gin.Context) {
+	orgID, projectID, ok := projectParams(c)
+	if !ok {
+		return
+	}
+
+	var req domain.TaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	task, err := h.timeService.CreateTask(orgID, projectID, &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, task)
+}
+
+// @Summary Update project task
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param id path string true "Project ID"
+// @Param task_id path string true "Task ID"
+// @Param request body domain.TaskRequest true "Task details"
+// @Success 200 {object} domain.Task
+// @Router /organizations/{organization_id}/projects/{id}/tasks/{task_id} [put]
+func (h *TimeHandler) UpdateTask(c *gin.Context) {
+	orgID, projectID, ok := projectParams(c)
+	if !ok {
+		return
+	}
+
+	taskID, err := uuid.Parse(c.Param("task_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task id"})
+		return
+	}
+
+	var req domain.TaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	task, err := h.timeService.UpdateTask(orgID, projectID, taskID, &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
+
+// @Summary Assign employee to project
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param id path string true "Project ID"
+// @Param request body domain.ProjectMemberRequest true "Member details"
+// @Success 201 {object} domain.ProjectMember
+// @Router /organizations/{organization_id}/projects/{id}/members [post]
+func (h *TimeHandler) AddProjectMember(c *gin.Context) {
+	orgID, projectID, ok := projectParams(c)
+	if !ok {
+		return
+	}
+
+	var req domain.ProjectMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	member, err := h.timeService.AddProjectMember(orgID, projectID, &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, member)
+}
+
+// @Summary Remove employee from project
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param id path string true "Project ID"
+// @Param employee_id path string true "Employee ID"
+// @Success 204
+// @Router /organizations/{organization_id}/projects/{id}/members/{employee_id} [delete]
+func (h *TimeHandler) RemoveProjectMember(c *gin.Context) {
+	orgID, projectID, ok := projectParams(c)
+	if !ok {
+		return
+	}
+
+	employeeID, err := uuid.Parse(c.Param("employee_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid employee id"})
+		return
+	}
+
+	if err := h.timeService.RemoveProjectMember(orgID, projectID, employeeID); err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+func projectParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	projectID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid project id"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return orgID, projectID, true
+}
@@ -0,0 +1,334 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/Axontik/comin-time-service/internal/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// @Summary Get remote work policy
+// @Tags remote-work
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Success 200 {object} domain.RemoteWorkPolicy
+// @Router /organizations/{organization_id}/remote-work/policy [get]
+func (h *TimeHandler) GetRemoteWorkPolicy(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return
+	}
+
+	policy, err := h.timeService.GetRemoteWorkPolicy(orgID)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, policy)
+}
+
+// @Summary Update remote work policy
+// @Tags remote-work
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param request body domain.RemoteWorkPolicyRequest true "Policy"
+// @Success 200 {object} domain.RemoteWorkPolicy
+// @Router /organizations/{organization_id}/remote-work/policy [put]
+func (h *TimeHandler) UpdateRemoteWorkPolicy(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return
+	}
+
+	var req domain.RemoteWorkPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	policy, err := h.timeService.UpdateRemoteWorkPolicy(orgID, &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, policy)
+}
+
+// @Summary Request remote work
+// @Tags remote-work
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param employee_id path string true "Employee ID"
+// @Param request body domain.CreateRemoteWorkRequest true "Remote work details"
+// @Success 201 {object} domain.RemoteWorkRequest
+// @Router /organizations/{organization_id}/employees/{employee_id}/remote-work-requests [post]
+func (h *TimeHandler) CreateRemoteWorkRequest(c *gin.Context) {
+	orgID, employeeID, ok := employeeParams(c)
+	if !ok {
+		return
+	}
+
+	var req domain.CreateRemoteWorkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	request, err := h.timeService.CreateRemoteWorkRequest(orgID, employeeID, &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, request)
+}
+
+// @Summary List employee remote work requests
+// @Tags remote-work
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param employee_id path string true "Employee ID"
+// @Param status query string false "pending, approved, rejected or cancelled"
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Success 200 {array} domain.RemoteWorkRequest
+// @Router /organizations/{organization_id}/employees/{employee_id}/remote-work-requests [get]
+func (h *TimeHandler) ListEmployeeRemoteWorkRequests(c *gin.Context) {
+	orgID, employeeID, ok := employeeParams(c)
+	if !ok {
+		return
+	}
+
+	filter, ok := remoteWorkRequestFilter(c)
+	if !ok {
+		return
+	}
+	filter.EmployeeID = &employeeID
+
+	requests, err := h.timeService.ListRemoteWorkRequests(orgID, filter)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, requests)
+}
+
+// @Summary Cancel remote work request
+// @Tags remote-work
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param employee_id path string true "Employee ID"
+// @Param id path string true "Remote work request ID"
+// @Success 200 {object} domain.RemoteWorkRequest
+// @Router /organizations/{organization_id}/employees/{employee_id}/remote-work-requests/{id}/cancel [put]
+func (h *TimeHandler) CancelRemoteWorkRequest(c *gin.Context) {
+	orgID, employeeID, ok := employeeParams(c)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid remote work request id"})
+		return
+	}
+
+	request, err := h.timeService.CancelRemoteWorkRequest(orgID, employeeID, id)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, request)
+}
+
+// @Summary List remote work requests
+// @Tags remote-work
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param employee_id query string false "Employee ID"
+// @Param status query string false "pending, approved, rejected or cancelled"
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Success 200 {array} domain.RemoteWorkRequest
+// @Router /organizations/{organization_id}/remote-work/requests [get]
+func (h *TimeHandler) ListRemoteWorkRequests(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return
+	}
+
+	filter, ok := remoteWorkRequestFilter(c)
+	if !ok {
+		return
+	}
+	if value := c.Query("employee_id"); value != "" {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid employee id"})
+			return
+		}
+		filter.EmployeeID = &id
+	}
+
+	requests, err := h.timeService.ListRemoteWorkRequests(orgID, filter)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, requests)
+}
+
+// @Summary Approve remote work request
+// @Tags remote-work
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param id path string true "Remote work request ID"
+// @Param request body domain.ReviewRemoteWorkRequest false "Review note"
+// @Success 200 {object} domain.RemoteWorkRequest
+// @Router /organizations/{organization_id}/remote-work/requests/{id}/approve [put]
+func (h *TimeHandler) ApproveRemoteWorkRequest(c *gin.Context) {
+	orgID, id, userID, req, ok := remoteWorkReviewParams(c)
+	if !ok {
+		return
+	}
+
+	request, err := h.timeService.ApproveRemoteWorkRequest(orgID, id, userID, req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, request)
+}
+
+// @Summary Reject remote work request
+// @Tags remote-work
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param id path string true "Remote work request ID"
+// @Param request body domain.ReviewRemoteWorkRequest false "Review note"
+// @Success 200 {object} domain.RemoteWorkRequest
+// @Router /organizations/{organization_id}/remote-work/requests/{id}/reject [put]
+func (h *TimeHandler) RejectRemoteWorkRequest(c *gin.Context) {
+	orgID, id, userID, req, ok := remoteWorkReviewParams(c)
+	if !ok {
+		return
+	}
+
+	request, err := h.timeService.RejectRemoteWorkRequest(orgID, id, userID, req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, request)
+}
+
+// @Summary List remote work violations
+// @Description Remote check-ins flagged against the remote work policy
+// @Tags remote-work
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param employee_id query string false "Employee ID"
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Success 200 {array} domain.RemoteWorkViolation
+// @Router /organizations/{organization_id}/remote-work/violations [get]
+func (h *TimeHandler) ListRemoteWorkViolations(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return
+	}
+
+	startDate, endDate, err := dateRangeQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	filter := &domain.RemoteWorkViolationFilter{StartDate: startDate, EndDate: endDate}
+	if value := c.Query("employee_id"); value != "" {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid employee id"})
+			return
+		}
+		filter.EmployeeID = &id
+	}
+
+	violations, err := h.timeService.ListRemoteWorkViolations(orgID, filter)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, violations)
+}
+
+// remoteWorkReviewParams reads the path parameters, the reviewing user and
+// the optional review note of an approval or rejection
+func remoteWorkReviewParams(c *gin.Context) (uuid.UUID, uuid.UUID, uuid.UUID, *domain.ReviewRemoteWorkRequest, bool) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return uuid.Nil, uuid.Nil, uuid.Nil, nil, false
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid remote work request id"})
+		return uuid.Nil, uuid.Nil, uuid.Nil, nil, false
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid user"})
+		return uuid.Nil, uuid.Nil, uuid.Nil, nil, false
+	}
+
+	req := &domain.ReviewRemoteWorkRequest{}
+	if err := c.ShouldBindJSON(req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return uuid.Nil, uuid.Nil, uuid.Nil, nil, false
+	}
+
+	return orgID, id, userID, req, true
+}
+
+// remoteWorkRequestFilter reads the status and date range query parameters
+func remoteWorkRequestFilter(c *gin.Context) (*domain.RemoteWorkRequestFilter, bool) {
+	startDate, endDate, err := dateRangeQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+
+	filter := &domain.RemoteWorkRequestFilter{StartDate: startDate, EndDate: endDate}
+	switch status := c.Query("status"); status {
+	case "", domain.RemoteRequestPending, domain.RemoteRequestApproved, domain.RemoteRequestRejected, domain.RemoteRequestCancelled:
+		filter.Status = status
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid status"})
+		return nil, false
+	}
+	return filter, true
+}
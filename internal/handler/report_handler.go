@@ -0,0 +1,221 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Axontik/comin-time-service/internal/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// @Summary Reconcile timesheets with attendance
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param employee_id query string false "Employee ID"
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Param tolerance query number false "Allowed difference in hours"
+// @Param discrepancies_only query bool false "Only return mismatched days"
+// @Success 200 {object} domain.ReconciliationReport
+// @Router /organizations/{organization_id}/reports/reconciliation [get]
+func (h *TimeHandler) GetReconciliationReport(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return
+	}
+
+	startDate, endDate, err := dateRangeQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	filter := &domain.ReconciliationFilter{
+		StartDate:         startDate,
+		EndDate:           endDate,
+		DiscrepanciesOnly: c.Query("discrepancies_only") == "true",
+	}
+
+	if value := c.Query("employee_id"); value != "" {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid employee id"})
+			return
+		}
+		filter.EmployeeID = &id
+	}
+
+	if value := c.Query("tolerance"); value != "" {
+		tolerance, err := strconv.ParseFloat(value, 64)
+		if err != nil || tolerance < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid tolerance"})
+			return
+		}
+		filter.Tolerance = &tolerance
+	}
+
+	report, err := h.timeService.GetReconciliationReport(orgID, filter)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// @Summary Utilization report
+// @Description Expected, attended, logged and billable hours with utilization percentages
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param group_by query string false "employee (default), team or project"
+// @Param employee_id query string false "Employee ID"
+// @Param team_id query string false "Team ID"
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Success 200 {object} domain.UtilizationReport
+// @Router /organizations/{organization_id}/reports/utilization [get]
+func (h *TimeHandler) GetUtilizationReport(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return
+	}
+
+	startDate, endDate, err := dateRangeQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	filter := &domain.UtilizationFilter{
+		GroupBy:   c.Query("group_by"),
+		StartDate: startDate,
+		EndDate:   endDate,
+	}
+
+	if value := c.Query("employee_id"); value != "" {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid employee id"})
+			return
+		}
+		filter.EmployeeID = &id
+	}
+
+	if value := c.Query("team_id"); value != "" {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid team id"})
+			return
+		}
+		filter.TeamID = &id
+	}
+
+	report, err := h.timeService.GetUtilizationReport(orgID, filter)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// @Summary Attendance report
+// @Description Pages through attendance records, or their groups by day, employee or site, with headcount, late count and average check-in aggregates
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Param employee_id query string false "Employee ID"
+// @Param status query string false "Attendance status"
+// @Param work_mode query string false "office, remote or hybrid"
+// @Param site query string false "Site of the employees"
+// @Param group_by query string false "day, employee or site"
+// @Param cursor query string false "next_cursor of the previous page, requested with the same group_by"
+// @Param limit query int false "Page size"
+// @Success 200 {object} domain.AttendanceReport
+// @Router /organizations/{organization_id}/reports/attendance [get]
+func (h *TimeHandler) GetAttendanceReport(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return
+	}
+
+	startDate, endDate, err := dateRangeQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	attendance, ok := attendanceFilter(c)
+	if !ok {
+		return
+	}
+	attendance.StartDate = startDate
+	attendance.EndDate = endDate
+
+	filter := &domain.AttendanceReportFilter{
+		AttendanceFilter: *attendance,
+		GroupBy:          c.Query("group_by"),
+		Cursor:           c.Query("cursor"),
+	}
+
+	if value := c.Query("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+			return
+		}
+		filter.Limit = limit
+	}
+
+	report, err := h.timeService.GetAttendanceReport(orgID, filter)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// attendanceFilter reads the employee_id, status, work_mode and site query
+// parameters
+func attendanceFilter(c *gin.Context) (*domain.AttendanceFilter, bool) {
+	filter := &domain.AttendanceFilter{Site: c.Query("site")}
+
+	if value := c.Query("employee_id"); value != "" {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid employee id"})
+			return nil, false
+		}
+		filter.EmployeeID = &id
+	}
+
+	switch status := c.Query("status"); status {
+	case "", domain.AttendanceStatusPresent, domain.AttendanceStatusAbsent, domain.AttendanceStatusLate, domain.AttendanceStatusHalfDay, domain.AttendanceStatusLeave:
+		filter.Status = status
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid status"})
+		return nil, false
+	}
+
+	switch workMode := c.Query("work_mode"); workMode {
+	case "", domain.WorkModeOffice, domain.WorkModeRemote, domain.WorkModeHybrid:
+		filter.WorkMode = workMode
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid work mode"})
+		return nil, false
+	}
+
+	return filter, true
+}
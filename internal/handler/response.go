@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	apperrors "github.com/Axontik/comin-time-service/internal/errors"
+	"github.com/Axontik/comin-time-service/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// respondError writes an AppError with its own status and code, and any
+// other error with the given fallback status.
+func respondError(c *gin.Context, status int, err error) {
+	var appErr *apperrors.AppError
+	if errors.As(err, &appErr) {
+		c.JSON(appErr.HTTPStatus, appErr)
+		return
+	}
+	c.JSON(status, gin.H{"error": err.Error()})
+}
+
+// currentUserID returns the ID of the authenticated user set by the
+// organization access middleware.
+func currentUserID(c *gin.Context) (uuid.UUID, bool) {
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+// dateRangeQuery reads the start_date and end_date query parameters,
+// defaulting to the current month.
+func dateRangeQuery(c *gin.Context) (time.Time, time.Time, error) {
+	today := time.Now().Truncate(24 * time.Hour)
+	startDate := utils.GetStartOfMonth(today)
+	endDate := utils.GetEndOfMonth(today)
+
+	if value := c.Query("start_date"); value != "" {
+		date, err := utils.ParseDate(value)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid start_date, expected YYYY-MM-DD")
+		}
+		startDate = date
+	}
+
+	if value := c.Query("end_date"); value != "" {
+		date, err := utils.ParseDate(value)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid end_date, expected YYYY-MM-DD")
+		}
+		endDate = date
+	}
+
+	if endDate.Before(startDate) {
+		return time.Time{}, time.Time{}, fmt.Errorf("end_date must not be before start_date")
+	}
+
+	return startDate, endDate, nil
+}
+
+// employeeParams reads the organization_id and employee_id path parameters
+func employeeParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	employeeID, err := uuid.Parse(c.Param("employee_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid employee id"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return orgID, employeeID, true
+}
+
+// timesheetParams reads the employee path parameters and the timesheet id
+func timesheetParams(c *gin.Context) (uuid.UUID, uuid.UUID, uuid.UUID, bool) {
+	orgID, employeeID, ok := employeeParams(c)
+	if !ok {
+		return uuid.Nil, uuid.Nil, uuid.Nil, false
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid timesheet id"})
+		return uuid.Nil, uuid.Nil, uuid.Nil, false
+	}
+
+	return orgID, employeeID, id, true
+}
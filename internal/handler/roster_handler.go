@@ -0,0 +1,631 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/Axontik/comin-time-service/internal/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// @Summary List rosters
+// @Tags rosters
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Success 200 {array} domain.Roster
+// @Router /organizations/{organization_id}/rosters [get]
+func (h *TimeHandler) ListRosters(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return
+	}
+
+	startDate, endDate, err := dateRangeQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rosters, err := h.timeService.ListRosters(orgID, startDate, endDate)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rosters)
+}
+
+// @Summary Create roster
+// @Description Creates a draft roster for the work week containing week_start
+// @Tags rosters
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param request body domain.RosterRequest true "Roster details"
+// @Success 201 {object} domain.Roster
+// @Router /organizations/{organization_id}/rosters [post]
+func (h *TimeHandler) CreateRoster(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return
+	}
+
+	var req domain.RosterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	roster, err := h.timeService.CreateRoster(orgID, &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, roster)
+}
+
+// @Summary Get roster
+// @Tags rosters
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param id path string true "Roster ID"
+// @Success 200 {object} domain.Roster
+// @Router /organizations/{organization_id}/rosters/{id} [get]
+func (h *TimeHandler) GetRoster(c *gin.Context) {
+	orgID, id, ok := rosterParams(c)
+	if !ok {
+		return
+	}
+
+	roster, err := h.timeService.GetRoster(orgID, id)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, roster)
+}
+
+// @Summary Update roster
+// @Tags rosters
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param id path string true "Roster ID"
+// @Param request body domain.RosterRequest true "Roster details"
+// @Success 200 {object} domain.Roster
+// @Router /organizations/{organization_id}/rosters/{id} [put]
+func (h *TimeHandler) UpdateRoster(c *gin.Context) {
+	orgID, id, ok := rosterParams(c)
+	if !ok {
+		return
+	}
+
+	var req domain.RosterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	roster, err := h.timeService.UpdateRoster(orgID, id, &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, roster)
+}
+
+// @Summary Delete roster
+// @Tags rosters
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param id path string true "Roster ID"
+// @Success 204
+// @Router /organizations/{organization_id}/rosters/{id} [delete]
+func (h *TimeHandler) DeleteRoster(c *gin.Context) {
+	orgID, id, ok := rosterParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.timeService.DeleteRoster(orgID, id); err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// @Summary Publish roster
+// @Tags rosters
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param id path string true "Roster ID"
+// @Success 200 {object} domain.Roster
+// @Router /organizations/{organization_id}/rosters/{id}/publish [put]
+func (h *TimeHandler) PublishRoster(c *gin.Context) {
+	orgID, id, ok := rosterParams(c)
+	if !ok {
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid user"})
+		return
+	}
+
+	roster, err := h.timeService.PublishRoster(orgID, id, userID)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, roster)
+}
+
+// @Summary Unpublish roster
+// @Description Returns the roster to draft and cancels pending swaps of its shifts
+// @Tags rosters
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param id path string true "Roster ID"
+// @Success 200 {object} domain.Roster
+// @Router /organizations/{organization_id}/rosters/{id}/unpublish [put]
+func (h *TimeHandler) UnpublishRoster(c *gin.Context) {
+	orgID, id, ok := rosterParams(c)
+	if !ok {
+		return
+	}
+
+	roster, err := h.timeService.UnpublishRoster(orgID, id)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, roster)
+}
+
+// @Summary Add shift
+// @Description Adds a shift to a draft roster; shifts without an employee are open
+// @Tags rosters
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param id path string true "Roster ID"
+// @Param request body domain.ShiftRequest true "Shift details"
+// @Success 201 {object} domain.Shift
+// @Router /organizations/{organization_id}/rosters/{id}/shifts [post]
+func (h *TimeHandler) AddShift(c *gin.Context) {
+	orgID, id, ok := rosterParams(c)
+	if !ok {
+		return
+	}
+
+	var req domain.ShiftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	shift, err := h.timeService.AddShift(orgID, id, &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, shift)
+}
+
+// @Summary Update shift
+// @Tags rosters
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param id path string true "Roster ID"
+// @Param shift_id path string true "Shift ID"
+// @Param request body domain.ShiftRequest true "Shift details"
+// @Success 200 {object} domain.Shift
+// @Router /organizations/{organization_id}/rosters/{id}/shifts/{shift_id} [put]
+func (h *TimeHandler) UpdateShift(c *gin.Context) {
+	orgID, id, shiftID, ok := shiftParams(c)
+	if !ok {
+		return
+	}
+
+	var req domain.ShiftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	shift, err := h.timeService.UpdateShift(orgID, id, shiftID, &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, shift)
+}
+
+// @Summary Delete shift
+// @Tags rosters
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param id path string true "Roster ID"
+// @Param shift_id path string true "Shift ID"
+// @Success 204
+// @Router /organizations/{organization_id}/rosters/{id}/shifts/{shift_id} [delete]
+func (h *TimeHandler) DeleteShift(c *gin.Context) {
+	orgID, id, shiftID, ok := shiftParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.timeService.DeleteShift(orgID, id, shiftID); err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// @Summary List open shifts
+// @Description Unassigned shifts of published rosters that employees can claim
+// @Tags rosters
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Success 200 {array} domain.Shift
+// @Router /organizations/{organization_id}/open-shifts [get]
+func (h *TimeHandler) ListOpenShifts(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return
+	}
+
+	startDate, endDate, err := dateRangeQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	shifts, err := h.timeService.ListOpenShifts(orgID, startDate, endDate)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, shifts)
+}
+
+// @Summary List employee shifts
+// @Description The employee's schedule from published rosters
+// @Tags rosters
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param employee_id path string true "Employee ID"
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Success 200 {array} domain.Shift
+// @Router /organizations/{organization_id}/employees/{employee_id}/shifts [get]
+func (h *TimeHandler) ListEmployeeShifts(c *gin.Context) {
+	orgID, employeeID, ok := employeeParams(c)
+	if !ok {
+		return
+	}
+
+	startDate, endDate, err := dateRangeQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	shifts, err := h.timeService.ListEmployeeShifts(orgID, employeeID, startDate, endDate)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, shifts)
+}
+
+// @Summary Claim open shift
+// @Tags rosters
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param employee_id path string true "Employee ID"
+// @Param id path string true "Shift ID"
+// @Success 200 {object} domain.Shift
+// @Router /organizations/{organization_id}/employees/{employee_id}/shifts/{id}/claim [post]
+func (h *TimeHandler) ClaimOpenShift(c *gin.Context) {
+	orgID, employeeID, ok := employeeParams(c)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid shift id"})
+		return
+	}
+
+	shift, err := h.timeService.ClaimOpenShift(orgID, employeeID, id)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, shift)
+}
+
+// @Summary Request shift swap
+// @Tags rosters
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param employee_id path string true "Employee ID"
+// @Param request body domain.CreateShiftSwapRequest true "Swap details"
+// @Success 201 {object} domain.ShiftSwapRequest
+// @Router /organizations/{organization_id}/employees/{employee_id}/shift-swaps [post]
+func (h *TimeHandler) CreateShiftSwapRequest(c *gin.Context) {
+	orgID, employeeID, ok := employeeParams(c)
+	if !ok {
+		return
+	}
+
+	var req domain.CreateShiftSwapRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	request, err := h.timeService.CreateShiftSwapRequest(orgID, employeeID, &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, request)
+}
+
+// @Summary List employee shift swaps
+// @Description Swap requests the employee made or is the target of
+// @Tags rosters
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param employee_id path string true "Employee ID"
+// @Param status query string false "pending, approved, rejected or cancelled"
+// @Success 200 {array} domain.ShiftSwapRequest
+// @Router /organizations/{organization_id}/employees/{employee_id}/shift-swaps [get]
+func (h *TimeHandler) ListEmployeeShiftSwapRequests(c *gin.Context) {
+	orgID, employeeID, ok := employeeParams(c)
+	if !ok {
+		return
+	}
+
+	filter, ok := shiftSwapFilter(c)
+	if !ok {
+		return
+	}
+	filter.EmployeeID = &employeeID
+
+	requests, err := h.timeService.ListShiftSwapRequests(orgID, filter)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, requests)
+}
+
+// @Summary Cancel shift swap request
+// @Tags rosters
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param employee_id path string true "Employee ID"
+// @Param id path string true "Shift swap request ID"
+// @Success 200 {object} domain.ShiftSwapRequest
+// @Router /organizations/{organization_id}/employees/{employee_id}/shift-swaps/{id}/cancel [put]
+func (h *TimeHandler) CancelShiftSwapRequest(c *gin.Context) {
+	orgID, employeeID, ok := employeeParams(c)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid shift swap request id"})
+		return
+	}
+
+	request, err := h.timeService.CancelShiftSwapRequest(orgID, employeeID, id)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, request)
+}
+
+// @Summary List shift swap requests
+// @Tags rosters
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param employee_id query string false "Employee ID"
+// @Param status query string false "pending, approved, rejected or cancelled"
+// @Success 200 {array} domain.ShiftSwapRequest
+// @Router /organizations/{organization_id}/shift-swaps [get]
+func (h *TimeHandler) ListShiftSwapRequests(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return
+	}
+
+	filter, ok := shiftSwapFilter(c)
+	if !ok {
+		return
+	}
+	if value := c.Query("employee_id"); value != "" {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid employee id"})
+			return
+		}
+		filter.EmployeeID = &id
+	}
+
+	requests, err := h.timeService.ListShiftSwapRequests(orgID, filter)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, requests)
+}
+
+// @Summary Approve shift swap request
+// @Tags rosters
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param id path string true "Shift swap request ID"
+// @Param request body domain.ReviewShiftSwapRequest false "Review note"
+// @Success 200 {object} domain.ShiftSwapRequest
+// @Router /organizations/{organization_id}/shift-swaps/{id}/approve [put]
+func (h *TimeHandler) ApproveShiftSwapRequest(c *gin.Context) {
+	orgID, id, userID, req, ok := shiftSwapReviewParams(c)
+	if !ok {
+		return
+	}
+
+	request, err := h.timeService.ApproveShiftSwapRequest(orgID, id, userID, req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, request)
+}
+
+// @Summary Reject shift swap request
+// @Tags rosters
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param id path string true "Shift swap request ID"
+// @Param request body domain.ReviewShiftSwapRequest false "Review note"
+// @Success 200 {object} domain.ShiftSwapRequest
+// @Router /organizations/{organization_id}/shift-swaps/{id}/reject [put]
+func (h *TimeHandler) RejectShiftSwapRequest(c *gin.Context) {
+	orgID, id, userID, req, ok := shiftSwapReviewParams(c)
+	if !ok {
+		return
+	}
+
+	request, err := h.timeService.RejectShiftSwapRequest(orgID, id, userID, req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, request)
+}
+
+// rosterParams reads the organization_id and roster id path parameters
+func rosterParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid roster id"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return orgID, id, true
+}
+
+// shiftParams reads the roster path parameters and the shift_id
+func shiftParams(c *gin.Context) (uuid.UUID, uuid.UUID, uuid.UUID, bool) {
+	orgID, id, ok := rosterParams(c)
+	if !ok {
+		return uuid.Nil, uuid.Nil, uuid.Nil, false
+	}
+
+	shiftID, err := uuid.Parse(c.Param("shift_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid shift id"})
+		return uuid.Nil, uuid.Nil, uuid.Nil, false
+	}
+
+	return orgID, id, shiftID, true
+}
+
+// shiftSwapReviewParams reads the path parameters, the reviewing user and
+// the optional review note of an approval or rejection
+func shiftSwapReviewParams(c *gin.Context) (uuid.UUID, uuid.UUID, uuid.UUID, *domain.ReviewShiftSwapRequest, bool) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return uuid.Nil, uuid.Nil, uuid.Nil, nil, false
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid shift swap request id"})
+		return uuid.Nil, uuid.Nil, uuid.Nil, nil, false
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid user"})
+		return uuid.Nil, uuid.Nil, uuid.Nil, nil, false
+	}
+
+	req := &domain.ReviewShiftSwapRequest{}
+	if err := c.ShouldBindJSON(req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return uuid.Nil, uuid.Nil, uuid.Nil, nil, false
+	}
+
+	return orgID, id, userID, req, true
+}
+
+// shiftSwapFilter reads the status query parameter
+func shiftSwapFilter(c *gin.Context) (*domain.ShiftSwapFilter, bool) {
+	filter := &domain.ShiftSwapFilter{}
+	switch status := c.Query("status"); status {
+	case "", domain.ShiftSwapPending, domain.ShiftSwapApproved, domain.ShiftSwapRejected, domain.ShiftSwapCancelled:
+		filter.Status = status
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid status"})
+		return nil, false
+	}
+	return filter, true
+}
@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Axontik/comin-time-service/internal/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// @Summary List teams
+// @Tags teams
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Success 200 {array} domain.Team
+// @Router /organizations/{organization_id}/teams [get]
+func (h *TimeHandler) ListTeams(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return
+	}
+
+	teams, err := h.timeService.ListTeams(orgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, teams)
+}
+
+// @Summary Create team
+// @Tags teams
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param request body domain.TeamRequest true "Team details"
+// @Success 201 {object} domain.Team
+// @Router /organizations/{organization_id}/teams [post]
+func (h *TimeHandler) CreateTeam(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return
+	}
+
+	var req domain.TeamRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	team, err := h.timeService.CreateTeam(orgID, &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, team)
+}
+
+// @Summary Update team
+// @Tags teams
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param id path string true "Team ID"
+// @Param request body domain.TeamRequest true "Team details"
+// @Success 200 {object} domain.Team
+// @Router /organizations/{organization_id}/teams/{id} [put]
+func (h *TimeHandler) UpdateTeam(c *gin.Context) {
+	orgID, id, ok := teamParams(c)
+	if !ok {
+		return
+	}
+
+	var req domain.TeamRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	team, err := h.timeService.UpdateTeam(orgID, id, &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, team)
+}
+
+// @Summary Delete team
+// @Tags teams
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param id path string true "Team ID"
+// @Success 204
+// @Router /organizations/{organization_id}/teams/{id} [delete]
+func (h *TimeHandler) DeleteTeam(c *gin.Context) {
+	orgID, id, ok := teamParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.timeService.DeleteTeam(orgID, id); err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// @Summary Set employee team
+// @Description Team the employee belongs to; no team removes the assignment
+// @Tags teams
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param employee_id path string true "Employee ID"
+// @Param request body domain.EmployeeTeamRequest true "Team"
+// @Success 200 {object} domain.EmployeeTeam
+// @Router /organizations/{organization_id}/employees/{employee_id}/team [put]
+func (h *TimeHandler) SetEmployeeTeam(c *gin.Context) {
+	orgID, employeeID, ok := employeeParams(c)
+	if !ok {
+		return
+	}
+
+	var req domain.EmployeeTeamRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	membership, err := h.timeService.SetEmployeeTeam(orgID, employeeID, &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, membership)
+}
+
+// teamParams reads the organization_id and team id path parameters
+func teamParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid team id"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return orgID, id, true
+}
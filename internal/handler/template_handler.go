@@ -0,0 +1,227 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Axontik/comin-time-service/internal/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// @Summary List timesheet templates
+// @Tags timesheet-templates
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param employee_id path string true "Employee ID"
+// @Success 200 {array} domain.TimesheetTemplate
+// @Router /organizations/{organization_id}/employees/{employee_id}/timesheet-templates [get]
+func (h *TimeHandler) ListTimesheetTemplates(c *gin.Context) {
+	orgID, employeeID, ok := employeeParams(c)
+	if !ok {
+		return
+	}
+
+	templates, err := h.timeService.ListTimesheetTemplates(orgID, employeeID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, templates)
+}
+
+// @Summary Create timesheet template
+// @Tags timesheet-templates
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param employee_id path string true "Employee ID"
+// @Param request body domain.TimesheetTemplateRequest true "Template details"
+// @Success 201 {object} domain.TimesheetTemplate
+// @Router /organizations/{organization_id}/employees/{employee_id}/timesheet-templates [post]
+func (h *TimeHandler) CreateTimesheetTemplate(c *gin.Context) {
+	orgID, employeeID, ok := employeeParams(c)
+	if !ok {
+		return
+	}
+
+	var req domain.TimesheetTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	template, err := h.timeService.CreateTimesheetTemplate(orgID, employeeID, &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, template)
+}
+
+// @Summary Update timesheet template
+// @Tags timesheet-templates
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param employee_id path string true "Employee ID"
+// @Param id path string true "Template ID"
+// @Param request body domain.TimesheetTemplateRequest true "Template details"
+// @Success 200 {object} domain.TimesheetTemplate
+// @Router /organizations/{organization_id}/employees/{employee_id}/timesheet-templates/{id} [put]
+func (h *TimeHandler) UpdateTimesheetTemplate(c *gin.Context) {
+	orgID, employeeID, id, ok := templateParams(c)
+	if !ok {
+		return
+	}
+
+	var req domain.TimesheetTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	template, err := h.timeService.UpdateTimesheetTemplate(orgID, employeeID, id, &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+// @Summary Delete timesheet template
+// @Tags timesheet-templates
+// @Param organization_id path string true "Organization ID"
+// @Param employee_id path string true "Employee ID"
+// @Param id path string true "Template ID"
+// @Success 204
+// @Router /organizations/{organization_id}/employees/{employee_id}/timesheet-templates/{id} [delete]
+func (h *TimeHandler) DeleteTimesheetTemplate(c *gin.Context) {
+	orgID, employeeID, id, ok := templateParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.timeService.DeleteTimesheetTemplate(orgID, employeeID, id); err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// @Summary Apply timesheet template to a week
+// @Tags timesheet-templates
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param employee_id path string true "Employee ID"
+// @Param id path string true "Template ID"
+// @Param request body domain.ApplyTemplateRequest true "Target week"
+// @Success 201 {object} domain.BulkTimesheetResult
+// @Router /organizations/{organization_id}/employees/{employee_id}/timesheet-templates/{id}/apply [post]
+func (h *TimeHandler) ApplyTimesheetTemplate(c *gin.Context) {
+	orgID, employeeID, id, ok := templateParams(c)
+	if !ok {
+		return
+	}
+
+	var req domain.ApplyTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.timeService.ApplyTimesheetTemplate(orgID, employeeID, id, &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// @Summary Copy previous week's entries as drafts
+// @Tags timesheets
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param employee_id path string true "Employee ID"
+// @Param request body domain.CopyPreviousWeekRequest true "Target week"
+// @Success 201 {object} domain.BulkTimesheetResult
+// @Router /organizations/{organization_id}/employees/{employee_id}/timesheets/copy-previous-week [post]
+func (h *TimeHandler) CopyPreviousWeek(c *gin.Context) {
+	orgID, employeeID, ok := employeeParams(c)
+	if !ok {
+		return
+	}
+
+	var req domain.CopyPreviousWeekRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.timeService.CopyPreviousWeek(orgID, employeeID, &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// @Summary Submit draft timesheets for approval
+// @Tags timesheets
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param employee_id path string true "Employee ID"
+// @Param start_date query string false "Start date (YYYY-MM-DD), defaults to the start of the month"
+// @Param end_date query string false "End date (YYYY-MM-DD), defaults to the end of the month"
+// @Success 200 {array} domain.TimesheetResponse
+// @Router /organizations/{organization_id}/employees/{employee_id}/timesheets/submit [post]
+func (h *TimeHandler) SubmitTimesheets(c *gin.Context) {
+	orgID, employeeID, ok := employeeParams(c)
+	if !ok {
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid user"})
+		return
+	}
+
+	startDate, endDate, err := dateRangeQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	timesheets, err := h.timeService.SubmitTimesheets(orgID, employeeID, userID, startDate, endDate)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, timesheets)
+}
+
+func templateParams(c *gin.Context) (uuid.UUID, uuid.UUID, uuid.UUID, bool) {
+	orgID, employeeID, ok := employeeParams(c)
+	if !ok {
+		return uuid.Nil, uuid.Nil, uuid.Nil, false
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid template id"})
+		return uuid.Nil, uuid.Nil, uuid.Nil, false
+	}
+
+	return orgID, employeeID, id, true
+}
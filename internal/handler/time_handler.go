@@ -2,9 +2,12 @@ package handler
 
 import (
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/Axontik/comin-time-service/internal/domain"
 	"github.com/Axontik/comin-time-service/internal/service"
+	"github.com/Axontik/comin-time-service/utils"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
@@ -54,7 +57,7 @@ func (h *TimeHandler) GenerateQRCode(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param request body domain.CheckInRequest true "Check-in details"
-// @Success 200 {object} domain.Attendance
+// @Success 200 {object} domain.AttendanceResult
 // @Router /attendance/check-in [post]
 func (h *TimeHandler) CheckIn(c *gin.Context) {
 	var req domain.CheckInRequest
@@ -65,7 +68,7 @@ func (h *TimeHandler) CheckIn(c *gin.Context) {
 
 	attendance, err := h.timeService.CheckIn(&req)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(c, http.StatusBadRequest, err)
 		return
 	}
 
@@ -77,7 +80,7 @@ func (h *TimeHandler) CheckIn(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param request body domain.CheckOutRequest true "Check-out details"
-// @Success 200 {object} domain.Attendance
+// @Success 200 {object} domain.AttendanceResult
 // @Router /attendance/check-out [post]
 func (h *TimeHandler) CheckOut(c *gin.Context) {
 	var req domain.CheckOutRequest
@@ -88,10 +91,56 @@ func (h *TimeHandler) CheckOut(c *gin.Context) {
 
 	attendance, err := h.timeService.CheckOut(&req)
 	if err != nil {
+		respondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, attendance)
+}
+
+// @Summary Start break
+// @Tags attendance
+// @Accept json
+// @Produce json
+// @Param request body domain.BreakRequest true "Break punch"
+// @Success 200 {object} domain.Attendance
+// @Router /attendance/break-start [post]
+func (h *TimeHandler) StartBreak(c *gin.Context) {
+	var req domain.BreakRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
+	attendance, err := h.timeService.StartBreak(&req)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, attendance)
+}
+
+// @Summary End break
+// @Tags attendance
+// @Accept json
+// @Produce json
+// @Param request body domain.BreakRequest true "Break punch"
+// @Success 200 {object} domain.Attendance
+// @Router /attendance/break-end [post]
+func (h *TimeHandler) EndBreak(c *gin.Context) {
+	var req domain.BreakRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	attendance, err := h.timeService.EndBreak(&req)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, err)
+		return
+	}
+
 	c.JSON(http.StatusOK, attendance)
 }
 
@@ -102,7 +151,7 @@ func (h *TimeHandler) CheckOut(c *gin.Context) {
 // @Param organization_id path string true "Organization ID"
 // @Param employee_id path string true "Employee ID"
 // @Param request body domain.CreateTimesheetRequest true "Timesheet details"
-// @Success 201 {object} domain.Timesheet
+// @Success 201 {object} domain.TimesheetResult
 // @Router /organizations/{organization_id}/employees/{employee_id}/timesheets [post]
 func (h *TimeHandler) CreateTimesheet(c *gin.Context) {
 	orgID, err := uuid.Parse(c.Param("organization_id"))
@@ -125,19 +174,63 @@ func (h *TimeHandler) CreateTimesheet(c *gin.Context) {
 
 	timesheet, err := h.timeService.CreateTimesheet(orgID, employeeID, &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, http.StatusInternalServerError, err)
 		return
 	}
 
 	c.JSON(http.StatusCreated, timesheet)
 }
 
+// @Summary List timesheets
+// @Tags timesheets
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param employee_id path string true "Employee ID"
+// @Param start_date query string false "Start date (YYYY-MM-DD), defaults to the start of the month"
+// @Param end_date query string false "End date (YYYY-MM-DD), defaults to the end of the month"
+// @Param deleted query bool false "List deleted entries instead"
+// @Success 200 {array} domain.TimesheetResponse
+// @Router /organizations/{organization_id}/employees/{employee_id}/timesheets [get]
+func (h *TimeHandler) ListTimesheets(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return
+	}
+
+	employeeID, err := uuid.Parse(c.Param("employee_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid employee id"})
+		return
+	}
+
+	startDate, endDate, err := dateRangeQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var timesheets []domain.TimesheetResponse
+	if c.Query("deleted") == "true" {
+		timesheets, err = h.timeService.ListDeletedTimesheets(orgID, employeeID, startDate, endDate)
+	} else {
+		timesheets, err = h.timeService.ListTimesheets(orgID, employeeID, startDate, endDate)
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, timesheets)
+}
+
 // @Summary Get timesheet by ID
 // @Tags timesheets
 // @Accept json
 // @Produce json
 // @Param id path string true "Timesheet ID"
-// @Success 200 {object} domain.Timesheet
+// @Success 200 {object} domain.TimesheetResponse
 // @Router /timesheets/{id} [get]
 func (h *TimeHandler) GetTimesheet(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
@@ -161,7 +254,7 @@ func (h *TimeHandler) GetTimesheet(c *gin.Context) {
 // @Produce json
 // @Param id path string true "Timesheet ID"
 // @Param request body domain.CreateTimesheetRequest true "Timesheet details"
-// @Success 200 {object} domain.Timesheet
+// @Success 200 {object} domain.TimesheetResult
 // @Router /timesheets/{id} [put]
 func (h *TimeHandler) UpdateTimesheet(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
@@ -170,15 +263,21 @@ func (h *TimeHandler) UpdateTimesheet(c *gin.Context) {
 		return
 	}
 
+	userID, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid user"})
+		return
+	}
+
 	var req domain.CreateTimesheetRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	timesheet, err := h.timeService.UpdateTimesheet(id, &req)
+	timesheet, err := h.timeService.UpdateTimesheet(id, userID, &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -199,15 +298,74 @@ func (h *TimeHandler) DeleteTimesheet(c *gin.Context) {
 		return
 	}
 
-	err = h.timeService.DeleteTimesheet(id)
+	userID, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid user"})
+		return
+	}
+
+	err = h.timeService.DeleteTimesheet(id, userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, http.StatusInternalServerError, err)
 		return
 	}
 
 	c.JSON(http.StatusNoContent, nil)
 }
 
+// @Summary Restore deleted timesheet
+// @Tags timesheets
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param employee_id path string true "Employee ID"
+// @Param id path string true "Timesheet ID"
+// @Success 200 {object} domain.TimesheetResult
+// @Router /organizations/{organization_id}/employees/{employee_id}/timesheets/{id}/restore [put]
+func (h *TimeHandler) RestoreTimesheet(c *gin.Context) {
+	orgID, employeeID, id, ok := timesheetParams(c)
+	if !ok {
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid user"})
+		return
+	}
+
+	timesheet, err := h.timeService.RestoreTimesheet(orgID, employeeID, id, userID)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, timesheet)
+}
+
+// @Summary Purge deleted timesheets past the retention window
+// @Tags timesheets
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Success 200 {object} map[string]int64
+// @Router /organizations/{organization_id}/timesheets/purge [post]
+func (h *TimeHandler) PurgeDeletedTimesheets(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return
+	}
+
+	purged, err := h.timeService.PurgeDeletedTimesheets(orgID)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"purged": purged})
+}
+
 // @Summary List attendances
 // @Tags attendance
 // @Accept json
@@ -215,6 +373,10 @@ func (h *TimeHandler) DeleteTimesheet(c *gin.Context) {
 // @Param organization_id path string true "Organization ID"
 // @Param start_date query string false "Start date (YYYY-MM-DD)"
 // @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Param employee_id query string false "Employee ID"
+// @Param status query string false "Attendance status"
+// @Param work_mode query string false "office, remote or hybrid"
+// @Param site query string false "Site of the employees"
 // @Success 200 {array} domain.Attendance
 // @Router /organizations/{organization_id}/attendance [get]
 func (h *TimeHandler) ListAttendances(c *gin.Context) {
@@ -224,19 +386,28 @@ func (h *TimeHandler) ListAttendances(c *gin.Context) {
 		return
 	}
 
-	// startDateStr := c.Query("start_date")
-	// startDate, err := time.Parse("2006-01-02", startDateStr)
-	// if err != nil {
-	// 	startDate = time.Time{}
-	// }
+	filter, ok := attendanceFilter(c)
+	if !ok {
+		return
+	}
+
+	if value := c.Query("start_date"); value != "" {
+		filter.StartDate, err = utils.ParseDate(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid start_date, expected YYYY-MM-DD"})
+			return
+		}
+	}
 
-	// endDateStr := c.Query("end_date")
-	// endDate, err := time.Parse("2006-01-02", endDateStr)
-	// if err != nil {
-	// 	endDate = time.Time{}
-	// }
+	if value := c.Query("end_date"); value != "" {
+		filter.EndDate, err = utils.ParseDate(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid end_date, expected YYYY-MM-DD"})
+			return
+		}
+	}
 
-	attendances, err := h.timeService.ListAttendances(orgID)
+	attendances, err := h.timeService.ListAttendances(orgID, filter)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -245,6 +416,51 @@ func (h *TimeHandler) ListAttendances(c *gin.Context) {
 	c.JSON(http.StatusOK, attendances)
 }
 
+// @Summary Get employee attendance summary
+// @Description Counts per status over a month, with absences, weekends and holidays
+// @Tags attendance
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param employee_id path string true "Employee ID"
+// @Param month query int false "Month (1-12), defaults to the current month"
+// @Param year query int false "Year, defaults to the current year"
+// @Success 200 {object} map[string]int
+// @Router /organizations/{organization_id}/attendance/{employee_id}/summary [get]
+func (h *TimeHandler) GetAttendanceSummary(c *gin.Context) {
+	orgID, employeeID, ok := employeeParams(c)
+	if !ok {
+		return
+	}
+
+	now := time.Now()
+	month, year := int(now.Month()), now.Year()
+	if value := c.Query("month"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid month"})
+			return
+		}
+		month = parsed
+	}
+	if value := c.Query("year"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid year"})
+			return
+		}
+		year = parsed
+	}
+
+	summary, err := h.timeService.GetAttendanceSummary(orgID, employeeID, month, year)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
+
 // @Summary Get employee QR codes
 // @Tags qr-codes
 // @Accept json
@@ -0,0 +1,190 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Axontik/comin-time-service/internal/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// @Summary Start timer
+// @Tags timers
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param employee_id path string true "Employee ID"
+// @Param request body domain.StartTimerRequest true "Timer details"
+// @Success 201 {object} domain.Timer
+// @Router /organizations/{organization_id}/employees/{employee_id}/timers [post]
+func (h *TimeHandler) StartTimer(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return
+	}
+
+	employeeID, err := uuid.Parse(c.Param("employee_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid employee id"})
+		return
+	}
+
+	var req domain.StartTimerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	timer, err := h.timeService.StartTimer(orgID, employeeID, &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, timer)
+}
+
+// @Summary List active timers
+// @Tags timers
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param employee_id path string true "Employee ID"
+// @Success 200 {array} domain.Timer
+// @Router /organizations/{organization_id}/employees/{employee_id}/timers [get]
+func (h *TimeHandler) ListActiveTimers(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return
+	}
+
+	employeeID, err := uuid.Parse(c.Param("employee_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid employee id"})
+		return
+	}
+
+	timers, err := h.timeService.ListActiveTimers(orgID, employeeID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, timers)
+}
+
+// @Summary Get timer
+// @Tags timers
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param employee_id path string true "Employee ID"
+// @Param id path string true "Timer ID"
+// @Success 200 {object} domain.Timer
+// @Router /organizations/{organization_id}/employees/{employee_id}/timers/{id} [get]
+func (h *TimeHandler) GetTimer(c *gin.Context) {
+	h.timerAction(c, h.timeService.GetTimer)
+}
+
+// @Summary Pause timer
+// @Tags timers
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param employee_id path string true "Employee ID"
+// @Param id path string true "Timer ID"
+// @Success 200 {object} domain.Timer
+// @Router /organizations/{organization_id}/employees/{employee_id}/timers/{id}/pause [put]
+func (h *TimeHandler) PauseTimer(c *gin.Context) {
+	h.timerAction(c, h.timeService.PauseTimer)
+}
+
+// @Summary Resume timer
+// @Tags timers
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param employee_id path string true "Employee ID"
+// @Param id path string true "Timer ID"
+// @Success 200 {object} domain.Timer
+// @Router /organizations/{organization_id}/employees/{employee_id}/timers/{id}/resume [put]
+func (h *TimeHandler) ResumeTimer(c *gin.Context) {
+	h.timerAction(c, h.timeService.ResumeTimer)
+}
+
+// @Summary Stop timer and log its time
+// @Tags timers
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param employee_id path string true "Employee ID"
+// @Param id path string true "Timer ID"
+// @Success 200 {object} domain.StopTimerResponse
+// @Router /organizations/{organization_id}/employees/{employee_id}/timers/{id}/stop [put]
+func (h *TimeHandler) StopTimer(c *gin.Context) {
+	orgID, employeeID, id, ok := timerParams(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.timeService.StopTimer(orgID, employeeID, id)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary Discard timer
+// @Description Stops the timer without logging its time
+// @Tags timers
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param employee_id path string true "Employee ID"
+// @Param id path string true "Timer ID"
+// @Success 200 {object} domain.Timer
+// @Router /organizations/{organization_id}/employees/{employee_id}/timers/{id}/discard [put]
+func (h *TimeHandler) DiscardTimer(c *gin.Context) {
+	h.timerAction(c, h.timeService.DiscardTimer)
+}
+
+func (h *TimeHandler) timerAction(c *gin.Context, action func(orgID, employeeID, id uuid.UUID) (*domain.Timer, error)) {
+	orgID, employeeID, id, ok := timerParams(c)
+	if !ok {
+		return
+	}
+
+	timer, err := action(orgID, employeeID, id)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, timer)
+}
+
+func timerParams(c *gin.Context) (uuid.UUID, uuid.UUID, uuid.UUID, bool) {
+	orgID, err := uuid.Parse(c.Param("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization id"})
+		return uuid.Nil, uuid.Nil, uuid.Nil, false
+	}
+
+	employeeID, err := uuid.Parse(c.Param("employee_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid employee id"})
+		return uuid.Nil, uuid.Nil, uuid.Nil, false
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid timer id"})
+		return uuid.Nil, uuid.Nil, uuid.Nil, false
+	}
+
+	return orgID, employeeID, id, true
+}
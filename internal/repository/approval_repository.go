@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"github.com/Axontik/comin-time-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func (r *timeRepository) ListPendingTimesheets(orgID uuid.UUID) ([]domain.Timesheet, error) {
+	timesheets := []domain.Timesheet{}
+	err := r.db.Where("organization_id = ? AND status = ?", orgID, domain.TimesheetStatusPending).Order("created_at").Find(&timesheets).Error
+	if err != nil {
+		return nil, err
+	}
+	return timesheets, nil
+}
+
+func (r *timeRepository) CreateApprovalSLARule(rule *domain.ApprovalSLARule) error {
+	return r.db.Create(rule).Error
+}
+
+func (r *timeRepository) GetApprovalSLARule(id uuid.UUID) (*domain.ApprovalSLARule, error) {
+	rule := &domain.ApprovalSLARule{}
+	err := r.db.Where("id = ?", id).First(rule).Error
+	if err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+func (r *timeRepository) UpdateApprovalSLARule(rule *domain.ApprovalSLARule) error {
+	return r.db.Save(rule).Error
+}
+
+func (r *timeRepository) DeleteApprovalSLARule(id uuid.UUID) error {
+	return r.db.Where("id = ?", id).Delete(&domain.ApprovalSLARule{}).Error
+}
+
+func (r *timeRepository) ListApprovalSLARules(orgID uuid.UUID) ([]domain.ApprovalSLARule, error) {
+	rules := []domain.ApprovalSLARule{}
+	err := r.db.Where("organization_id = ?", orgID).Order("level").Find(&rules).Error
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (r *timeRepository) ListActiveApprovalSLARules() ([]domain.ApprovalSLARule, error) {
+	rules := []domain.ApprovalSLARule{}
+	err := r.db.Where("is_active = ?", true).Order("organization_id, level").Find(&rules).Error
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (r *timeRepository) CreateTimesheetApprover(approver *domain.TimesheetApprover) error {
+	return r.db.Create(approver).Error
+}
+
+func (r *timeRepository) ListTimesheetApprovers(timesheetID uuid.UUID) ([]domain.TimesheetApprover, error) {
+	approvers := []domain.TimesheetApprover{}
+	err := r.db.Where("timesheet_id = ?", timesheetID).Order("level, assigned_at").Find(&approvers).Error
+	if err != nil {
+		return nil, err
+	}
+	return approvers, nil
+}
+
+func (r *timeRepository) DeactivateTimesheetApprovers(timesheetID uuid.UUID) error {
+	return r.db.Model(&domain.TimesheetApprover{}).Where("timesheet_id = ? AND is_active = ?", timesheetID, true).Update("is_active", false).Error
+}
+
+func (r *timeRepository) CreateNotificationEvent(event *domain.NotificationEvent) error {
+	return r.db.Create(event).Error
+}
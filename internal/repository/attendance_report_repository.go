@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"github.com/Axontik/comin-time-service/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// attendanceAggregateColumns computes a domain.AttendanceAggregate; its
+// parameters are the late, half-day, absent and leave statuses and the
+// timezone check-ins are averaged in
+const attendanceAggregateColumns = `COUNT(*) AS records,
+	COUNT(DISTINCT attendances.employee_id) FILTER (WHERE attendances.check_in IS NOT NULL) AS headcount,
+	COUNT(*) FILTER (WHERE attendances.status = ?) AS late,
+	COUNT(*) FILTER (WHERE attendances.status = ?) AS half_day,
+	COUNT(*) FILTER (WHERE attendances.status = ?) AS absent,
+	COUNT(*) FILTER (WHERE attendances.status = ?) AS leave,
+	COALESCE(to_char(make_interval(secs => AVG(EXTRACT(EPOCH FROM (attendances.check_in AT TIME ZONE ?)::time))::float8), 'HH24:MI'), '') AS average_check_in`
+
+// attendanceGroupKeys are the keys attendance records are grouped by
+var attendanceGroupKeys = map[string]string{
+	domain.AttendanceByDay:      "to_char(attendances.date, 'YYYY-MM-DD')",
+	domain.AttendanceByEmployee: "attendances.employee_id::text",
+	domain.AttendanceBySite:     "COALESCE(employee_sites.site, '')",
+}
+
+// attendanceQuery selects the organization's attendance records matching the filter
+func (r *timeRepository) attendanceQuery(orgID uuid.UUID, filter *domain.AttendanceFilter) *gorm.DB {
+	query := r.db.Model(&domain.Attendance{}).Where("attendances.organization_id = ?", orgID)
+	if !filter.StartDate.IsZero() {
+		query = query.Where("attendances.date >= ?", filter.StartDate)
+	}
+	if !filter.EndDate.IsZero() {
+		query = query.Where("attendances.date <= ?", filter.EndDate)
+	}
+	if filter.EmployeeID != nil {
+		query = query.Where("attendances.employee_id = ?", *filter.EmployeeID)
+	}
+	if filter.Status != "" {
+		query = query.Where("attendances.status = ?", filter.Status)
+	}
+	if filter.WorkMode != "" {
+		query = query.Where("attendances.work_mode = ?", filter.WorkMode)
+	}
+	if filter.Site != "" {
+		query = query.Where("attendances.employee_id IN (?)", r.db.Model(&domain.EmployeeSite{}).Select("employee_id").Where("organization_id = ? AND site = ?", orgID, filter.Site))
+	}
+	return query
+}
+
+// ListAttendancesAfter returns up to limit records matching the filter in
+// date, employee and ID order, starting after the key of the given record
+func (r *timeRepository) ListAttendancesAfter(orgID uuid.UUID, filter *domain.AttendanceFilter, after *domain.Attendance, limit int) ([]domain.Attendance, error) {
+	attendances := []domain.Attendance{}
+	query := r.attendanceQuery(orgID, filter)
+	if after != nil {
+		query = query.Where("(attendances.date, attendances.employee_id, attendances.id) > (?, ?, ?)", after.Date, after.EmployeeID, after.ID)
+	}
+	err := query.Order("attendances.date, attendances.employee_id, attendances.id").Limit(limit).Find(&attendances).Error
+	if err != nil {
+		return nil, err
+	}
+	return attendances, nil
+}
+
+// SummarizeAttendances aggregates every record matching the filter,
+// averaging check-ins in the given timezone
+func (r *timeRepository) SummarizeAttendances(orgID uuid.UUID, filter *domain.AttendanceFilter, timezone string) (*domain.AttendanceAggregate, error) {
+	aggregate := &domain.AttendanceAggregate{}
+	err := r.attendanceQuery(orgID, filter).
+		Select(attendanceAggregateColumns, domain.AttendanceStatusLate, domain.AttendanceStatusHalfDay, domain.AttendanceStatusAbsent, domain.AttendanceStatusLeave, timezone).
+		Scan(aggregate).Error
+	if err != nil {
+		return nil, err
+	}
+	return aggregate, nil
+}
+
+// ListAttendanceGroups aggregates the records matching the filter by day,
+// employee or site and returns up to limit groups in key order, starting
+// after the given key
+func (r *timeRepository) ListAttendanceGroups(orgID uuid.UUID, filter *domain.AttendanceFilter, timezone, groupBy string, after *string, limit int) ([]domain.AttendanceGroup, error) {
+	groups := []domain.AttendanceGroup{}
+	key := attendanceGroupKeys[groupBy]
+	query := r.attendanceQuery(orgID, filter)
+	if groupBy == domain.AttendanceBySite {
+		query = query.Joins("LEFT JOIN employee_sites ON employee_sites.organization_id = attendances.organization_id AND employee_sites.employee_id = attendances.employee_id")
+	}
+	if after != nil {
+		query = query.Where(key+" > ?", *after)
+	}
+	err := query.
+		Select(key+" AS key, "+attendanceAggregateColumns, domain.AttendanceStatusLate, domain.AttendanceStatusHalfDay, domain.AttendanceStatusAbsent, domain.AttendanceStatusLeave, timezone).
+		Group(key).Order(key).Limit(limit).Scan(&groups).Error
+	if err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
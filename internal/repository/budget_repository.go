@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"github.com/Axontik/comin-time-service/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func (r *timeRepository) CreateProjectBudget(budget *domain.ProjectBudget) error {
+	return r.db.Create(budget).Error
+}
+
+func (r *timeRepository) GetProjectBudget(id uuid.UUID) (*domain.ProjectBudget, error) {
+	budget := &domain.ProjectBudget{}
+	err := r.db.Where("id = ?", id).First(budget).Error
+	if err != nil {
+		return nil, err
+	}
+	return budget, nil
+}
+
+func (r *timeRepository) UpdateProjectBudget(budget *domain.ProjectBudget) error {
+	return r.db.Save(budget).Error
+}
+
+func (r *timeRepository) DeleteProjectBudget(id uuid.UUID) error {
+	return r.db.Where("id = ?", id).Delete(&domain.ProjectBudget{}).Error
+}
+
+func (r *timeRepository) ListProjectBudgets(projectID uuid.UUID) ([]domain.ProjectBudget, error) {
+	budgets := []domain.ProjectBudget{}
+	err := r.db.Where("project_id = ?", projectID).Order("task_id NULLS FIRST").Find(&budgets).Error
+	if err != nil {
+		return nil, err
+	}
+	return budgets, nil
+}
+
+func (r *timeRepository) SumProjectTimesheets(projectID uuid.UUID, taskID *uuid.UUID, excludeID uuid.UUID) ([]domain.TimesheetUsage, error) {
+	usage := []domain.TimesheetUsage{}
+	err := projectTimesheets(r.db, projectID, taskID).
+		Where("id <> ?", excludeID).
+		Select("status, COALESCE(SUM(hours), 0) AS hours, COALESCE(SUM(billable_amount), 0) AS amount").
+		Group("status").
+		Scan(&usage).Error
+	if err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
+
+func (r *timeRepository) ListProjectTimesheets(projectID uuid.UUID, taskID *uuid.UUID) ([]domain.Timesheet, error) {
+	timesheets := []domain.Timesheet{}
+	err := projectTimesheets(r.db, projectID, taskID).Order("date").Find(&timesheets).Error
+	if err != nil {
+		return nil, err
+	}
+	return timesheets, nil
+}
+
+// projectTimesheets scopes a query to the pending and approved timesheets of a project or task
+func projectTimesheets(db *gorm.DB, projectID uuid.UUID, taskID *uuid.UUID) *gorm.DB {
+	query := db.Model(&domain.Timesheet{}).Where("project_id = ? AND status IN ?", projectID, []string{domain.TimesheetStatusPending, domain.TimesheetStatusApproved})
+	if taskID != nil {
+		query = query.Where("task_id = ?", *taskID)
+	}
+	return query
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/Axontik/comin-time-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func (r *timeRepository) CreateTimesheetComment(comment *domain.TimesheetComment) error {
+	return r.db.Create(comment).Error
+}
+
+func (r *timeRepository) ListTimesheetComments(timesheetID uuid.UUID) ([]domain.TimesheetComment, error) {
+	comments := []domain.TimesheetComment{}
+	err := r.db.Where("timesheet_id = ?", timesheetID).Order("created_at").Find(&comments).Error
+	if err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+func (r *timeRepository) ListPeriodComments(orgID, employeeID uuid.UUID, periodStart time.Time) ([]domain.TimesheetComment, error) {
+	comments := []domain.TimesheetComment{}
+	err := r.db.Where("organization_id = ? AND employee_id = ? AND timesheet_id IS NULL AND period_start = ?", orgID, employeeID, periodStart).Order("created_at").Find(&comments).Error
+	if err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+func (r *timeRepository) CreateTimesheetChanges(changes []domain.TimesheetChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	return r.db.Create(&changes).Error
+}
+
+func (r *timeRepository) ListTimesheetChanges(timesheetID uuid.UUID) ([]domain.TimesheetChange, error) {
+	changes := []domain.TimesheetChange{}
+	err := r.db.Where("timesheet_id = ?", timesheetID).Order("changed_at, field").Find(&changes).Error
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
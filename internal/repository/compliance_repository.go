@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"github.com/Axontik/comin-time-service/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func (r *timeRepository) CreateComplianceRuleSet(ruleSet *domain.ComplianceRuleSet) error {
+	return r.db.Create(ruleSet).Error
+}
+
+func (r *timeRepository) GetComplianceRuleSet(id uuid.UUID) (*domain.ComplianceRuleSet, error) {
+	ruleSet := &domain.ComplianceRuleSet{}
+	err := r.db.Preload("Rules").Where("id = ?", id).First(ruleSet).Error
+	if err != nil {
+		return nil, err
+	}
+	return ruleSet, nil
+}
+
+// UpdateComplianceRuleSet saves the rule set and replaces its rules
+func (r *timeRepository) UpdateComplianceRuleSet(ruleSet *domain.ComplianceRuleSet) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Rules").Save(ruleSet).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("rule_set_id = ?", ruleSet.ID).Delete(&domain.ComplianceRule{}).Error; err != nil {
+			return err
+		}
+		for i := range ruleSet.Rules {
+			ruleSet.Rules[i].RuleSetID = ruleSet.ID
+		}
+		if len(ruleSet.Rules) == 0 {
+			return nil
+		}
+		return tx.Create(&ruleSet.Rules).Error
+	})
+}
+
+func (r *timeRepository) DeleteComplianceRuleSet(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("rule_set_id = ?", id).Delete(&domain.ComplianceRule{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&domain.ComplianceRuleSet{}).Error
+	})
+}
+
+func (r *timeRepository) ListComplianceRuleSets(orgID uuid.UUID) ([]domain.ComplianceRuleSet, error) {
+	ruleSets := []domain.ComplianceRuleSet{}
+	err := r.db.Preload("Rules").Where("organization_id = ?", orgID).Order("jurisdiction").Find(&ruleSets).Error
+	if err != nil {
+		return nil, err
+	}
+	return ruleSets, nil
+}
+
+func (r *timeRepository) GetEmployeeJurisdiction(orgID, employeeID uuid.UUID) (*domain.EmployeeJurisdiction, error) {
+	jurisdiction := &domain.EmployeeJurisdiction{}
+	err := r.db.Where("organization_id = ? AND employee_id = ?", orgID, employeeID).First(jurisdiction).Error
+	if err != nil {
+		return nil, err
+	}
+	return jurisdiction, nil
+}
+
+func (r *timeRepository) SaveEmployeeJurisdiction(jurisdiction *domain.EmployeeJurisdiction) error {
+	return r.db.Save(jurisdiction).Error
+}
+
+func (r *timeRepository) DeleteEmployeeJurisdiction(orgID, employeeID uuid.UUID) error {
+	return r.db.Where("organization_id = ? AND employee_id = ?", orgID, employeeID).Delete(&domain.EmployeeJurisdiction{}).Error
+}
+
+func (r *timeRepository) ListEmployeeJurisdictions(orgID uuid.UUID) ([]domain.EmployeeJurisdiction, error) {
+	jurisdictions := []domain.EmployeeJurisdiction{}
+	err := r.db.Where("organization_id = ?", orgID).Find(&jurisdictions).Error
+	if err != nil {
+		return nil, err
+	}
+	return jurisdictions, nil
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/Axontik/comin-time-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func (r *timeRepository) CreateHoliday(holiday *domain.Holiday) error {
+	return r.db.Create(holiday).Error
+}
+
+func (r *timeRepository) GetHoliday(id uuid.UUID) (*domain.Holiday, error) {
+	holiday := &domain.Holiday{}
+	err := r.db.Where("id = ?", id).First(holiday).Error
+	if err != nil {
+		return nil, err
+	}
+	return holiday, nil
+}
+
+func (r *timeRepository) UpdateHoliday(holiday *domain.Holiday) error {
+	return r.db.Save(holiday).Error
+}
+
+func (r *timeRepository) DeleteHoliday(id uuid.UUID) error {
+	return r.db.Where("id = ?", id).Delete(&domain.Holiday{}).Error
+}
+
+// ListHolidays returns the holidays dated in the range together with the
+// recurring holidays that started before its end, for every site.
+func (r *timeRepository) ListHolidays(orgID uuid.UUID, startDate, endDate time.Time) ([]domain.Holiday, error) {
+	holidays := []domain.Holiday{}
+	err := r.db.Where("organization_id = ? AND ((date BETWEEN ? AND ?) OR (recurring AND date <= ?))", orgID, startDate, endDate, endDate).Order("date").Find(&holidays).Error
+	if err != nil {
+		return nil, err
+	}
+	return holidays, nil
+}
+
+func (r *timeRepository) FindHoliday(orgID uuid.UUID, site string, date time.Time) (*domain.Holiday, error) {
+	holiday := &domain.Holiday{}
+	err := r.db.Where("organization_id = ? AND site = ? AND date = ?", orgID, site, date).First(holiday).Error
+	if err != nil {
+		return nil, err
+	}
+	return holiday, nil
+}
+
+func (r *timeRepository) GetEmployeeSite(orgID, employeeID uuid.UUID) (*domain.EmployeeSite, error) {
+	site := &domain.EmployeeSite{}
+	err := r.db.Where("organization_id = ? AND employee_id = ?", orgID, employeeID).First(site).Error
+	if err != nil {
+		return nil, err
+	}
+	return site, nil
+}
+
+func (r *timeRepository) SaveEmployeeSite(site *domain.EmployeeSite) error {
+	return r.db.Save(site).Error
+}
+
+func (r *timeRepository) DeleteEmployeeSite(orgID, employeeID uuid.UUID) error {
+	return r.db.Where("organization_id = ? AND employee_id = ?", orgID, employeeID).Delete(&domain.EmployeeSite{}).Error
+}
+
+func (r *timeRepository) ListEmployeeSites(orgID uuid.UUID) ([]domain.EmployeeSite, error) {
+	sites := []domain.EmployeeSite{}
+	err := r.db.Where("organization_id = ?", orgID).Find(&sites).Error
+	if err != nil {
+		return nil, err
+	}
+	return sites, nil
+}
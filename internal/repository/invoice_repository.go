@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Axontik/comin-time-service/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ErrTimesheetsAlreadyInvoiced is returned when an entry was invoiced by a
+// concurrent request while the invoice was being created.
+var ErrTimesheetsAlreadyInvoiced = errors.New("timesheets already invoiced")
+
+// CreateInvoice stores the invoice with its lines and marks the given
+// timesheets as invoiced in a single transaction.
+func (r *timeRepository) CreateInvoice(invoice *domain.Invoice, timesheetIDs []uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(invoice).Error; err != nil {
+			return err
+		}
+
+		result := tx.Model(&domain.Timesheet{}).Where("id IN ? AND invoice_id IS NULL", timesheetIDs).Update("invoice_id", invoice.ID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != int64(len(timesheetIDs)) {
+			return ErrTimesheetsAlreadyInvoiced
+		}
+		return nil
+	})
+}
+
+func (r *timeRepository) GetInvoice(id uuid.UUID) (*domain.Invoice, error) {
+	invoice := &domain.Invoice{}
+	err := r.db.Preload("Lines", func(db *gorm.DB) *gorm.DB {
+		return db.Order("description")
+	}).Where("id = ?", id).First(invoice).Error
+	if err != nil {
+		return nil, err
+	}
+	return invoice, nil
+}
+
+func (r *timeRepository) ListInvoices(orgID uuid.UUID) ([]domain.Invoice, error) {
+	invoices := []domain.Invoice{}
+	err := r.db.Where("organization_id = ?", orgID).Order("created_at DESC").Find(&invoices).Error
+	if err != nil {
+		return nil, err
+	}
+	return invoices, nil
+}
+
+// DeleteInvoice removes a draft and releases its timesheets for billing
+func (r *timeRepository) DeleteInvoice(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domain.Timesheet{}).Where("invoice_id = ?", id).Update("invoice_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("invoice_id = ?", id).Delete(&domain.InvoiceLine{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&domain.Invoice{}).Error
+	})
+}
+
+// ListInvoiceableTimesheets returns the approved billable entries of the
+// projects that have not been invoiced yet.
+func (r *timeRepository) ListInvoiceableTimesheets(orgID uuid.UUID, projectIDs []uuid.UUID, startDate, endDate time.Time) ([]domain.Timesheet, error) {
+	timesheets := []domain.Timesheet{}
+	if len(projectIDs) == 0 {
+		return timesheets, nil
+	}
+	err := r.db.Where("organization_id = ? AND project_id IN ? AND date BETWEEN ? AND ?", orgID, projectIDs, startDate, endDate).
+		Where("status = ? AND is_billable AND invoice_id IS NULL", domain.TimesheetStatusApproved).
+		Order("date, start_time").Find(&timesheets).Error
+	if err != nil {
+		return nil, err
+	}
+	return timesheets, nil
+}
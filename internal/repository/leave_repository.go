@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/Axontik/comin-time-service/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func (r *timeRepository) CreateLeaveType(leaveType *domain.LeaveType) error {
+	return r.db.Create(leaveType).Error
+}
+
+func (r *timeRepository) GetLeaveType(id uuid.UUID) (*domain.LeaveType, error) {
+	leaveType := &domain.LeaveType{}
+	err := r.db.Where("id = ?", id).First(leaveType).Error
+	if err != nil {
+		return nil, err
+	}
+	return leaveType, nil
+}
+
+func (r *timeRepository) UpdateLeaveType(leaveType *domain.LeaveType) error {
+	return r.db.Save(leaveType).Error
+}
+
+func (r *timeRepository) DeleteLeaveType(id uuid.UUID) error {
+	return r.db.Where("id = ?", id).Delete(&domain.LeaveType{}).Error
+}
+
+func (r *timeRepository) ListLeaveTypes(orgID uuid.UUID) ([]domain.LeaveType, error) {
+	leaveTypes := []domain.LeaveType{}
+	err := r.db.Where("organization_id = ?", orgID).Order("name").Find(&leaveTypes).Error
+	if err != nil {
+		return nil, err
+	}
+	return leaveTypes, nil
+}
+
+func (r *timeRepository) CountLeaveRequestsByType(leaveTypeID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Model(&domain.LeaveRequest{}).Where("leave_type_id = ?", leaveTypeID).Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *timeRepository) CreateLeaveRequest(request *domain.LeaveRequest) error {
+	return r.db.Create(request).Error
+}
+
+func (r *timeRepository) GetLeaveRequest(id uuid.UUID) (*domain.LeaveRequest, error) {
+	request := &domain.LeaveRequest{}
+	err := r.db.Where("id = ?", id).First(request).Error
+	if err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+func (r *timeRepository) UpdateLeaveRequest(request *domain.LeaveRequest) error {
+	return r.db.Save(request).Error
+}
+
+// ListLeaveRequests returns the requests overlapping the filter's range
+func (r *timeRepository) ListLeaveRequests(orgID uuid.UUID, filter *domain.LeaveRequestFilter) ([]domain.LeaveRequest, error) {
+	requests := []domain.LeaveRequest{}
+	query := r.db.Where("organization_id = ? AND start_date <= ? AND end_date >= ?", orgID, filter.EndDate, filter.StartDate)
+	if filter.EmployeeID != nil {
+		query = query.Where("employee_id = ?", *filter.EmployeeID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	err := query.Order("start_date, created_at").Find(&requests).Error
+	if err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+func (r *timeRepository) GetLeaveBalance(employeeID, leaveTypeID uuid.UUID, year int) (*domain.LeaveBalance, error) {
+	balance := &domain.LeaveBalance{}
+	err := r.db.Where("employee_id = ? AND leave_type_id = ? AND year = ?", employeeID, leaveTypeID, year).First(balance).Error
+	if err != nil {
+		return nil, err
+	}
+	return balance, nil
+}
+
+// GetLatestLeaveBalance returns the employee's most recent balance of the
+// leave type before the year
+func (r *timeRepository) GetLatestLeaveBalance(employeeID, leaveTypeID uuid.UUID, year int) (*domain.LeaveBalance, error) {
+	balance := &domain.LeaveBalance{}
+	err := r.db.Where("employee_id = ? AND leave_type_id = ? AND year < ?", employeeID, leaveTypeID, year).Order("year DESC").First(balance).Error
+	if err != nil {
+		return nil, err
+	}
+	return balance, nil
+}
+
+func (r *timeRepository) SaveLeaveBalance(balance *domain.LeaveBalance) error {
+	return r.db.Save(balance).Error
+}
+
+// ApproveLeaveRequest stores the approved request together with the
+// updated balances and the attendance records of the leave days.
+func (r *timeRepository) ApproveLeaveRequest(request *domain.LeaveRequest, balances []domain.LeaveBalance, attendances []domain.Attendance) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(request).Error; err != nil {
+			return err
+		}
+		for i := range balances {
+			if err := tx.Save(&balances[i]).Error; err != nil {
+				return err
+			}
+		}
+		if len(attendances) == 0 {
+			return nil
+		}
+		return tx.Create(&attendances).Error
+	})
+}
+
+// CancelLeaveRequest stores the cancelled request and balances, and removes
+// the leave from attendance. Records the employee has punched on are kept
+// without their link to the request.
+func (r *timeRepository) CancelLeaveRequest(request *domain.LeaveRequest, balances []domain.LeaveBalance) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(request).Error; err != nil {
+			return err
+		}
+		for i := range balances {
+			if err := tx.Save(&balances[i]).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Where("leave_request_id = ? AND check_in IS NULL", request.ID).Delete(&domain.Attendance{}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&domain.Attendance{}).Where("leave_request_id = ?", request.ID).
+			Updates(map[string]interface{}{"leave_request_id": nil, "status": domain.AttendanceStatusPresent}).Error
+	})
+}
+
+func (r *timeRepository) ListLeaveBalances(employeeID uuid.UUID, year int) ([]domain.LeaveBalance, error) {
+	balances := []domain.LeaveBalance{}
+	err := r.db.Where("employee_id = ? AND year = ?", employeeID, year).Find(&balances).Error
+	if err != nil {
+		return nil, err
+	}
+	return balances, nil
+}
+
+// SumPendingLeaveDays totals the days of the employee's pending requests of
+// the leave type starting in the range
+func (r *timeRepository) SumPendingLeaveDays(employeeID, leaveTypeID uuid.UUID, startDate, endDate time.Time) (float64, error) {
+	var total float64
+	err := r.db.Model(&domain.LeaveRequest{}).
+		Where("employee_id = ? AND leave_type_id = ? AND status = ? AND start_date BETWEEN ? AND ?", employeeID, leaveTypeID, domain.LeaveStatusPending, startDate, endDate).
+		Select("COALESCE(SUM(days), 0)").Scan(&total).Error
+	if err != nil {
+		return 0, err
+	}
+	return total, nil
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"github.com/Axontik/comin-time-service/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func (r *timeRepository) CreateOnCallRotation(rotation *domain.OnCallRotation) error {
+	return r.db.Create(rotation).Error
+}
+
+func (r *timeRepository) GetOnCallRotation(id uuid.UUID) (*domain.OnCallRotation, error) {
+	rotation := &domain.OnCallRotation{}
+	err := r.db.Preload("Members", orderRotationMembers).Where("id = ?", id).First(rotation).Error
+	if err != nil {
+		return nil, err
+	}
+	return rotation, nil
+}
+
+// UpdateOnCallRotation saves the rotation and replaces its members
+func (r *timeRepository) UpdateOnCallRotation(rotation *domain.OnCallRotation) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Members").Save(rotation).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("rotation_id = ?", rotation.ID).Delete(&domain.OnCallRotationMember{}).Error; err != nil {
+			return err
+		}
+		for i := range rotation.Members {
+			rotation.Members[i].ID = uuid.Nil
+			rotation.Members[i].RotationID = rotation.ID
+		}
+		if len(rotation.Members) == 0 {
+			return nil
+		}
+		return tx.Create(&rotation.Members).Error
+	})
+}
+
+func (r *timeRepository) DeleteOnCallRotation(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("rotation_id = ?", id).Delete(&domain.OnCallRotationMember{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&domain.OnCallRotation{}).Error
+	})
+}
+
+func (r *timeRepository) ListOnCallRotations(orgID uuid.UUID, teamID *uuid.UUID) ([]domain.OnCallRotation, error) {
+	rotations := []domain.OnCallRotation{}
+	query := r.db.Preload("Members", orderRotationMembers).Where("organization_id = ?", orgID)
+	if teamID != nil {
+		query = query.Where("team_id = ?", *teamID)
+	}
+	err := query.Order("name").Find(&rotations).Error
+	if err != nil {
+		return nil, err
+	}
+	return rotations, nil
+}
+
+func (r *timeRepository) CountOnCallIncidents(rotationID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Model(&domain.OnCallIncident{}).Where("rotation_id = ?", rotationID).Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// CreateOnCallIncident stores the incident without its call-out timesheet
+// entries, which are created on their own
+func (r *timeRepository) CreateOnCallIncident(incident *domain.OnCallIncident) error {
+	return r.db.Omit("Timesheets").Create(incident).Error
+}
+
+// ListOnCallIncidents returns the incidents overlapping the filter's period
+func (r *timeRepository) ListOnCallIncidents(orgID uuid.UUID, filter *domain.OnCallIncidentFilter) ([]domain.OnCallIncident, error) {
+	incidents := []domain.OnCallIncident{}
+	query := r.db.Preload("Timesheets").Where("organization_id = ? AND started_at < ? AND ended_at > ?", orgID, filter.To, filter.From)
+	if filter.EmployeeID != nil {
+		query = query.Where("employee_id = ?", *filter.EmployeeID)
+	}
+	if filter.RotationID != nil {
+		query = query.Where("rotation_id = ?", *filter.RotationID)
+	}
+	err := query.Order("started_at").Find(&incidents).Error
+	if err != nil {
+		return nil, err
+	}
+	return incidents, nil
+}
+
+func orderRotationMembers(db *gorm.DB) *gorm.DB {
+	return db.Order("position")
+}
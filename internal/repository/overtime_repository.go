@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/Axontik/comin-time-service/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func (r *timeRepository) CreateOvertimeRule(rule *domain.OvertimeRule) error {
+	return r.db.Create(rule).Error
+}
+
+func (r *timeRepository) GetOvertimeRule(id uuid.UUID) (*domain.OvertimeRule, error) {
+	rule := &domain.OvertimeRule{}
+	err := r.db.Where("id = ?", id).First(rule).Error
+	if err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+func (r *timeRepository) UpdateOvertimeRule(rule *domain.OvertimeRule) error {
+	return r.db.Save(rule).Error
+}
+
+func (r *timeRepository) DeleteOvertimeRule(id uuid.UUID) error {
+	return r.db.Where("id = ?", id).Delete(&domain.OvertimeRule{}).Error
+}
+
+func (r *timeRepository) ListOvertimeRules(orgID uuid.UUID) ([]domain.OvertimeRule, error) {
+	rules := []domain.OvertimeRule{}
+	err := r.db.Where("organization_id = ?", orgID).Order("kind, threshold_hours").Find(&rules).Error
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// ReplaceOvertimeEntries swaps the employee's entries on the given dates
+// for the recomputed ones.
+func (r *timeRepository) ReplaceOvertimeEntries(employeeID uuid.UUID, dates []time.Time, entries []domain.OvertimeEntry) error {
+	if len(dates) == 0 {
+		return nil
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("employee_id = ? AND date IN ?", employeeID, dates).Delete(&domain.OvertimeEntry{}).Error; err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			return nil
+		}
+		return tx.Create(&entries).Error
+	})
+}
+
+func (r *timeRepository) ListOvertimeEntries(orgID uuid.UUID, employeeID *uuid.UUID, startDate, endDate time.Time) ([]domain.OvertimeEntry, error) {
+	entries := []domain.OvertimeEntry{}
+	query := r.db.Where("organization_id = ? AND date BETWEEN ? AND ?", orgID, startDate, endDate)
+	if employeeID != nil {
+		query = query.Where("employee_id = ?", *employeeID)
+	}
+	err := query.Order("employee_id, date, multiplier").Find(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (r *timeRepository) ListTimesheetsByStatus(orgID uuid.UUID, employeeID *uuid.UUID, startDate, endDate time.Time, status string) ([]domain.Timesheet, error) {
+	timesheets := []domain.Timesheet{}
+	query := r.db.Where("organization_id = ? AND date BETWEEN ? AND ? AND status = ?", orgID, startDate, endDate, status)
+	if employeeID != nil {
+		query = query.Where("employee_id = ?", *employeeID)
+	}
+	err := query.Order("date, start_time").Find(&timesheets).Error
+	if err != nil {
+		return nil, err
+	}
+	return timesheets, nil
+}
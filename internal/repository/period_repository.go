@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/Axontik/comin-time-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func (r *timeRepository) CreatePayrollPeriod(period *domain.PayrollPeriod) error {
+	return r.db.Create(period).Error
+}
+
+func (r *timeRepository) GetPayrollPeriod(id uuid.UUID) (*domain.PayrollPeriod, error) {
+	period := &domain.PayrollPeriod{}
+	err := r.db.Where("id = ?", id).First(period).Error
+	if err != nil {
+		return nil, err
+	}
+	return period, nil
+}
+
+func (r *timeRepository) UpdatePayrollPeriod(period *domain.PayrollPeriod) error {
+	return r.db.Save(period).Error
+}
+
+func (r *timeRepository) ListPayrollPeriods(orgID uuid.UUID) ([]domain.PayrollPeriod, error) {
+	periods := []domain.PayrollPeriod{}
+	err := r.db.Where("organization_id = ?", orgID).Order("start_date DESC").Find(&periods).Error
+	if err != nil {
+		return nil, err
+	}
+	return periods, nil
+}
+
+// ListClosedPeriods returns the closed periods of the organization that
+// overlap the date range.
+func (r *timeRepository) ListClosedPeriods(orgID uuid.UUID, startDate, endDate time.Time) ([]domain.PayrollPeriod, error) {
+	periods := []domain.PayrollPeriod{}
+	err := r.db.Where("organization_id = ? AND status = ? AND start_date <= ? AND end_date >= ?", orgID, domain.PeriodStatusClosed, endDate, startDate).Order("start_date").Find(&periods).Error
+	if err != nil {
+		return nil, err
+	}
+	return periods, nil
+}
+
+func (r *timeRepository) CreatePayrollPeriodEvent(event *domain.PayrollPeriodEvent) error {
+	return r.db.Create(event).Error
+}
+
+func (r *timeRepository) ListPayrollPeriodEvents(periodID uuid.UUID) ([]domain.PayrollPeriodEvent, error) {
+	events := []domain.PayrollPeriodEvent{}
+	err := r.db.Where("period_id = ?", periodID).Order("created_at").Find(&events).Error
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (r *timeRepository) CountOrganizationTimesheetsByStatus(orgID uuid.UUID, startDate, endDate time.Time, status string) (int64, error) {
+	var count int64
+	err := r.db.Model(&domain.Timesheet{}).Where("organization_id = ? AND date BETWEEN ? AND ? AND status = ?", orgID, startDate, endDate, status).Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/Axontik/comin-time-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func (r *timeRepository) CountTimesheetsByStatus(orgID, employeeID uuid.UUID, startDate, endDate time.Time, status string) (int64, error) {
+	var count int64
+	err := r.db.Model(&domain.Timesheet{}).Where("organization_id = ? AND employee_id = ? AND date BETWEEN ? AND ? AND status = ?", orgID, employeeID, startDate, endDate, status).Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *timeRepository) ListOverlappingTimesheets(employeeID uuid.UUID, startTime, endTime time.Time, excludeID uuid.UUID) ([]domain.Timesheet, error) {
+	timesheets := []domain.Timesheet{}
+	err := r.db.Where("employee_id = ? AND id <> ? AND status <> ? AND start_time < ? AND end_time > ?", employeeID, excludeID, domain.TimesheetStatusRejected, endTime, startTime).Order("start_time").Find(&timesheets).Error
+	if err != nil {
+		return nil, err
+	}
+	return timesheets, nil
+}
+
+func (r *timeRepository) GetTimesheetPolicy(orgID uuid.UUID) (*domain.TimesheetPolicy, error) {
+	policy := &domain.TimesheetPolicy{}
+	err := r.db.Where("organization_id = ?", orgID).First(policy).Error
+	if err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+func (r *timeRepository) SaveTimesheetPolicy(policy *domain.TimesheetPolicy) error {
+	return r.db.Save(policy).Error
+}
+
+func (r *timeRepository) GetEmployeeWorkWeek(orgID, employeeID uuid.UUID) (*domain.EmployeeWorkWeek, error) {
+	workWeek := &domain.EmployeeWorkWeek{}
+	err := r.db.Where("organization_id = ? AND employee_id = ?", orgID, employeeID).First(workWeek).Error
+	if err != nil {
+		return nil, err
+	}
+	return workWeek, nil
+}
+
+func (r *timeRepository) SaveEmployeeWorkWeek(workWeek *domain.EmployeeWorkWeek) error {
+	return r.db.Save(workWeek).Error
+}
+
+func (r *timeRepository) DeleteEmployeeWorkWeek(orgID, employeeID uuid.UUID) error {
+	return r.db.Where("organization_id = ? AND employee_id = ?", orgID, employeeID).Delete(&domain.EmployeeWorkWeek{}).Error
+}
+
+func (r *timeRepository) ListEmployeeWorkWeeks(orgID uuid.UUID) ([]domain.EmployeeWorkWeek, error) {
+	workWeeks := []domain.EmployeeWorkWeek{}
+	err := r.db.Where("organization_id = ?", orgID).Find(&workWeeks).Error
+	if err != nil {
+		return nil, err
+	}
+	return workWeeks, nil
+}
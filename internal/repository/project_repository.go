@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"github.com/Axontik/comin-time-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func (r *timeRepository) CreateProject(project *domain.Project) error {
+	return r.db.Create(project).Error
+}
+
+func (r *timeRepository) GetProject(id uuid.UUID) (*domain.Project, error) {
+	project := &domain.Project{}
+	err := r.db.Preload("Members").Where("id = ?", id).First(project).Error
+	if err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+func (r *timeRepository) UpdateProject(project *domain.Project) error {
+	return r.db.Omit("Members").Save(project).Error
+}
+
+func (r *timeRepository) ListProjects(orgID uuid.UUID, status string) ([]domain.Project, error) {
+	projects := []domain.Project{}
+	query := r.db.Where("organization_id = ?", orgID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Order("name").Find(&projects).Error
+	if err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
+
+func (r *timeRepository) ListProjectsByIDs(ids []uuid.UUID) ([]domain.Project, error) {
+	projects := []domain.Project{}
+	if len(ids) == 0 {
+		return projects, nil
+	}
+	err := r.db.Where("id IN ?", ids).Find(&projects).Error
+	if err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
+
+func (r *timeRepository) CreateTask(task *domain.Task) error {
+	return r.db.Create(task).Error
+}
+
+func (r *timeRepository) GetTask(id uuid.UUID) (*domain.Task, error) {
+	task := &domain.Task{}
+	err := r.db.Where("id = ?", id).First(task).Error
+	if err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+func (r *timeRepository) UpdateTask(task *domain.Task) error {
+	return r.db.Save(task).Error
+}
+
+func (r *timeRepository) ListTasks(projectID uuid.UUID) ([]domain.Task, error) {
+	tasks := []domain.Task{}
+	err := r.db.Where("project_id = ?", projectID).Order("name").Find(&tasks).Error
+	if err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+func (r *timeRepository) ListTasksByIDs(ids []uuid.UUID) ([]domain.Task, error) {
+	tasks := []domain.Task{}
+	if len(ids) == 0 {
+		return tasks, nil
+	}
+	err := r.db.Where("id IN ?", ids).Find(&tasks).Error
+	if err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+func (r *timeRepository) AddProjectMember(member *domain.ProjectMember) error {
+	return r.db.Create(member).Error
+}
+
+func (r *timeRepository) RemoveProjectMember(projectID, employeeID uuid.UUID) error {
+	return r.db.Where("project_id = ? AND employee_id = ?", projectID, employeeID).Delete(&domain.ProjectMember{}).Error
+}
+
+func (r *timeRepository) IsProjectMember(projectID, employeeID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.Model(&domain.ProjectMember{}).Where("project_id = ? AND employee_id = ?", projectID, employeeID).Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *timeRepository) GetProjectMember(projectID, employeeID uuid.UUID) (*domain.ProjectMember, error) {
+	member := &domain.ProjectMember{}
+	err := r.db.Where("project_id = ? AND employee_id = ?", projectID, employeeID).First(member).Error
+	if err != nil {
+		return nil, err
+	}
+	return member, nil
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/Axontik/comin-time-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func (r *timeRepository) CreateRateCard(rateCard *domain.RateCard) error {
+	return r.db.Create(rateCard).Error
+}
+
+func (r *timeRepository) GetRateCard(id uuid.UUID) (*domain.RateCard, error) {
+	rateCard := &domain.RateCard{}
+	err := r.db.Where("id = ?", id).First(rateCard).Error
+	if err != nil {
+		return nil, err
+	}
+	return rateCard, nil
+}
+
+func (r *timeRepository) UpdateRateCard(rateCard *domain.RateCard) error {
+	return r.db.Save(rateCard).Error
+}
+
+func (r *timeRepository) DeleteRateCard(id uuid.UUID) error {
+	return r.db.Where("id = ?", id).Delete(&domain.RateCard{}).Error
+}
+
+func (r *timeRepository) ListRateCards(orgID uuid.UUID) ([]domain.RateCard, error) {
+	rateCards := []domain.RateCard{}
+	err := r.db.Where("organization_id = ?", orgID).Order("effective_from DESC").Find(&rateCards).Error
+	if err != nil {
+		return nil, err
+	}
+	return rateCards, nil
+}
+
+func (r *timeRepository) ListEffectiveRateCards(orgID uuid.UUID, date time.Time) ([]domain.RateCard, error) {
+	rateCards := []domain.RateCard{}
+	err := r.db.Where("organization_id = ? AND effective_from <= ? AND (effective_to IS NULL OR effective_to >= ?)", orgID, date, date).Order("effective_from DESC").Find(&rateCards).Error
+	if err != nil {
+		return nil, err
+	}
+	return rateCards, nil
+}
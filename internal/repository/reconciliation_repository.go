@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/Axontik/comin-time-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func (r *timeRepository) ListAttendancesInRange(orgID uuid.UUID, employeeID *uuid.UUID, startDate, endDate time.Time) ([]domain.Attendance, error) {
+	attendances := []domain.Attendance{}
+	query := r.db.Where("organization_id = ? AND date BETWEEN ? AND ?", orgID, startDate, endDate)
+	if employeeID != nil {
+		query = query.Where("employee_id = ?", *employeeID)
+	}
+	err := query.Order("date, employee_id").Find(&attendances).Error
+	if err != nil {
+		return nil, err
+	}
+	return attendances, nil
+}
+
+// SumTimesheetHoursByDay totals the non-rejected hours of regular work per
+// employee and day; call-outs happen outside attendance and are left out
+func (r *timeRepository) SumTimesheetHoursByDay(orgID uuid.UUID, employeeID *uuid.UUID, startDate, endDate time.Time) ([]domain.DailyTimesheetHours, error) {
+	totals := []domain.DailyTimesheetHours{}
+	query := r.db.Model(&domain.Timesheet{}).Where("organization_id = ? AND date BETWEEN ? AND ? AND status <> ? AND entry_type = ?", orgID, startDate, endDate, domain.TimesheetStatusRejected, domain.TimesheetEntryWork)
+	if employeeID != nil {
+		query = query.Where("employee_id = ?", *employeeID)
+	}
+	err := query.Select("employee_id, date, COALESCE(SUM(hours), 0) AS hours").Group("employee_id, date").Order("date, employee_id").Scan(&totals).Error
+	if err != nil {
+		return nil, err
+	}
+	return totals, nil
+}
+
+// SumTimesheetHoursByProject totals the non-rejected hours of regular work,
+// and the billable share of them, per employee and project
+func (r *timeRepository) SumTimesheetHoursByProject(orgID uuid.UUID, employeeID *uuid.UUID, startDate, endDate time.Time) ([]domain.TimesheetHoursTotal, error) {
+	totals := []domain.TimesheetHoursTotal{}
+	query := r.db.Model(&domain.Timesheet{}).Where("organization_id = ? AND date BETWEEN ? AND ? AND status <> ? AND entry_type = ?", orgID, startDate, endDate, domain.TimesheetStatusRejected, domain.TimesheetEntryWork)
+	if employeeID != nil {
+		query = query.Where("employee_id = ?", *employeeID)
+	}
+	err := query.Select("employee_id, project_id, COALESCE(SUM(hours), 0) AS hours, COALESCE(SUM(CASE WHEN is_billable THEN hours ELSE 0 END), 0) AS billable_hours").
+		Group("employee_id, project_id").Order("employee_id").Scan(&totals).Error
+	if err != nil {
+		return nil, err
+	}
+	return totals, nil
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/Axontik/comin-time-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func (r *timeRepository) GetRemoteWorkPolicy(orgID uuid.UUID) (*domain.RemoteWorkPolicy, error) {
+	policy := &domain.RemoteWorkPolicy{}
+	err := r.db.Where("organization_id = ?", orgID).First(policy).Error
+	if err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+func (r *timeRepository) SaveRemoteWorkPolicy(policy *domain.RemoteWorkPolicy) error {
+	return r.db.Save(policy).Error
+}
+
+func (r *timeRepository) CreateRemoteWorkRequest(request *domain.RemoteWorkRequest) error {
+	return r.db.Create(request).Error
+}
+
+func (r *timeRepository) GetRemoteWorkRequest(id uuid.UUID) (*domain.RemoteWorkRequest, error) {
+	request := &domain.RemoteWorkRequest{}
+	err := r.db.Where("id = ?", id).First(request).Error
+	if err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+func (r *timeRepository) UpdateRemoteWorkRequest(request *domain.RemoteWorkRequest) error {
+	return r.db.Save(request).Error
+}
+
+// ListRemoteWorkRequests returns the requests overlapping the filter's range
+func (r *timeRepository) ListRemoteWorkRequests(orgID uuid.UUID, filter *domain.RemoteWorkRequestFilter) ([]domain.RemoteWorkRequest, error) {
+	requests := []domain.RemoteWorkRequest{}
+	query := r.db.Where("organization_id = ? AND start_date <= ? AND end_date >= ?", orgID, filter.EndDate, filter.StartDate)
+	if filter.EmployeeID != nil {
+		query = query.Where("employee_id = ?", *filter.EmployeeID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	err := query.Order("start_date, created_at").Find(&requests).Error
+	if err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+// CountRemoteAttendances counts the employee's remote check-ins in a range
+func (r *timeRepository) CountRemoteAttendances(employeeID uuid.UUID, startDate, endDate time.Time) (int64, error) {
+	var count int64
+	err := r.db.Model(&domain.Attendance{}).
+		Where("employee_id = ? AND date BETWEEN ? AND ? AND work_mode = ? AND check_in IS NOT NULL", employeeID, startDate, endDate, domain.WorkModeRemote).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *timeRepository) CreateRemoteWorkViolations(violations []domain.RemoteWorkViolation) error {
+	if len(violations) == 0 {
+		return nil
+	}
+	return r.db.Create(&violations).Error
+}
+
+func (r *timeRepository) ListRemoteWorkViolations(orgID uuid.UUID, filter *domain.RemoteWorkViolationFilter) ([]domain.RemoteWorkViolation, error) {
+	violations := []domain.RemoteWorkViolation{}
+	query := r.db.Where("organization_id = ? AND date BETWEEN ? AND ?", orgID, filter.StartDate, filter.EndDate)
+	if filter.EmployeeID != nil {
+		query = query.Where("employee_id = ?", *filter.EmployeeID)
+	}
+	err := query.Order("date, created_at").Find(&violations).Error
+	if err != nil {
+		return nil, err
+	}
+	return violations, nil
+}
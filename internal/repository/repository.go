@@ -13,7 +13,11 @@ type TimeRepository interface {
 	CreateAttendance(attendance *domain.Attendance) error
 	GetAttendanceByDate(employeeID uuid.UUID, date time.Time) (*domain.Attendance, error)
 	UpdateAttendance(attendance *domain.Attendance) error
-	ListAttendances(orgID uuid.UUID) ([]domain.Attendance, error)
+	ListAttendances(orgID uuid.UUID, filter *domain.AttendanceFilter) ([]domain.Attendance, error)
+	ListAttendancesInRange(orgID uuid.UUID, employeeID *uuid.UUID, startDate, endDate time.Time) ([]domain.Attendance, error)
+	ListAttendancesAfter(orgID uuid.UUID, filter *domain.AttendanceFilter, after *domain.Attendance, limit int) ([]domain.Attendance, error)
+	SummarizeAttendances(orgID uuid.UUID, filter *domain.AttendanceFilter, timezone string) (*domain.AttendanceAggregate, error)
+	ListAttendanceGroups(orgID uuid.UUID, filter *domain.AttendanceFilter, timezone, groupBy string, after *string, limit int) ([]domain.AttendanceGroup, error)
 
 	// QR Code methods
 	CreateQRCode(qrCode *domain.QRCode) error
@@ -29,6 +33,220 @@ type TimeRepository interface {
 	DeleteTimesheet(id uuid.UUID) error
 	ListTimesheets(orgID, employeeID uuid.UUID, startDate, endDate time.Time) ([]domain.Timesheet, error)
 	GetTimesheetSummary(orgID, employeeID uuid.UUID, startDate, endDate time.Time) (float64, error)
+	ListPendingTimesheets(orgID uuid.UUID) ([]domain.Timesheet, error)
+	ListOverlappingTimesheets(employeeID uuid.UUID, startTime, endTime time.Time, excludeID uuid.UUID) ([]domain.Timesheet, error)
+	CountTimesheetsByStatus(orgID, employeeID uuid.UUID, startDate, endDate time.Time, status string) (int64, error)
+	SumTimesheetHoursByDay(orgID uuid.UUID, employeeID *uuid.UUID, startDate, endDate time.Time) ([]domain.DailyTimesheetHours, error)
+	SumTimesheetHoursByProject(orgID uuid.UUID, employeeID *uuid.UUID, startDate, endDate time.Time) ([]domain.TimesheetHoursTotal, error)
+	GetDeletedTimesheet(id uuid.UUID) (*domain.Timesheet, error)
+	ListDeletedTimesheets(orgID, employeeID uuid.UUID, startDate, endDate time.Time) ([]domain.Timesheet, error)
+	RestoreTimesheet(id uuid.UUID) error
+	PurgeDeletedTimesheets(orgID uuid.UUID, deletedBefore time.Time) (int64, error)
+
+	// Policy methods
+	GetTimesheetPolicy(orgID uuid.UUID) (*domain.TimesheetPolicy, error)
+	SaveTimesheetPolicy(policy *domain.TimesheetPolicy) error
+	GetEmployeeWorkWeek(orgID, employeeID uuid.UUID) (*domain.EmployeeWorkWeek, error)
+	SaveEmployeeWorkWeek(workWeek *domain.EmployeeWorkWeek) error
+	DeleteEmployeeWorkWeek(orgID, employeeID uuid.UUID) error
+	ListEmployeeWorkWeeks(orgID uuid.UUID) ([]domain.EmployeeWorkWeek, error)
+
+	// Payroll period methods
+	CreatePayrollPeriod(period *domain.PayrollPeriod) error
+	GetPayrollPeriod(id uuid.UUID) (*domain.PayrollPeriod, error)
+	UpdatePayrollPeriod(period *domain.PayrollPeriod) error
+	ListPayrollPeriods(orgID uuid.UUID) ([]domain.PayrollPeriod, error)
+	ListClosedPeriods(orgID uuid.UUID, startDate, endDate time.Time) ([]domain.PayrollPeriod, error)
+	CreatePayrollPeriodEvent(event *domain.PayrollPeriodEvent) error
+	ListPayrollPeriodEvents(periodID uuid.UUID) ([]domain.PayrollPeriodEvent, error)
+	CountOrganizationTimesheetsByStatus(orgID uuid.UUID, startDate, endDate time.Time, status string) (int64, error)
+
+	// Approval methods
+	CreateApprovalSLARule(rule *domain.ApprovalSLARule) error
+	GetApprovalSLARule(id uuid.UUID) (*domain.ApprovalSLARule, error)
+	UpdateApprovalSLARule(rule *domain.ApprovalSLARule) error
+	DeleteApprovalSLARule(id uuid.UUID) error
+	ListApprovalSLARules(orgID uuid.UUID) ([]domain.ApprovalSLARule, error)
+	ListActiveApprovalSLARules() ([]domain.ApprovalSLARule, error)
+	CreateTimesheetApprover(approver *domain.TimesheetApprover) error
+	ListTimesheetApprovers(timesheetID uuid.UUID) ([]domain.TimesheetApprover, error)
+	DeactivateTimesheetApprovers(timesheetID uuid.UUID) error
+
+	// Project methods
+	CreateProject(project *domain.Project) error
+	GetProject(id uuid.UUID) (*domain.Project, error)
+	UpdateProject(project *domain.Project) error
+	ListProjects(orgID uuid.UUID, status string) ([]domain.Project, error)
+	ListProjectsByIDs(ids []uuid.UUID) ([]domain.Project, error)
+	CreateTask(task *domain.Task) error
+	GetTask(id uuid.UUID) (*domain.Task, error)
+	UpdateTask(task *domain.Task) error
+	ListTasks(projectID uuid.UUID) ([]domain.Task, error)
+	ListTasksByIDs(ids []uuid.UUID) ([]domain.Task, error)
+	AddProjectMember(member *domain.ProjectMember) error
+	RemoveProjectMember(projectID, employeeID uuid.UUID) error
+	IsProjectMember(projectID, employeeID uuid.UUID) (bool, error)
+	GetProjectMember(projectID, employeeID uuid.UUID) (*domain.ProjectMember, error)
+
+	// Budget methods
+	CreateProjectBudget(budget *domain.ProjectBudget) error
+	GetProjectBudget(id uuid.UUID) (*domain.ProjectBudget, error)
+	UpdateProjectBudget(budget *domain.ProjectBudget) error
+	DeleteProjectBudget(id uuid.UUID) error
+	ListProjectBudgets(projectID uuid.UUID) ([]domain.ProjectBudget, error)
+	SumProjectTimesheets(projectID uuid.UUID, taskID *uuid.UUID, excludeID uuid.UUID) ([]domain.TimesheetUsage, error)
+	ListProjectTimesheets(projectID uuid.UUID, taskID *uuid.UUID) ([]domain.Timesheet, error)
+
+	// Rate card methods
+	CreateRateCard(rateCard *domain.RateCard) error
+	GetRateCard(id uuid.UUID) (*domain.RateCard, error)
+	UpdateRateCard(rateCard *domain.RateCard) error
+	DeleteRateCard(id uuid.UUID) error
+	ListRateCards(orgID uuid.UUID) ([]domain.RateCard, error)
+	ListEffectiveRateCards(orgID uuid.UUID, date time.Time) ([]domain.RateCard, error)
+
+	// Invoice methods
+	CreateInvoice(invoice *domain.Invoice, timesheetIDs []uuid.UUID) error
+	GetInvoice(id uuid.UUID) (*domain.Invoice, error)
+	ListInvoices(orgID uuid.UUID) ([]domain.Invoice, error)
+	DeleteInvoice(id uuid.UUID) error
+	ListInvoiceableTimesheets(orgID uuid.UUID, projectIDs []uuid.UUID, startDate, endDate time.Time) ([]domain.Timesheet, error)
+
+	// Template methods
+	CreateTimesheetTemplate(template *domain.TimesheetTemplate) error
+	GetTimesheetTemplate(id uuid.UUID) (*domain.TimesheetTemplate, error)
+	UpdateTimesheetTemplate(template *domain.TimesheetTemplate) error
+	DeleteTimesheetTemplate(id uuid.UUID) error
+	ListTimesheetTemplates(orgID, employeeID uuid.UUID) ([]domain.TimesheetTemplate, error)
+
+	// Comment methods
+	CreateTimesheetComment(comment *domain.TimesheetComment) error
+	ListTimesheetComments(timesheetID uuid.UUID) ([]domain.TimesheetComment, error)
+	ListPeriodComments(orgID, employeeID uuid.UUID, periodStart time.Time) ([]domain.TimesheetComment, error)
+	CreateTimesheetChanges(changes []domain.TimesheetChange) error
+	ListTimesheetChanges(timesheetID uuid.UUID) ([]domain.TimesheetChange, error)
+
+	// Timer methods
+	CreateTimer(timer *domain.Timer) error
+	GetTimer(id uuid.UUID) (*domain.Timer, error)
+	UpdateTimer(timer *domain.Timer) error
+	ListActiveTimers(orgID, employeeID uuid.UUID) ([]domain.Timer, error)
+	GetRunningTimer(employeeID uuid.UUID) (*domain.Timer, error)
+	CreateTimerSegment(segment *domain.TimerSegment) error
+	UpdateTimerSegment(segment *domain.TimerSegment) error
+
+	// Overtime methods
+	CreateOvertimeRule(rule *domain.OvertimeRule) error
+	GetOvertimeRule(id uuid.UUID) (*domain.OvertimeRule, error)
+	UpdateOvertimeRule(rule *domain.OvertimeRule) error
+	DeleteOvertimeRule(id uuid.UUID) error
+	ListOvertimeRules(orgID uuid.UUID) ([]domain.OvertimeRule, error)
+	ReplaceOvertimeEntries(employeeID uuid.UUID, dates []time.Time, entries []domain.OvertimeEntry) error
+	ListOvertimeEntries(orgID uuid.UUID, employeeID *uuid.UUID, startDate, endDate time.Time) ([]domain.OvertimeEntry, error)
+	ListTimesheetsByStatus(orgID uuid.UUID, employeeID *uuid.UUID, startDate, endDate time.Time, status string) ([]domain.Timesheet, error)
+
+	// Compliance methods
+	CreateComplianceRuleSet(ruleSet *domain.ComplianceRuleSet) error
+	GetComplianceRuleSet(id uuid.UUID) (*domain.ComplianceRuleSet, error)
+	UpdateComplianceRuleSet(ruleSet *domain.ComplianceRuleSet) error
+	DeleteComplianceRuleSet(id uuid.UUID) error
+	ListComplianceRuleSets(orgID uuid.UUID) ([]domain.ComplianceRuleSet, error)
+	GetEmployeeJurisdiction(orgID, employeeID uuid.UUID) (*domain.EmployeeJurisdiction, error)
+	SaveEmployeeJurisdiction(jurisdiction *domain.EmployeeJurisdiction) error
+	DeleteEmployeeJurisdiction(orgID, employeeID uuid.UUID) error
+	ListEmployeeJurisdictions(orgID uuid.UUID) ([]domain.EmployeeJurisdiction, error)
+
+	// Leave methods
+	CreateLeaveType(leaveType *domain.LeaveType) error
+	GetLeaveType(id uuid.UUID) (*domain.LeaveType, error)
+	UpdateLeaveType(leaveType *domain.LeaveType) error
+	DeleteLeaveType(id uuid.UUID) error
+	ListLeaveTypes(orgID uuid.UUID) ([]domain.LeaveType, error)
+	CountLeaveRequestsByType(leaveTypeID uuid.UUID) (int64, error)
+	CreateLeaveRequest(request *domain.LeaveRequest) error
+	GetLeaveRequest(id uuid.UUID) (*domain.LeaveRequest, error)
+	UpdateLeaveRequest(request *domain.LeaveRequest) error
+	ListLeaveRequests(orgID uuid.UUID, filter *domain.LeaveRequestFilter) ([]domain.LeaveRequest, error)
+	ApproveLeaveRequest(request *domain.LeaveRequest, balances []domain.LeaveBalance, attendances []domain.Attendance) error
+	CancelLeaveRequest(request *domain.LeaveRequest, balances []domain.LeaveBalance) error
+	GetLeaveBalance(employeeID, leaveTypeID uuid.UUID, year int) (*domain.LeaveBalance, error)
+	GetLatestLeaveBalance(employeeID, leaveTypeID uuid.UUID, year int) (*domain.LeaveBalance, error)
+	SaveLeaveBalance(balance *domain.LeaveBalance) error
+	ListLeaveBalances(employeeID uuid.UUID, year int) ([]domain.LeaveBalance, error)
+	SumPendingLeaveDays(employeeID, leaveTypeID uuid.UUID, startDate, endDate time.Time) (float64, error)
+
+	// Holiday methods
+	CreateHoliday(holiday *domain.Holiday) error
+	GetHoliday(id uuid.UUID) (*domain.Holiday, error)
+	UpdateHoliday(holiday *domain.Holiday) error
+	DeleteHoliday(id uuid.UUID) error
+	ListHolidays(orgID uuid.UUID, startDate, endDate time.Time) ([]domain.Holiday, error)
+	FindHoliday(orgID uuid.UUID, site string, date time.Time) (*domain.Holiday, error)
+	GetEmployeeSite(orgID, employeeID uuid.UUID) (*domain.EmployeeSite, error)
+	SaveEmployeeSite(site *domain.EmployeeSite) error
+	DeleteEmployeeSite(orgID, employeeID uuid.UUID) error
+	ListEmployeeSites(orgID uuid.UUID) ([]domain.EmployeeSite, error)
+
+	// Team methods
+	CreateTeam(team *domain.Team) error
+	GetTeam(id uuid.UUID) (*domain.Team, error)
+	UpdateTeam(team *domain.Team) error
+	DeleteTeam(id uuid.UUID) error
+	ListTeams(orgID uuid.UUID) ([]domain.Team, error)
+	GetEmployeeTeam(orgID, employeeID uuid.UUID) (*domain.EmployeeTeam, error)
+	SaveEmployeeTeam(membership *domain.EmployeeTeam) error
+	DeleteEmployeeTeam(orgID, employeeID uuid.UUID) error
+	ListEmployeeTeams(orgID uuid.UUID) ([]domain.EmployeeTeam, error)
+
+	// Remote work methods
+	GetRemoteWorkPolicy(orgID uuid.UUID) (*domain.RemoteWorkPolicy, error)
+	SaveRemoteWorkPolicy(policy *domain.RemoteWorkPolicy) error
+	CreateRemoteWorkRequest(request *domain.RemoteWorkRequest) error
+	GetRemoteWorkRequest(id uuid.UUID) (*domain.RemoteWorkRequest, error)
+	UpdateRemoteWorkRequest(request *domain.RemoteWorkRequest) error
+	ListRemoteWorkRequests(orgID uuid.UUID, filter *domain.RemoteWorkRequestFilter) ([]domain.RemoteWorkRequest, error)
+	CountRemoteAttendances(employeeID uuid.UUID, startDate, endDate time.Time) (int64, error)
+	CreateRemoteWorkViolations(violations []domain.RemoteWorkViolation) error
+	ListRemoteWorkViolations(orgID uuid.UUID, filter *domain.RemoteWorkViolationFilter) ([]domain.RemoteWorkViolation, error)
+
+	// Roster methods
+	CreateRoster(roster *domain.Roster) error
+	GetRoster(id uuid.UUID) (*domain.Roster, error)
+	UpdateRoster(roster *domain.Roster) error
+	DeleteRoster(id uuid.UUID) error
+	ListRosters(orgID uuid.UUID, startDate, endDate time.Time) ([]domain.Roster, error)
+	CreateShift(shift *domain.Shift) error
+	GetShift(id uuid.UUID) (*domain.Shift, error)
+	UpdateShift(shift *domain.Shift) error
+	DeleteShift(id uuid.UUID) error
+	ListShifts(orgID uuid.UUID, filter *domain.ShiftFilter) ([]domain.Shift, error)
+	ListOverlappingShifts(employeeID uuid.UUID, startsAt, endsAt time.Time) ([]domain.Shift, error)
+	ListScheduledShifts(employeeID uuid.UUID, from, to time.Time) ([]domain.Shift, error)
+	ClaimShift(shiftID, employeeID uuid.UUID) (bool, error)
+	CreateShiftSwapRequest(request *domain.ShiftSwapRequest) error
+	GetShiftSwapRequest(id uuid.UUID) (*domain.ShiftSwapRequest, error)
+	UpdateShiftSwapRequest(request *domain.ShiftSwapRequest) error
+	ListShiftSwapRequests(orgID uuid.UUID, filter *domain.ShiftSwapFilter) ([]domain.ShiftSwapRequest, error)
+	CountPendingShiftSwaps(shiftID uuid.UUID) (int64, error)
+	CancelPendingShiftSwaps(rosterID uuid.UUID) error
+	ApplyShiftSwap(request *domain.ShiftSwapRequest, shifts []domain.Shift) error
+
+	// On-call methods
+	CreateOnCallRotation(rotation *domain.OnCallRotation) error
+	GetOnCallRotation(id uuid.UUID) (*domain.OnCallRotation, error)
+	UpdateOnCallRotation(rotation *domain.OnCallRotation) error
+	DeleteOnCallRotation(id uuid.UUID) error
+	ListOnCallRotations(orgID uuid.UUID, teamID *uuid.UUID) ([]domain.OnCallRotation, error)
+	CountOnCallIncidents(rotationID uuid.UUID) (int64, error)
+	CreateOnCallIncident(incident *domain.OnCallIncident) error
+	ListOnCallIncidents(orgID uuid.UUID, filter *domain.OnCallIncidentFilter) ([]domain.OnCallIncident, error)
+
+	// Notification methods
+	CreateNotificationEvent(event *domain.NotificationEvent) error
+
+	// Transaction runs fn with a repository bound to one database
+	// transaction, committed when fn returns nil and rolled back otherwise
+	Transaction(fn func(repo TimeRepository) error) error
 }
 
 type timeRepository struct {
@@ -39,6 +257,12 @@ func NewTimeRepository(db *gorm.DB) TimeRepository {
 	return &timeRepository{db: db}
 }
 
+func (r *timeRepository) Transaction(fn func(repo TimeRepository) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return fn(&timeRepository{db: tx})
+	})
+}
+
 func (r *timeRepository) CreateAttendance(attendance *domain.Attendance) error {
 	result := r.db.Create(attendance)
 	if result.Error != nil {
@@ -57,12 +281,14 @@ func (r *timeRepository) GetAttendanceByDate(employeeID uuid.UUID, date time.Tim
 }
 
 func (r *timeRepository) UpdateAttendance(attendance *domain.Attendance) error {
-	return r.db.Model(&domain.Attendance{}).Where("id = ?", attendance.ID).Updates(attendance).Error
+	return r.db.Save(attendance).Error
 }
 
-func (r *timeRepository) ListAttendances(orgID uuid.UUID) ([]domain.Attendance, error) {
+// ListAttendances returns the organization's attendance records matching
+// the filter; zero dates leave the range open on that side
+func (r *timeRepository) ListAttendances(orgID uuid.UUID, filter *domain.AttendanceFilter) ([]domain.Attendance, error) {
 	attendances := []domain.Attendance{}
-	err := r.db.Where("organization_id = ?", orgID).Find(&attendances).Error
+	err := r.attendanceQuery(orgID, filter).Order("date, employee_id").Find(&attendances).Error
 	if err != nil {
 		return nil, err
 	}
@@ -109,7 +335,7 @@ func (r *timeRepository) GetTimesheet(id uuid.UUID) (*domain.Timesheet, error) {
 }
 
 func (r *timeRepository) UpdateTimesheet(timesheet *domain.Timesheet) error {
-	return r.db.Model(&domain.Timesheet{}).Updates(timesheet).Error
+	return r.db.Model(&domain.Timesheet{}).Where("id = ?", timesheet.ID).Select("*").Updates(timesheet).Error
 }
 
 func (r *timeRepository) DeleteTimesheet(id uuid.UUID) error {
@@ -118,16 +344,45 @@ func (r *timeRepository) DeleteTimesheet(id uuid.UUID) error {
 
 func (r *timeRepository) ListTimesheets(orgID, employeeID uuid.UUID, startDate, endDate time.Time) ([]domain.Timesheet, error) {
 	timesheets := []domain.Timesheet{}
-	err := r.db.Where("organization_id = ? AND employee_id = ? AND date BETWEEN ? AND ?", orgID, employeeID, startDate, endDate).Find(&timesheets).Error
+	err := r.db.Where("organization_id = ? AND employee_id = ? AND date BETWEEN ? AND ?", orgID, employeeID, startDate, endDate).Order("date, start_time").Find(&timesheets).Error
+	if err != nil {
+		return nil, err
+	}
+	return timesheets, nil
+}
+
+func (r *timeRepository) GetDeletedTimesheet(id uuid.UUID) (*domain.Timesheet, error) {
+	timesheet := &domain.Timesheet{}
+	err := r.db.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).First(timesheet).Error
+	if err != nil {
+		return nil, err
+	}
+	return timesheet, nil
+}
+
+func (r *timeRepository) ListDeletedTimesheets(orgID, employeeID uuid.UUID, startDate, endDate time.Time) ([]domain.Timesheet, error) {
+	timesheets := []domain.Timesheet{}
+	err := r.db.Unscoped().Where("organization_id = ? AND employee_id = ? AND date BETWEEN ? AND ? AND deleted_at IS NOT NULL", orgID, employeeID, startDate, endDate).Order("date, start_time").Find(&timesheets).Error
 	if err != nil {
 		return nil, err
 	}
 	return timesheets, nil
 }
 
+func (r *timeRepository) RestoreTimesheet(id uuid.UUID) error {
+	return r.db.Unscoped().Model(&domain.Timesheet{}).Where("id = ?", id).Update("deleted_at", nil).Error
+}
+
+// PurgeDeletedTimesheets permanently removes the organization's timesheets
+// deleted before the given time.
+func (r *timeRepository) PurgeDeletedTimesheets(orgID uuid.UUID, deletedBefore time.Time) (int64, error) {
+	result := r.db.Unscoped().Where("organization_id = ? AND deleted_at IS NOT NULL AND deleted_at < ?", orgID, deletedBefore).Delete(&domain.Timesheet{})
+	return result.RowsAffected, result.Error
+}
+
 func (r *timeRepository) GetTimesheetSummary(orgID, employeeID uuid.UUID, startDate, endDate time.Time) (float64, error) {
 	var totalHours float64
-	err := r.db.Model(&domain.Timesheet{}).Where("organization_id = ? AND employee_id = ? AND date BETWEEN ? AND ?", orgID, employeeID, startDate, endDate).Select("SUM(hours)").Find(&totalHours).Error
+	err := r.db.Model(&domain.Timesheet{}).Where("organization_id = ? AND employee_id = ? AND date BETWEEN ? AND ? AND status <> ?", orgID, employeeID, startDate, endDate, domain.TimesheetStatusRejected).Select("COALESCE(SUM(hours), 0)").Find(&totalHours).Error
 	if err != nil {
 		return 0, err
 	}
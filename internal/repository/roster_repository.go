@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/Axontik/comin-time-service/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func (r *timeRepository) CreateRoster(roster *domain.Roster) error {
+	return r.db.Omit("Shifts").Create(roster).Error
+}
+
+func (r *timeRepository) GetRoster(id uuid.UUID) (*domain.Roster, error) {
+	roster := &domain.Roster{}
+	err := r.db.Preload("Shifts", orderShifts).Where("id = ?", id).First(roster).Error
+	if err != nil {
+		return nil, err
+	}
+	return roster, nil
+}
+
+func (r *timeRepository) UpdateRoster(roster *domain.Roster) error {
+	return r.db.Omit("Shifts").Save(roster).Error
+}
+
+// DeleteRoster removes the roster with its shifts and their swap requests
+func (r *timeRepository) DeleteRoster(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		shiftIDs := tx.Model(&domain.Shift{}).Select("id").Where("roster_id = ?", id)
+		if err := tx.Where("shift_id IN (?) OR target_shift_id IN (?)", shiftIDs, shiftIDs).Delete(&domain.ShiftSwapRequest{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("roster_id = ?", id).Delete(&domain.Shift{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&domain.Roster{}).Error
+	})
+}
+
+// ListRosters returns the rosters of the weeks starting in a range
+func (r *timeRepository) ListRosters(orgID uuid.UUID, startDate, endDate time.Time) ([]domain.Roster, error) {
+	rosters := []domain.Roster{}
+	err := r.db.Where("organization_id = ? AND week_start BETWEEN ? AND ?", orgID, startDate, endDate).
+		Order("week_start, name").Find(&rosters).Error
+	if err != nil {
+		return nil, err
+	}
+	return rosters, nil
+}
+
+func (r *timeRepository) CreateShift(shift *domain.Shift) error {
+	return r.db.Create(shift).Error
+}
+
+func (r *timeRepository) GetShift(id uuid.UUID) (*domain.Shift, error) {
+	shift := &domain.Shift{}
+	err := r.db.Where("id = ?", id).First(shift).Error
+	if err != nil {
+		return nil, err
+	}
+	return shift, nil
+}
+
+func (r *timeRepository) UpdateShift(shift *domain.Shift) error {
+	return r.db.Save(shift).Error
+}
+
+func (r *timeRepository) DeleteShift(id uuid.UUID) error {
+	return r.db.Where("id = ?", id).Delete(&domain.Shift{}).Error
+}
+
+// ListShifts returns the shifts starting on the dates of the filter's range
+func (r *timeRepository) ListShifts(orgID uuid.UUID, filter *domain.ShiftFilter) ([]domain.Shift, error) {
+	shifts := []domain.Shift{}
+	query := r.db.Where("organization_id = ? AND date BETWEEN ? AND ?", orgID, filter.StartDate, filter.EndDate)
+	if filter.EmployeeID != nil {
+		query = query.Where("employee_id = ?", *filter.EmployeeID)
+	}
+	if filter.OpenOnly {
+		query = query.Where("employee_id IS NULL")
+	}
+	if filter.PublishedOnly {
+		query = query.Where("roster_id IN (?)", r.db.Model(&domain.Roster{}).Select("id").Where("status = ?", domain.RosterStatusPublished))
+	}
+	err := query.Order("starts_at").Find(&shifts).Error
+	if err != nil {
+		return nil, err
+	}
+	return shifts, nil
+}
+
+// ListOverlappingShifts returns the employee's shifts, in any roster, that
+// overlap a period
+func (r *timeRepository) ListOverlappingShifts(employeeID uuid.UUID, startsAt, endsAt time.Time) ([]domain.Shift, error) {
+	shifts := []domain.Shift{}
+	err := r.db.Where("employee_id = ? AND starts_at < ? AND ends_at > ?", employeeID, endsAt, startsAt).
+		Order("starts_at").Find(&shifts).Error
+	if err != nil {
+		return nil, err
+	}
+	return shifts, nil
+}
+
+// ListScheduledShifts returns the employee's published shifts starting in
+// a period
+func (r *timeRepository) ListScheduledShifts(employeeID uuid.UUID, from, to time.Time) ([]domain.Shift, error) {
+	shifts := []domain.Shift{}
+	err := r.db.Where("employee_id = ? AND starts_at BETWEEN ? AND ?", employeeID, from, to).
+		Where("roster_id IN (?)", r.db.Model(&domain.Roster{}).Select("id").Where("status = ?", domain.RosterStatusPublished)).
+		Order("starts_at").Find(&shifts).Error
+	if err != nil {
+		return nil, err
+	}
+	return shifts, nil
+}
+
+// ClaimShift assigns an open shift to the employee. It reports false when
+// the shift was no longer open.
+func (r *timeRepository) ClaimShift(shiftID, employeeID uuid.UUID) (bool, error) {
+	result := r.db.Model(&domain.Shift{}).Where("id = ? AND employee_id IS NULL", shiftID).
+		Updates(map[string]interface{}{"employee_id": employeeID, "updated_at": time.Now()})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *timeRepository) CreateShiftSwapRequest(request *domain.ShiftSwapRequest) error {
+	return r.db.Create(request).Error
+}
+
+func (r *timeRepository) GetShiftSwapRequest(id uuid.UUID) (*domain.ShiftSwapRequest, error) {
+	request := &domain.ShiftSwapRequest{}
+	err := r.db.Where("id = ?", id).First(request).Error
+	if err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+func (r *timeRepository) UpdateShiftSwapRequest(request *domain.ShiftSwapRequest) error {
+	return r.db.Save(request).Error
+}
+
+// ListShiftSwapRequests returns the requests the filter's employee made or
+// is the target of
+func (r *timeRepository) ListShiftSwapRequests(orgID uuid.UUID, filter *domain.ShiftSwapFilter) ([]domain.ShiftSwapRequest, error) {
+	requests := []domain.ShiftSwapRequest{}
+	query := r.db.Where("organization_id = ?", orgID)
+	if filter.EmployeeID != nil {
+		query = query.Where("requester_id = ? OR target_employee_id = ?", *filter.EmployeeID, *filter.EmployeeID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	err := query.Order("created_at DESC").Find(&requests).Error
+	if err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+func (r *timeRepository) CountPendingShiftSwaps(shiftID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Model(&domain.ShiftSwapRequest{}).
+		Where("status = ? AND (shift_id = ? OR target_shift_id = ?)", domain.ShiftSwapPending, shiftID, shiftID).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// CancelPendingShiftSwaps cancels the pending swaps of a roster's shifts
+func (r *timeRepository) CancelPendingShiftSwaps(rosterID uuid.UUID) error {
+	shiftIDs := r.db.Model(&domain.Shift{}).Select("id").Where("roster_id = ?", rosterID)
+	return r.db.Model(&domain.ShiftSwapRequest{}).
+		Where("status = ? AND (shift_id IN (?) OR target_shift_id IN (?))", domain.ShiftSwapPending, shiftIDs, shiftIDs).
+		Updates(map[string]interface{}{"status": domain.ShiftSwapCancelled, "updated_at": time.Now()}).Error
+}
+
+// ApplyShiftSwap saves the approved request together with the reassigned
+// shifts
+func (r *timeRepository) ApplyShiftSwap(request *domain.ShiftSwapRequest, shifts []domain.Shift) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(request).Error; err != nil {
+			return err
+		}
+		for i := range shifts {
+			if err := tx.Save(&shifts[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func orderShifts(db *gorm.DB) *gorm.DB {
+	return db.Order("starts_at")
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"github.com/Axontik/comin-time-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+func (r *timeRepository) CreateTeam(team *domain.Team) error {
+	return r.db.Create(team).Error
+}
+
+func (r *timeRepository) GetTeam(id uuid.UUID) (*domain.Team, error) {
+	team := &domain.Team{}
+	err := r.db.Where("id = ?", id).First(team).Error
+	if err != nil {
+		return nil, err
+	}
+	return team, nil
+}
+
+func (r *timeRepository) UpdateTeam(team *domain.Team) error {
+	return r.db.Save(team).Error
+}
+
+func (r *timeRepository) DeleteTeam(id uuid.UUID) error {
+	return r.db.Where("id = ?", id).Delete(&domain.Team{}).Error
+}
+
+func (r *timeRepository) ListTeams(orgID uuid.UUID) ([]domain.Team, error) {
+	teams := []domain.Team{}
+	err := r.db.Where("organization_id = ?", orgID).Order("name").Find(&teams).Error
+	if err != nil {
+		return nil, err
+	}
+	return teams, nil
+}
+
+func (r *timeRepository) GetEmployeeTeam(orgID, employeeID uuid.UUID) (*domain.EmployeeTeam, error) {
+	membership := &domain.EmployeeTeam{}
+	err := r.db.Where("organization_id = ? AND employee_id = ?", orgID, employeeID).First(membership).Error
+	if err != nil {
+		return nil, err
+	}
+	return membership, nil
+}
+
+func (r *timeRepository) SaveEmployeeTeam(membership *domain.EmployeeTeam) error {
+	return r.db.Save(membership).Error
+}
+
+func (r *timeRepository) DeleteEmployeeTeam(orgID, employeeID uuid.UUID) error {
+	return r.db.Where("organization_id = ? AND employee_id = ?", orgID, employeeID).Delete(&domain.EmployeeTeam{}).Error
+}
+
+func (r *timeRepository) ListEmployeeTeams(orgID uuid.UUID) ([]domain.EmployeeTeam, error) {
+	memberships := []domain.EmployeeTeam{}
+	err := r.db.Where("organization_id = ?", orgID).Find(&memberships).Error
+	if err != nil {
+		return nil, err
+	}
+	return memberships, nil
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"github.com/Axontik/comin-time-service/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func (r *timeRepository) CreateTimesheetTemplate(template *domain.TimesheetTemplate) error {
+	return r.db.Create(template).Error
+}
+
+func (r *timeRepository) GetTimesheetTemplate(id uuid.UUID) (*domain.TimesheetTemplate, error) {
+	template := &domain.TimesheetTemplate{}
+	err := r.db.Preload("Lines", orderTemplateLines).Where("id = ?", id).First(template).Error
+	if err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// UpdateTimesheetTemplate saves the template and replaces its lines
+func (r *timeRepository) UpdateTimesheetTemplate(template *domain.TimesheetTemplate) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Lines").Save(template).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("template_id = ?", template.ID).Delete(&domain.TimesheetTemplateLine{}).Error; err != nil {
+			return err
+		}
+		for i := range template.Lines {
+			template.Lines[i].TemplateID = template.ID
+		}
+		if len(template.Lines) == 0 {
+			return nil
+		}
+		return tx.Create(&template.Lines).Error
+	})
+}
+
+func (r *timeRepository) DeleteTimesheetTemplate(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("template_id = ?", id).Delete(&domain.TimesheetTemplateLine{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&domain.TimesheetTemplate{}).Error
+	})
+}
+
+func (r *timeRepository) ListTimesheetTemplates(orgID, employeeID uuid.UUID) ([]domain.TimesheetTemplate, error) {
+	templates := []domain.TimesheetTemplate{}
+	err := r.db.Preload("Lines", orderTemplateLines).Where("organization_id = ? AND employee_id = ?", orgID, employeeID).Order("name").Find(&templates).Error
+	if err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+func orderTemplateLines(db *gorm.DB) *gorm.DB {
+	return db.Order("weekday, start_time")
+}
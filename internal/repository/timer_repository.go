@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"github.com/Axontik/comin-time-service/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func (r *timeRepository) CreateTimer(timer *domain.Timer) error {
+	return r.db.Create(timer).Error
+}
+
+func (r *timeRepository) GetTimer(id uuid.UUID) (*domain.Timer, error) {
+	timer := &domain.Timer{}
+	err := r.db.Preload("Segments", func(db *gorm.DB) *gorm.DB {
+		return db.Order("started_at")
+	}).Where("id = ?", id).First(timer).Error
+	if err != nil {
+		return nil, err
+	}
+	return timer, nil
+}
+
+func (r *timeRepository) UpdateTimer(timer *domain.Timer) error {
+	return r.db.Model(&domain.Timer{}).Where("id = ?", timer.ID).Omit("Segments").Updates(timer).Error
+}
+
+func (r *timeRepository) ListActiveTimers(orgID, employeeID uuid.UUID) ([]domain.Timer, error) {
+	timers := []domain.Timer{}
+	err := r.db.Preload("Segments", func(db *gorm.DB) *gorm.DB {
+		return db.Order("started_at")
+	}).Where("organization_id = ? AND employee_id = ? AND status IN ?", orgID, employeeID, []string{domain.TimerStatusRunning, domain.TimerStatusPaused}).Order("started_at").Find(&timers).Error
+	if err != nil {
+		return nil, err
+	}
+	return timers, nil
+}
+
+func (r *timeRepository) GetRunningTimer(employeeID uuid.UUID) (*domain.Timer, error) {
+	timer := &domain.Timer{}
+	err := r.db.Where("employee_id = ? AND status = ?", employeeID, domain.TimerStatusRunning).First(timer).Error
+	if err != nil {
+		return nil, err
+	}
+	return timer, nil
+}
+
+func (r *timeRepository) CreateTimerSegment(segment *domain.TimerSegment) error {
+	return r.db.Create(segment).Error
+}
+
+func (r *timeRepository) UpdateTimerSegment(segment *domain.TimerSegment) error {
+	return r.db.Model(&domain.TimerSegment{}).Where("id = ?", segment.ID).Updates(segment).Error
+}
@@ -0,0 +1,358 @@
+package service
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/Axontik/comin-time-service/internal/domain"
+	apperrors "github.com/Axontik/comin-time-service/internal/errors"
+	"github.com/google/uuid"
+)
+
+func (s *timeService) CreateApprovalSLARule(orgID uuid.UUID, req *domain.ApprovalSLARuleRequest) (*domain.ApprovalSLARule, error) {
+	if err := validateApprovalSLARule(req); err != nil {
+		return nil, err
+	}
+
+	rules, err := s.timeRepo.ListApprovalSLARules(orgID)
+	if err != nil {
+		return nil, err
+	}
+	for _, rule := range rules {
+		if rule.Level == req.Level {
+			return nil, apperrors.NewConflictError("an SLA rule already exists for this level")
+		}
+	}
+
+	rule := &domain.ApprovalSLARule{OrganizationID: orgID, IsActive: true}
+	applyApprovalSLARuleRequest(rule, req)
+
+	if err := s.timeRepo.CreateApprovalSLARule(rule); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+func (s *timeService) UpdateApprovalSLARule(orgID, id uuid.UUID, req *domain.ApprovalSLARuleRequest) (*domain.ApprovalSLARule, error) {
+	if err := validateApprovalSLARule(req); err != nil {
+		return nil, err
+	}
+
+	rule, err := s.getApprovalSLARule(orgID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := s.timeRepo.ListApprovalSLARules(orgID)
+	if err != nil {
+		return nil, err
+	}
+	for _, other := range rules {
+		if other.ID != rule.ID && other.Level == req.Level {
+			return nil, apperrors.NewConflictError("an SLA rule already exists for this level")
+		}
+	}
+
+	applyApprovalSLARuleRequest(rule, req)
+
+	if err := s.timeRepo.UpdateApprovalSLARule(rule); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+func (s *timeService) DeleteApprovalSLARule(orgID, id uuid.UUID) error {
+	if _, err := s.getApprovalSLARule(orgID, id); err != nil {
+		return err
+	}
+	return s.timeRepo.DeleteApprovalSLARule(id)
+}
+
+func (s *timeService) ListApprovalSLARules(orgID uuid.UUID) ([]domain.ApprovalSLARule, error) {
+	return s.timeRepo.ListApprovalSLARules(orgID)
+}
+
+func (s *timeService) ListTimesheetApprovers(timesheetID uuid.UUID) ([]domain.TimesheetApprover, error) {
+	return s.timeRepo.ListTimesheetApprovers(timesheetID)
+}
+
+// EscalateOverdueTimesheets applies the organizations' SLA rules to every
+// pending timesheet and returns the number of escalations performed.
+func (s *timeService) EscalateOverdueTimesheets(now time.Time) (int, error) {
+	rules, err := s.timeRepo.ListActiveApprovalSLARules()
+	if err != nil {
+		return 0, err
+	}
+
+	rulesByOrg := map[uuid.UUID][]domain.ApprovalSLARule{}
+	for _, rule := range rules {
+		rulesByOrg[rule.OrganizationID] = append(rulesByOrg[rule.OrganizationID], rule)
+	}
+
+	escalated := 0
+	for orgID, orgRules := range rulesByOrg {
+		count, err := s.escalateOrganization(orgID, orgRules, now)
+		if err != nil {
+			log.Printf("Failed to escalate timesheets for organization %s: %v", orgID, err)
+			continue
+		}
+		escalated += count
+	}
+
+	return escalated, nil
+}
+
+func (s *timeService) escalateOrganization(orgID uuid.UUID, rules []domain.ApprovalSLARule, now time.Time) (int, error) {
+	pending, err := s.timeRepo.ListPendingTimesheets(orgID)
+	if err != nil {
+		return 0, err
+	}
+	if len(pending) == 0 {
+		return 0, nil
+	}
+
+	// Pending entries are ordered by creation, so the first is the oldest
+	calendar, err := s.holidayCalendar(orgID, pending[0].CreatedAt, now)
+	if err != nil {
+		return 0, err
+	}
+	policy, err := s.GetTimesheetPolicy(orgID)
+	if err != nil {
+		return 0, err
+	}
+	workWeek := policyWorkWeek(policy)
+
+	escalated := 0
+	for i := range pending {
+		timesheet := &pending[i]
+		approvers, err := s.timeRepo.ListTimesheetApprovers(timesheet.ID)
+		if err != nil {
+			return escalated, err
+		}
+
+		currentLevel := 0
+		for _, approver := range approvers {
+			if approver.Level > currentLevel {
+				currentLevel = approver.Level
+			}
+		}
+
+		elapsed := workWeek.CountBusinessDays(timesheet.CreatedAt, now, calendar.fullDayHolidaysFor(timesheet.EmployeeID))
+		for _, rule := range rules {
+			if rule.Level <= currentLevel {
+				continue
+			}
+			if elapsed < rule.BusinessDays {
+				break
+			}
+
+			approver, err := s.escalateTimesheet(timesheet, approvers, rule, elapsed, now)
+			if err != nil {
+				log.Printf("Failed to escalate timesheet %s to level %d: %v", timesheet.ID, rule.Level, err)
+				continue
+			}
+			if approver == nil {
+				continue
+			}
+
+			if rule.Action == domain.EscalationActionReassign {
+				for j := range approvers {
+					approvers[j].IsActive = false
+				}
+			}
+			approvers = append(approvers, *approver)
+			currentLevel = rule.Level
+			escalated++
+		}
+	}
+
+	return escalated, nil
+}
+
+// escalateTimesheet assigns the approver designated by rule and notifies them.
+// It returns nil when no approver could be resolved for the rule.
+func (s *timeService) escalateTimesheet(timesheet *domain.Timesheet, approvers []domain.TimesheetApprover, rule domain.ApprovalSLARule, elapsed int, now time.Time) (*domain.TimesheetApprover, error) {
+	targetID, err := s.resolveEscalationTarget(timesheet, approvers, rule)
+	if err != nil {
+		return nil, err
+	}
+	if targetID == nil {
+		log.Printf("No escalation target for timesheet %s at level %d", timesheet.ID, rule.Level)
+		return nil, nil
+	}
+
+	if rule.Action == domain.EscalationActionReassign {
+		if err := s.timeRepo.DeactivateTimesheetApprovers(timesheet.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	approver := &domain.TimesheetApprover{
+		TimesheetID: timesheet.ID,
+		ApproverID:  *targetID,
+		Level:       rule.Level,
+		IsActive:    true,
+		AssignedAt:  now,
+	}
+	if err := s.timeRepo.CreateTimesheetApprover(approver); err != nil {
+		return nil, err
+	}
+
+	previous := []uuid.UUID{}
+	for _, a := range approvers {
+		if a.IsActive {
+			previous = append(previous, a.ApproverID)
+		}
+	}
+
+	s.emitNotification(timesheet.OrganizationID, targetID, domain.NotificationTimesheetEscalated, "timesheet", &timesheet.ID, map[string]interface{}{
+		"timesheet_id":       timesheet.ID,
+		"employee_id":        timesheet.EmployeeID,
+		"level":              rule.Level,
+		"action":             rule.Action,
+		"business_days":      elapsed,
+		"previous_approvers": previous,
+	})
+
+	return approver, nil
+}
+
+func (s *timeService) resolveEscalationTarget(timesheet *domain.Timesheet, approvers []domain.TimesheetApprover, rule domain.ApprovalSLARule) (*uuid.UUID, error) {
+	if rule.EscalationTarget == domain.EscalationTargetOrgAdmin {
+		return rule.EscalationUserID, nil
+	}
+
+	// Escalate to the manager of the most senior active approver, or of the
+	// employee when nobody has been assigned yet.
+	subjectID := timesheet.EmployeeID
+	level := -1
+	for _, approver := range approvers {
+		if approver.IsActive && approver.Level > level {
+			subjectID = approver.ApproverID
+			level = approver.Level
+		}
+	}
+
+	if s.employees == nil {
+		return rule.EscalationUserID, nil
+	}
+
+	managerID, err := s.employees.GetManagerID(timesheet.OrganizationID, subjectID)
+	if err != nil {
+		return nil, err
+	}
+	if managerID == nil {
+		return rule.EscalationUserID, nil
+	}
+	return managerID, nil
+}
+
+// assignInitialApprover routes a new timesheet to the employee's manager.
+// Failures are logged only; the SLA job will escalate unassigned timesheets.
+func (s *timeService) assignInitialApprover(timesheet *domain.Timesheet) {
+	if s.employees == nil {
+		return
+	}
+
+	managerID, err := s.employees.GetManagerID(timesheet.OrganizationID, timesheet.EmployeeID)
+	if err != nil {
+		log.Printf("Failed to resolve manager for employee %s: %v", timesheet.EmployeeID, err)
+		return
+	}
+	if managerID == nil {
+		return
+	}
+
+	approver := &domain.TimesheetApprover{
+		TimesheetID: timesheet.ID,
+		ApproverID:  *managerID,
+		IsActive:    true,
+		AssignedAt:  time.Now(),
+	}
+	if err := s.timeRepo.CreateTimesheetApprover(approver); err != nil {
+		log.Printf("Failed to assign approver to timesheet %s: %v", timesheet.ID, err)
+	}
+}
+
+// emitNotification writes an event to the notification outbox. Failures are
+// logged only, so that notifications never block the underlying operation.
+func (s *timeService) emitNotification(orgID uuid.UUID, recipientID *uuid.UUID, eventType, subjectType string, subjectID *uuid.UUID, payload interface{}) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		log.Printf("Failed to encode %s notification: %v", eventType, err)
+		return
+	}
+
+	event := &domain.NotificationEvent{
+		OrganizationID: orgID,
+		RecipientID:    recipientID,
+		Type:           eventType,
+		SubjectType:    subjectType,
+		SubjectID:      subjectID,
+		Payload:        string(data),
+		Status:         domain.NotificationStatusPending,
+	}
+	if err := s.timeRepo.CreateNotificationEvent(event); err != nil {
+		log.Printf("Failed to store %s notification: %v", eventType, err)
+	}
+}
+
+func (s *timeService) getApprovalSLARule(orgID, id uuid.UUID) (*domain.ApprovalSLARule, error) {
+	rule, err := s.timeRepo.GetApprovalSLARule(id)
+	if err != nil {
+		return nil, apperrors.NewNotFoundError("SLA rule not found")
+	}
+	if rule.OrganizationID != orgID {
+		return nil, apperrors.NewNotFoundError("SLA rule not found")
+	}
+	return rule, nil
+}
+
+func validateApprovalSLARule(req *domain.ApprovalSLARuleRequest) error {
+	if req.EscalationTarget == domain.EscalationTargetOrgAdmin && req.EscalationUserID == nil {
+		return apperrors.NewBadRequestError("escalation_user_id is required when escalating to an org admin")
+	}
+	return nil
+}
+
+func applyApprovalSLARuleRequest(rule *domain.ApprovalSLARule, req *domain.ApprovalSLARuleRequest) {
+	rule.Level = req.Level
+	rule.BusinessDays = req.BusinessDays
+	rule.EscalationTarget = req.EscalationTarget
+	rule.EscalationUserID = req.EscalationUserID
+	rule.Action = req.Action
+	if rule.Action == "" {
+		rule.Action = domain.EscalationActionAddApprover
+	}
+	if req.IsActive != nil {
+		rule.IsActive = *req.IsActive
+	}
+}
+
+// reviewableTimesheet loads the employee's entry for an approval or
+// rejection by the user. Nobody reviews their own entries; admins review
+// any other, everyone else only those they are an active approver of.
+func (s *timeService) reviewableTimesheet(orgID, employeeID, id, userID uuid.UUID, isAdmin bool) (*domain.Timesheet, error) {
+	timesheet, err := s.timeRepo.GetTimesheet(id)
+	if err != nil || timesheet.OrganizationID != orgID || timesheet.EmployeeID != employeeID {
+		return nil, apperrors.NewNotFoundError("timesheet not found")
+	}
+	if userID == timesheet.EmployeeID {
+		return nil, apperrors.NewForbiddenError("employees cannot review their own timesheets")
+	}
+	if isAdmin {
+		return timesheet, nil
+	}
+
+	approvers, err := s.timeRepo.ListTimesheetApprovers(timesheet.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, approver := range approvers {
+		if approver.IsActive && approver.ApproverID == userID {
+			return timesheet, nil
+		}
+	}
+	return nil, apperrors.NewForbiddenError("only an assigned approver can review this timesheet")
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Axontik/comin-time-service/internal/domain"
+	apperrors "github.com/Axontik/comin-time-service/internal/errors"
+	"github.com/Axontik/comin-time-service/utils"
+	"github.com/google/uuid"
+)
+
+// GetAttendanceReport returns a page of the attendance records matching the
+// filter, or of their groups by day, employee or site, with aggregates over
+// every matching record. Pages follow each other through an opaque cursor
+// holding the grouping and the key of the last row returned.
+func (s *timeService) GetAttendanceReport(orgID uuid.UUID, filter *domain.AttendanceReportFilter) (*domain.AttendanceReport, error) {
+	switch filter.GroupBy {
+	case "", domain.AttendanceByDay, domain.AttendanceByEmployee, domain.AttendanceBySite:
+	default:
+		return nil, apperrors.NewBadRequestError("group_by must be day, employee or site")
+	}
+	switch {
+	case filter.Limit == 0:
+		filter.Limit = domain.DefaultAttendanceReportLimit
+	case filter.Limit < 0 || filter.Limit > domain.MaxAttendanceReportLimit:
+		return nil, apperrors.NewBadRequestError(fmt.Sprintf("limit must be between 1 and %d", domain.MaxAttendanceReportLimit))
+	}
+	var after *string
+	if filter.Cursor != "" {
+		decoded, err := base64.RawURLEncoding.DecodeString(filter.Cursor)
+		if err != nil {
+			return nil, apperrors.NewBadRequestError("invalid cursor")
+		}
+		groupBy, key, ok := strings.Cut(string(decoded), ":")
+		if !ok {
+			return nil, apperrors.NewBadRequestError("invalid cursor")
+		}
+		if groupBy != filter.GroupBy {
+			return nil, apperrors.NewBadRequestError("cursor does not match group_by")
+		}
+		after = &key
+	}
+
+	policy, err := s.GetTimesheetPolicy(orgID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := time.LoadLocation(policy.Timezone); err != nil {
+		return nil, err
+	}
+	summary, err := s.timeRepo.SummarizeAttendances(orgID, &filter.AttendanceFilter, policy.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.AttendanceReport{
+		StartDate: filter.StartDate,
+		EndDate:   filter.EndDate,
+		GroupBy:   filter.GroupBy,
+		Summary:   *summary,
+	}
+
+	// One row more than the page tells whether another page follows
+	if filter.GroupBy == "" {
+		var last *domain.Attendance
+		if after != nil {
+			if last, err = attendanceRecordKey(*after); err != nil {
+				return nil, err
+			}
+		}
+		records, err := s.timeRepo.ListAttendancesAfter(orgID, &filter.AttendanceFilter, last, filter.Limit+1)
+		if err != nil {
+			return nil, err
+		}
+		if len(records) > filter.Limit {
+			records = records[:filter.Limit]
+			last := records[len(records)-1]
+			report.NextCursor = attendanceCursor("", fmt.Sprintf("%s|%s|%s", utils.FormatDate(last.Date), last.EmployeeID, last.ID))
+		}
+		report.Records = records
+		return report, nil
+	}
+
+	groups, err := s.timeRepo.ListAttendanceGroups(orgID, &filter.AttendanceFilter, policy.Timezone, filter.GroupBy, after, filter.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+	if len(groups) > filter.Limit {
+		groups = groups[:filter.Limit]
+		report.NextCursor = attendanceCursor(filter.GroupBy, groups[len(groups)-1].Key)
+	}
+	report.Groups = groups
+	return report, nil
+}
+
+// attendanceCursor encodes the grouping and the key of the last row of a
+// page; the separator keeps the cursor of the empty site key non-empty
+func attendanceCursor(groupBy, key string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(groupBy + ":" + key))
+}
+
+// attendanceRecordKey reads the date, employee and ID of a record cursor key
+func attendanceRecordKey(key string) (*domain.Attendance, error) {
+	parts := strings.Split(key, "|")
+	if len(parts) != 3 {
+		return nil, apperrors.NewBadRequestError("invalid cursor")
+	}
+	date, err := utils.ParseDate(parts[0])
+	if err != nil {
+		return nil, apperrors.NewBadRequestError("invalid cursor")
+	}
+	employeeID, err := uuid.Parse(parts[1])
+	if err != nil {
+		return nil, apperrors.NewBadRequestError("invalid cursor")
+	}
+	id, err := uuid.Parse(parts[2])
+	if err != nil {
+		return nil, apperrors.NewBadRequestError("invalid cursor")
+	}
+	attendance := &domain.Attendance{EmployeeID: employeeID, Date: date}
+	attendance.ID = id
+	return attendance, nil
+}
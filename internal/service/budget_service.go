@@ -0,0 +1,336 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/Axontik/comin-time-service/internal/domain"
+	apperrors "github.com/Axontik/comin-time-service/internal/errors"
+	"github.com/google/uuid"
+)
+
+func (s *timeService) CreateProjectBudget(orgID, projectID uuid.UUID, req *domain.ProjectBudgetRequest) (*domain.ProjectBudget, error) {
+	if err := s.validateBudgetScope(orgID, projectID, req.TaskID); err != nil {
+		return nil, err
+	}
+
+	budgets, err := s.timeRepo.ListProjectBudgets(projectID)
+	if err != nil {
+		return nil, err
+	}
+	for _, budget := range budgets {
+		if sameTask(budget.TaskID, req.TaskID) {
+			return nil, apperrors.NewConflictError("a budget already exists for this project or task")
+		}
+	}
+
+	budget := &domain.ProjectBudget{ProjectID: projectID}
+	applyProjectBudgetRequest(budget, req)
+	if err := s.timeRepo.CreateProjectBudget(budget); err != nil {
+		return nil, err
+	}
+	return budget, nil
+}
+
+func (s *timeService) UpdateProjectBudget(orgID, projectID, id uuid.UUID, req *domain.ProjectBudgetRequest) (*domain.ProjectBudget, error) {
+	budget, err := s.getProjectBudget(orgID, projectID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !sameTask(budget.TaskID, req.TaskID) {
+		return nil, apperrors.NewBadRequestError("the task of a budget cannot be changed")
+	}
+
+	applyProjectBudgetRequest(budget, req)
+	if err := s.timeRepo.UpdateProjectBudget(budget); err != nil {
+		return nil, err
+	}
+	s.checkBudgetAlerts(projectID, budget.TaskID)
+
+	return s.timeRepo.GetProjectBudget(id)
+}
+
+func (s *timeService) DeleteProjectBudget(orgID, projectID, id uuid.UUID) error {
+	if _, err := s.getProjectBudget(orgID, projectID, id); err != nil {
+		return err
+	}
+	return s.timeRepo.DeleteProjectBudget(id)
+}
+
+func (s *timeService) ListProjectBudgets(orgID, projectID uuid.UUID) ([]domain.BudgetStatus, error) {
+	if _, err := s.getOrganizationProject(orgID, projectID); err != nil {
+		return nil, err
+	}
+
+	budgets, err := s.timeRepo.ListProjectBudgets(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := []domain.BudgetStatus{}
+	for _, budget := range budgets {
+		status, err := s.budgetStatus(budget)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, *status)
+	}
+	return statuses, nil
+}
+
+// GetProjectBurnDown returns the budget of a project (or task) with its weekly
+// burn from approved and pending timesheets.
+func (s *timeService) GetProjectBurnDown(orgID, projectID uuid.UUID, taskID *uuid.UUID) (*domain.BurnDownResponse, error) {
+	if err := s.validateBudgetScope(orgID, projectID, taskID); err != nil {
+		return nil, err
+	}
+
+	budget := domain.ProjectBudget{ProjectID: projectID, TaskID: taskID}
+	budgets, err := s.timeRepo.ListProjectBudgets(projectID)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range budgets {
+		if sameTask(b.TaskID, taskID) {
+			budget = b
+		}
+	}
+
+	status, err := s.budgetStatus(budget)
+	if err != nil {
+		return nil, err
+	}
+
+	timesheets, err := s.timeRepo.ListProjectTimesheets(projectID, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &domain.BurnDownResponse{Budget: *status, Points: []domain.BurnDownPoint{}}
+	if len(timesheets) == 0 {
+		return response, nil
+	}
+
+	policy, err := s.GetTimesheetPolicy(orgID)
+	if err != nil {
+		return nil, err
+	}
+	workWeek := policyWorkWeek(policy)
+
+	var cumulativeHours, cumulativeAmount float64
+	index := 0
+	lastWeek := workWeek.StartOfWeek(time.Now().Truncate(24 * time.Hour))
+	for week := workWeek.StartOfWeek(timesheets[0].Date); !week.After(lastWeek); week = week.AddDate(0, 0, 7) {
+		point := domain.BurnDownPoint{WeekStart: week}
+		weekEnd := week.AddDate(0, 0, 7)
+		for ; index < len(timesheets) && timesheets[index].Date.Before(weekEnd); index++ {
+			timesheet := timesheets[index]
+			if timesheet.Status == domain.TimesheetStatusApproved {
+				point.ApprovedHours += timesheet.Hours
+			} else {
+				point.PendingHours += timesheet.Hours
+			}
+			cumulativeHours += timesheet.Hours
+			cumulativeAmount += timesheet.BillableAmount
+		}
+		point.CumulativeHours = cumulativeHours
+		point.CumulativeAmount = roundAmount(cumulativeAmount)
+		point.RemainingHours = budget.BudgetHours - cumulativeHours
+		point.RemainingAmount = roundAmount(budget.BudgetAmount - cumulativeAmount)
+		response.Points = append(response.Points, point)
+	}
+
+	return response, nil
+}
+
+func (s *timeService) budgetStatus(budget domain.ProjectBudget) (*domain.BudgetStatus, error) {
+	usage, err := s.timeRepo.SumProjectTimesheets(budget.ProjectID, budget.TaskID, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &domain.BudgetStatus{ProjectBudget: budget}
+	for _, u := range usage {
+		if u.Status == domain.TimesheetStatusApproved {
+			status.ApprovedHours += u.Hours
+			status.ApprovedAmount += u.Amount
+		} else {
+			status.PendingHours += u.Hours
+			status.PendingAmount += u.Amount
+		}
+	}
+
+	usedHours := status.ApprovedHours + status.PendingHours
+	usedAmount := status.ApprovedAmount + status.PendingAmount
+	status.RemainingHours = budget.BudgetHours - usedHours
+	status.RemainingAmount = roundAmount(budget.BudgetAmount - usedAmount)
+	status.HoursPercent = budgetPercent(usedHours, budget.BudgetHours)
+	status.AmountPercent = budgetPercent(usedAmount, budget.BudgetAmount)
+	return status, nil
+}
+
+// validateProjectBudget rejects an entry that would take a hard-capped
+// project or task budget over its limit.
+func (s *timeService) validateProjectBudget(timesheet *domain.Timesheet) error {
+	if timesheet.ProjectID == nil {
+		return nil
+	}
+
+	budgets, err := s.timeRepo.ListProjectBudgets(*timesheet.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	for _, budget := range budgets {
+		if !budget.HardCap || !budgetApplies(budget, timesheet) {
+			continue
+		}
+
+		usage, err := s.timeRepo.SumProjectTimesheets(budget.ProjectID, budget.TaskID, timesheet.ID)
+		if err != nil {
+			return err
+		}
+		hours, amount := timesheet.Hours, timesheet.BillableAmount
+		for _, u := range usage {
+			hours += u.Hours
+			amount += u.Amount
+		}
+
+		if budget.BudgetHours > 0 && hours > budget.BudgetHours {
+			return apperrors.NewBusinessRuleError(apperrors.ErrBudgetExceeded, fmt.Sprintf("the entry exceeds the budget of %.2f hours", budget.BudgetHours), map[string]interface{}{
+				"budget_id":    budget.ID,
+				"task_id":      budget.TaskID,
+				"budget_hours": budget.BudgetHours,
+				"total_hours":  hours,
+			})
+		}
+		if budget.BudgetAmount > 0 && amount > budget.BudgetAmount {
+			return apperrors.NewBusinessRuleError(apperrors.ErrBudgetExceeded, fmt.Sprintf("the entry exceeds the budget of %.2f", budget.BudgetAmount), map[string]interface{}{
+				"budget_id":     budget.ID,
+				"task_id":       budget.TaskID,
+				"budget_amount": budget.BudgetAmount,
+				"total_amount":  roundAmount(amount),
+			})
+		}
+	}
+	return nil
+}
+
+// checkBudgetAlerts emits an alert for every budget threshold newly crossed by
+// the project, or by the task when taskID is set. Errors are logged only.
+func (s *timeService) checkBudgetAlerts(projectID uuid.UUID, taskID *uuid.UUID) {
+	project, err := s.timeRepo.GetProject(projectID)
+	if err != nil {
+		log.Printf("Failed to load project %s for budget alerts: %v", projectID, err)
+		return
+	}
+
+	budgets, err := s.timeRepo.ListProjectBudgets(projectID)
+	if err != nil {
+		log.Printf("Failed to load budgets of project %s: %v", projectID, err)
+		return
+	}
+
+	for _, budget := range budgets {
+		if budget.TaskID != nil && !sameTask(budget.TaskID, taskID) {
+			continue
+		}
+
+		status, err := s.budgetStatus(budget)
+		if err != nil {
+			log.Printf("Failed to compute budget %s: %v", budget.ID, err)
+			continue
+		}
+
+		hoursLevel := s.alertBudgetThreshold(project, status, "hours", status.HoursPercent, budget.HoursAlertLevel)
+		amountLevel := s.alertBudgetThreshold(project, status, "amount", status.AmountPercent, budget.AmountAlertLevel)
+		if hoursLevel == budget.HoursAlertLevel && amountLevel == budget.AmountAlertLevel {
+			continue
+		}
+
+		budget.HoursAlertLevel = hoursLevel
+		budget.AmountAlertLevel = amountLevel
+		if err := s.timeRepo.UpdateProjectBudget(&budget); err != nil {
+			log.Printf("Failed to update alert level of budget %s: %v", budget.ID, err)
+		}
+	}
+}
+
+// alertBudgetThreshold returns the highest threshold reached by percent and
+// notifies when it is above the last alerted level. The level drops again when
+// usage falls, so that crossing a threshold a second time alerts again.
+func (s *timeService) alertBudgetThreshold(project *domain.Project, status *domain.BudgetStatus, metric string, percent float64, lastLevel int) int {
+	level := 0
+	for _, threshold := range domain.BudgetAlertThresholds {
+		if percent >= float64(threshold) {
+			level = threshold
+		}
+	}
+
+	if level > lastLevel {
+		s.emitNotification(project.OrganizationID, nil, domain.NotificationBudgetThreshold, "project", &project.ID, map[string]interface{}{
+			"project_id":   project.ID,
+			"project_name": project.Name,
+			"task_id":      status.TaskID,
+			"budget_id":    status.ID,
+			"metric":       metric,
+			"threshold":    level,
+			"percent":      percent,
+		})
+	}
+	return level
+}
+
+func (s *timeService) validateBudgetScope(orgID, projectID uuid.UUID, taskID *uuid.UUID) error {
+	if _, err := s.getOrganizationProject(orgID, projectID); err != nil {
+		return err
+	}
+	if taskID != nil {
+		task, err := s.timeRepo.GetTask(*taskID)
+		if err != nil || task.ProjectID != projectID {
+			return apperrors.NewNotFoundError("task not found")
+		}
+	}
+	return nil
+}
+
+func (s *timeService) getProjectBudget(orgID, projectID, id uuid.UUID) (*domain.ProjectBudget, error) {
+	if _, err := s.getOrganizationProject(orgID, projectID); err != nil {
+		return nil, err
+	}
+	budget, err := s.timeRepo.GetProjectBudget(id)
+	if err != nil || budget.ProjectID != projectID {
+		return nil, apperrors.NewNotFoundError("budget not found")
+	}
+	return budget, nil
+}
+
+func applyProjectBudgetRequest(budget *domain.ProjectBudget, req *domain.ProjectBudgetRequest) {
+	budget.TaskID = req.TaskID
+	budget.BudgetHours = req.BudgetHours
+	budget.BudgetAmount = req.BudgetAmount
+	budget.Currency = req.Currency
+	budget.HardCap = req.HardCap
+}
+
+// budgetApplies reports whether a budget covers the entry: project budgets
+// cover every entry of the project, task budgets only the entries of the task.
+func budgetApplies(budget domain.ProjectBudget, timesheet *domain.Timesheet) bool {
+	return budget.TaskID == nil || sameTask(budget.TaskID, timesheet.TaskID)
+}
+
+func sameTask(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func budgetPercent(used, budget float64) float64 {
+	if budget <= 0 {
+		return 0
+	}
+	return math.Round(used/budget*10000) / 100
+}
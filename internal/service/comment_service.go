@@ -0,0 +1,199 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Axontik/comin-time-service/internal/domain"
+	apperrors "github.com/Axontik/comin-time-service/internal/errors"
+	"github.com/Axontik/comin-time-service/utils"
+	"github.com/google/uuid"
+)
+
+func (s *timeService) AddTimesheetComment(orgID, employeeID, id, authorID uuid.UUID, req *domain.TimesheetCommentRequest) (*domain.TimesheetComment, error) {
+	timesheet, err := s.employeeTimesheet(orgID, employeeID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	comment := &domain.TimesheetComment{
+		OrganizationID: timesheet.OrganizationID,
+		EmployeeID:     timesheet.EmployeeID,
+		TimesheetID:    &timesheet.ID,
+		AuthorID:       authorID,
+		Kind:           domain.CommentKindComment,
+		Body:           req.Body,
+	}
+	if err := s.createTimesheetComment(comment); err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+func (s *timeService) ListTimesheetComments(orgID, employeeID, id uuid.UUID) ([]domain.TimesheetComment, error) {
+	if _, err := s.employeeTimesheet(orgID, employeeID, id); err != nil {
+		return nil, err
+	}
+	return s.timeRepo.ListTimesheetComments(id)
+}
+
+// AddPeriodComment adds a comment to the employee's timesheet period (week)
+// containing periodStart.
+func (s *timeService) AddPeriodComment(orgID, employeeID, authorID uuid.UUID, periodStart time.Time, req *domain.TimesheetCommentRequest) (*domain.TimesheetComment, error) {
+	start, err := s.periodStart(orgID, employeeID, periodStart)
+	if err != nil {
+		return nil, err
+	}
+	comment := &domain.TimesheetComment{
+		OrganizationID: orgID,
+		EmployeeID:     employeeID,
+		PeriodStart:    &start,
+		AuthorID:       authorID,
+		Kind:           domain.CommentKindComment,
+		Body:           req.Body,
+	}
+	if err := s.createTimesheetComment(comment); err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+func (s *timeService) ListPeriodComments(orgID, employeeID uuid.UUID, periodStart time.Time) ([]domain.TimesheetComment, error) {
+	start, err := s.periodStart(orgID, employeeID, periodStart)
+	if err != nil {
+		return nil, err
+	}
+	return s.timeRepo.ListPeriodComments(orgID, employeeID, start)
+}
+
+// periodStart returns the first day of the employee's work week containing the date
+func (s *timeService) periodStart(orgID, employeeID uuid.UUID, date time.Time) (time.Time, error) {
+	policy, err := s.GetTimesheetPolicy(orgID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return s.employeeWorkWeek(policy, employeeID).StartOfWeek(date), nil
+}
+
+func (s *timeService) ListTimesheetHistory(orgID, employeeID, id uuid.UUID) ([]domain.TimesheetChange, error) {
+	if _, err := s.employeeTimesheet(orgID, employeeID, id); err != nil {
+		return nil, err
+	}
+	return s.timeRepo.ListTimesheetChanges(id)
+}
+
+// employeeTimesheet loads an entry of the employee, reporting entries of
+// other employees or organizations as not found
+func (s *timeService) employeeTimesheet(orgID, employeeID, id uuid.UUID) (*domain.Timesheet, error) {
+	timesheet, err := s.timeRepo.GetTimesheet(id)
+	if err != nil || timesheet.OrganizationID != orgID || timesheet.EmployeeID != employeeID {
+		return nil, apperrors.NewNotFoundError("timesheet not found")
+	}
+	return timesheet, nil
+}
+
+// createTimesheetComment stores the comment and lets the employee know when
+// somebody else commented on their time.
+func (s *timeService) createTimesheetComment(comment *domain.TimesheetComment) error {
+	if err := s.timeRepo.CreateTimesheetComment(comment); err != nil {
+		return err
+	}
+
+	if comment.AuthorID != comment.EmployeeID {
+		subjectType, subjectID := "timesheet", comment.TimesheetID
+		if subjectID == nil {
+			subjectType, subjectID = "timesheet_period", &comment.ID
+		}
+		s.emitNotification(comment.OrganizationID, &comment.EmployeeID, domain.NotificationTimesheetComment, subjectType, subjectID, map[string]interface{}{
+			"comment_id":   comment.ID,
+			"timesheet_id": comment.TimesheetID,
+			"period_start": comment.PeriodStart,
+			"author_id":    comment.AuthorID,
+			"kind":         comment.Kind,
+		})
+	}
+	return nil
+}
+
+// recordTimesheetChanges writes the fields that differ between the previous
+// and the current version of an entry to its history. Failures are logged
+// only, as the entry itself has already been saved.
+func (s *timeService) recordTimesheetChanges(previous, current *domain.Timesheet, changedBy uuid.UUID) {
+	before := timesheetHistoryFields(previous)
+	after := timesheetHistoryFields(current)
+
+	changes := []domain.TimesheetChange{}
+	now := time.Now()
+	for _, field := range timesheetHistoryFieldNames {
+		if before[field] == after[field] {
+			continue
+		}
+		changes = append(changes, domain.TimesheetChange{
+			TimesheetID: current.ID,
+			ChangedBy:   &changedBy,
+			Field:       field,
+			OldValue:    before[field],
+			NewValue:    after[field],
+			ChangedAt:   now,
+		})
+	}
+
+	if err := s.timeRepo.CreateTimesheetChanges(changes); err != nil {
+		log.Printf("Failed to record history of timesheet %s: %v", current.ID, err)
+	}
+}
+
+// recordTimesheetDeletion adds the deletion or restore of an entry to its history
+func (s *timeService) recordTimesheetDeletion(timesheet *domain.Timesheet, changedBy uuid.UUID, deleted bool) {
+	now := time.Now()
+	change := domain.TimesheetChange{
+		TimesheetID: timesheet.ID,
+		ChangedBy:   &changedBy,
+		Field:       "deleted_at",
+		NewValue:    now.Format(time.RFC3339),
+		ChangedAt:   now,
+	}
+	if !deleted {
+		change.OldValue = timesheet.DeletedAt.Time.Format(time.RFC3339)
+		change.NewValue = ""
+	}
+
+	if err := s.timeRepo.CreateTimesheetChanges([]domain.TimesheetChange{change}); err != nil {
+		log.Printf("Failed to record history of timesheet %s: %v", timesheet.ID, err)
+	}
+}
+
+var timesheetHistoryFieldNames = []string{
+	"project_id", "task_id", "description", "date", "hours",
+	"start_time", "end_time", "is_billable", "notes", "status",
+}
+
+func timesheetHistoryFields(timesheet *domain.Timesheet) map[string]string {
+	return map[string]string{
+		"project_id":  optionalID(timesheet.ProjectID),
+		"task_id":     optionalID(timesheet.TaskID),
+		"description": timesheet.Description,
+		"date":        utils.FormatDate(timesheet.Date),
+		"hours":       fmt.Sprintf("%.2f", timesheet.Hours),
+		"start_time":  optionalTime(timesheet.StartTime),
+		"end_time":    optionalTime(timesheet.EndTime),
+		"is_billable": fmt.Sprintf("%t", timesheet.IsBillable),
+		"notes":       timesheet.Notes,
+		"status":      timesheet.Status,
+	}
+}
+
+func optionalID(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
+
+func optionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,571 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/Axontik/comin-time-service/internal/domain"
+	apperrors "github.com/Axontik/comin-time-service/internal/errors"
+	"github.com/Axontik/comin-time-service/utils"
+	"github.com/google/uuid"
+)
+
+func (s *timeService) CreateComplianceRuleSet(orgID uuid.UUID, req *domain.ComplianceRuleSetRequest) (*domain.ComplianceRuleSet, error) {
+	ruleSet := &domain.ComplianceRuleSet{OrganizationID: orgID, IsActive: true}
+	if err := s.ensureUniqueJurisdiction(orgID, uuid.Nil, req.Jurisdiction); err != nil {
+		return nil, err
+	}
+	if err := applyComplianceRuleSetRequest(ruleSet, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.timeRepo.CreateComplianceRuleSet(ruleSet); err != nil {
+		return nil, err
+	}
+	return ruleSet, nil
+}
+
+func (s *timeService) UpdateComplianceRuleSet(orgID, id uuid.UUID, req *domain.ComplianceRuleSetRequest) (*domain.ComplianceRuleSet, error) {
+	ruleSet, err := s.getComplianceRuleSet(orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ensureUniqueJurisdiction(orgID, id, req.Jurisdiction); err != nil {
+		return nil, err
+	}
+	if err := applyComplianceRuleSetRequest(ruleSet, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.timeRepo.UpdateComplianceRuleSet(ruleSet); err != nil {
+		return nil, err
+	}
+	return ruleSet, nil
+}
+
+func (s *timeService) DeleteComplianceRuleSet(orgID, id uuid.UUID) error {
+	if _, err := s.getComplianceRuleSet(orgID, id); err != nil {
+		return err
+	}
+	return s.timeRepo.DeleteComplianceRuleSet(id)
+}
+
+func (s *timeService) ListComplianceRuleSets(orgID uuid.UUID) ([]domain.ComplianceRuleSet, error) {
+	return s.timeRepo.ListComplianceRuleSets(orgID)
+}
+
+// SetEmployeeJurisdiction assigns the employee to a jurisdiction; an empty
+// jurisdiction puts the employee back on the organization's default set.
+func (s *timeService) SetEmployeeJurisdiction(orgID, employeeID uuid.UUID, req *domain.EmployeeJurisdictionRequest) (*domain.EmployeeJurisdiction, error) {
+	if req.Jurisdiction == "" {
+		if err := s.timeRepo.DeleteEmployeeJurisdiction(orgID, employeeID); err != nil {
+			return nil, err
+		}
+		return &domain.EmployeeJurisdiction{OrganizationID: orgID, EmployeeID: employeeID}, nil
+	}
+
+	jurisdiction, err := s.timeRepo.GetEmployeeJurisdiction(orgID, employeeID)
+	if err != nil {
+		jurisdiction = &domain.EmployeeJurisdiction{OrganizationID: orgID, EmployeeID: employeeID}
+	}
+	jurisdiction.Jurisdiction = req.Jurisdiction
+	if err := s.timeRepo.SaveEmployeeJurisdiction(jurisdiction); err != nil {
+		return nil, err
+	}
+	return jurisdiction, nil
+}
+
+// GetComplianceReport evaluates the attendance and the pending and approved
+// timesheets in the range against each employee's rule set.
+func (s *timeService) GetComplianceReport(orgID uuid.UUID, filter *domain.ComplianceFilter) (*domain.ComplianceReport, error) {
+	ruleSetFor, err := s.complianceRuleSets(orgID)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := s.GetTimesheetPolicy(orgID)
+	if err != nil {
+		return nil, err
+	}
+	workWeekOf, err := s.workWeeks(policy)
+	if err != nil {
+		return nil, err
+	}
+
+	// Weekly totals and rest before the first day need the days before the
+	// range, back to the earliest week start of any employee
+	from := filter.StartDate.AddDate(0, 0, -7)
+
+	sources := map[string]map[uuid.UUID][]workPiece{
+		domain.ComplianceSourceAttendance: {},
+		domain.ComplianceSourceTimesheets: {},
+	}
+	attendances, err := s.timeRepo.ListAttendancesInRange(orgID, filter.EmployeeID, from, filter.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	for i := range attendances {
+		if piece, ok := attendanceWorkPiece(&attendances[i]); ok {
+			pieces := sources[domain.ComplianceSourceAttendance]
+			pieces[attendances[i].EmployeeID] = append(pieces[attendances[i].EmployeeID], piece)
+		}
+	}
+	for _, status := range []string{domain.TimesheetStatusPending, domain.TimesheetStatusApproved} {
+		timesheets, err := s.timeRepo.ListTimesheetsByStatus(orgID, filter.EmployeeID, from, filter.EndDate, status)
+		if err != nil {
+			return nil, err
+		}
+		for i := range timesheets {
+			pieces := sources[domain.ComplianceSourceTimesheets]
+			pieces[timesheets[i].EmployeeID] = append(pieces[timesheets[i].EmployeeID], timesheetWorkPiece(&timesheets[i]))
+		}
+	}
+
+	report := &domain.ComplianceReport{
+		StartDate:  filter.StartDate,
+		EndDate:    filter.EndDate,
+		Summary:    domain.ComplianceSummary{ByRule: map[string]int{}},
+		Violations: []domain.ComplianceViolation{},
+	}
+	for source, byEmployee := range sources {
+		for employeeID, pieces := range byEmployee {
+			ruleSet := ruleSetFor(employeeID)
+			if ruleSet == nil {
+				continue
+			}
+			sortWorkPieces(pieces)
+			for _, violation := range evaluateCompliance(ruleSet, pieces, workWeekOf(employeeID)) {
+				if !inDateRange(violation.Date, filter.StartDate, filter.EndDate) {
+					continue
+				}
+				if filter.Severity != "" && violation.Severity != filter.Severity {
+					continue
+				}
+				violation.EmployeeID = employeeID
+				violation.Source = source
+				report.Violations = append(report.Violations, violation)
+			}
+		}
+	}
+
+	sort.Slice(report.Violations, func(i, j int) bool {
+		a, b := report.Violations[i], report.Violations[j]
+		if !a.Date.Equal(b.Date) {
+			return a.Date.Before(b.Date)
+		}
+		if a.EmployeeID != b.EmployeeID {
+			return a.EmployeeID.String() < b.EmployeeID.String()
+		}
+		if a.Source != b.Source {
+			return a.Source < b.Source
+		}
+		return a.Rule < b.Rule
+	})
+	for _, violation := range report.Violations {
+		report.Summary.Total++
+		report.Summary.ByRule[violation.Rule]++
+		if violation.Severity == domain.SeverityCritical {
+			report.Summary.Critical++
+		} else {
+			report.Summary.Warnings++
+		}
+	}
+	return report, nil
+}
+
+// validateCheckInCompliance checks the rest since the last shift and the
+// hours already worked in the week before a check-in. Critical violations
+// block the check-in; the others are returned.
+func (s *timeService) validateCheckInCompliance(attendance *domain.Attendance) ([]domain.ComplianceViolation, error) {
+	ruleSetFor, err := s.complianceRuleSets(attendance.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	ruleSet := ruleSetFor(attendance.EmployeeID)
+	if ruleSet == nil {
+		return nil, nil
+	}
+
+	policy, err := s.GetTimesheetPolicy(attendance.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	workWeek := s.employeeWorkWeek(policy, attendance.EmployeeID)
+
+	weekStart := workWeek.StartOfWeek(attendance.Date)
+	attendances, err := s.timeRepo.ListAttendancesInRange(attendance.OrganizationID, &attendance.EmployeeID, weekStart.AddDate(0, 0, -1), attendance.Date)
+	if err != nil {
+		return nil, err
+	}
+	pieces := []workPiece{}
+	weekHours := 0.0
+	for i := range attendances {
+		if piece, ok := attendanceWorkPiece(&attendances[i]); ok {
+			pieces = append(pieces, piece)
+			if !piece.Date.Before(weekStart) {
+				weekHours += piece.Hours
+			}
+		}
+	}
+	pieces = append(pieces, workPiece{Date: attendance.Date, Start: attendance.CheckIn, End: attendance.CheckIn})
+	sortWorkPieces(pieces)
+
+	violations := []domain.ComplianceViolation{}
+	for _, violation := range evaluateCompliance(ruleSet, pieces, workWeek) {
+		if violation.Rule == domain.ComplianceRuleMinRest && violation.Date.Equal(attendance.Date) {
+			violations = append(violations, violation)
+		}
+	}
+	for _, rule := range ruleSet.Rules {
+		if rule.Kind == domain.ComplianceRuleMaxWeeklyHours && weekHours >= rule.Limit {
+			violations = append(violations, complianceViolation(ruleSet, rule, attendance.Date, weekHours,
+				fmt.Sprintf("the weekly maximum of %.2f hours is already reached", rule.Limit)))
+		}
+	}
+
+	critical := false
+	for i := range violations {
+		violations[i].EmployeeID = attendance.EmployeeID
+		violations[i].Source = domain.ComplianceSourceAttendance
+		critical = critical || violations[i].Severity == domain.SeverityCritical
+	}
+	if critical {
+		return nil, apperrors.NewBusinessRuleError(apperrors.ErrComplianceViolation, "check-in breaks the labor-law rules", violations)
+	}
+	return violations, nil
+}
+
+// checkOutViolations returns the break and weekly limits a completed shift
+// breaks. Check-outs are never blocked since the employee has already
+// stopped working, and failures are logged only.
+func (s *timeService) checkOutViolations(attendance *domain.Attendance) []domain.ComplianceViolation {
+	ruleSetFor, err := s.complianceRuleSets(attendance.OrganizationID)
+	if err != nil {
+		log.Printf("Failed to load compliance rules: %v", err)
+		return nil
+	}
+	ruleSet := ruleSetFor(attendance.EmployeeID)
+	if ruleSet == nil {
+		return nil
+	}
+
+	policy, err := s.GetTimesheetPolicy(attendance.OrganizationID)
+	if err != nil {
+		log.Printf("Failed to load timesheet policy for compliance: %v", err)
+		return nil
+	}
+	workWeek := s.employeeWorkWeek(policy, attendance.EmployeeID)
+
+	attendances, err := s.timeRepo.ListAttendancesInRange(attendance.OrganizationID, &attendance.EmployeeID, workWeek.StartOfWeek(attendance.Date), attendance.Date)
+	if err != nil {
+		log.Printf("Failed to load attendance for compliance: %v", err)
+		return nil
+	}
+	pieces := []workPiece{}
+	for i := range attendances {
+		if piece, ok := attendanceWorkPiece(&attendances[i]); ok {
+			pieces = append(pieces, piece)
+		}
+	}
+	sortWorkPieces(pieces)
+
+	violations := []domain.ComplianceViolation{}
+	for _, violation := range evaluateCompliance(ruleSet, pieces, workWeek) {
+		if violation.Rule != domain.ComplianceRuleMinRest && violation.Date.Equal(attendance.Date) {
+			violation.EmployeeID = attendance.EmployeeID
+			violation.Source = domain.ComplianceSourceAttendance
+			violations = append(violations, violation)
+		}
+	}
+	return violations
+}
+
+// validateTimesheetCompliance evaluates the employee's pending and approved
+// entries around the given one, as it will be saved. Critical violations
+// reject the entry; the others are returned as warnings.
+func (s *timeService) validateTimesheetCompliance(timesheet *domain.Timesheet) ([]domain.TimesheetWarning, error) {
+	ruleSetFor, err := s.complianceRuleSets(timesheet.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	ruleSet := ruleSetFor(timesheet.EmployeeID)
+	if ruleSet == nil {
+		return nil, nil
+	}
+
+	policy, err := s.GetTimesheetPolicy(timesheet.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	workWeek := s.employeeWorkWeek(policy, timesheet.EmployeeID)
+
+	weekStart := workWeek.StartOfWeek(timesheet.Date)
+	weekEnd := workWeek.EndOfWeek(timesheet.Date)
+	timesheets, err := s.timeRepo.ListTimesheets(timesheet.OrganizationID, timesheet.EmployeeID, weekStart.AddDate(0, 0, -1), weekEnd.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+	pieces := []workPiece{timesheetWorkPiece(timesheet)}
+	for i := range timesheets {
+		other := &timesheets[i]
+		if other.ID == timesheet.ID {
+			continue
+		}
+		if other.Status == domain.TimesheetStatusPending || other.Status == domain.TimesheetStatusApproved {
+			pieces = append(pieces, timesheetWorkPiece(other))
+		}
+	}
+	sortWorkPieces(pieces)
+
+	nextDay := timesheet.Date.AddDate(0, 0, 1)
+	violations := []domain.ComplianceViolation{}
+	critical := false
+	for _, violation := range evaluateCompliance(ruleSet, pieces, workWeek) {
+		relevant := false
+		switch violation.Rule {
+		case domain.ComplianceRuleMaxWeeklyHours:
+			relevant = inDateRange(violation.Date, weekStart, weekEnd)
+		case domain.ComplianceRuleMinRest:
+			relevant = violation.Date.Equal(timesheet.Date) || violation.Date.Equal(nextDay)
+		default:
+			relevant = violation.Date.Equal(timesheet.Date)
+		}
+		if !relevant {
+			continue
+		}
+		violation.EmployeeID = timesheet.EmployeeID
+		violation.Source = domain.ComplianceSourceTimesheets
+		violations = append(violations, violation)
+		critical = critical || violation.Severity == domain.SeverityCritical
+	}
+	if critical {
+		return nil, apperrors.NewBusinessRuleError(apperrors.ErrComplianceViolation, "timesheet breaks the labor-law rules", violations)
+	}
+
+	warnings := make([]domain.TimesheetWarning, 0, len(violations))
+	for _, violation := range violations {
+		warnings = append(warnings, domain.TimesheetWarning{
+			Rule:    violation.Rule,
+			Message: violation.Message,
+			Limit:   violation.Limit,
+			Actual:  violation.Actual,
+		})
+	}
+	return warnings, nil
+}
+
+// complianceRuleSets returns a lookup of the active rule set of an employee:
+// the set of the employee's jurisdiction, or else the organization default.
+func (s *timeService) complianceRuleSets(orgID uuid.UUID) (func(uuid.UUID) *domain.ComplianceRuleSet, error) {
+	ruleSets, err := s.timeRepo.ListComplianceRuleSets(orgID)
+	if err != nil {
+		return nil, err
+	}
+	jurisdictions, err := s.timeRepo.ListEmployeeJurisdictions(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	byJurisdiction := map[string]*domain.ComplianceRuleSet{}
+	for i := range ruleSets {
+		if ruleSets[i].IsActive {
+			byJurisdiction[ruleSets[i].Jurisdiction] = &ruleSets[i]
+		}
+	}
+	employees := map[uuid.UUID]string{}
+	for _, jurisdiction := range jurisdictions {
+		employees[jurisdiction.EmployeeID] = jurisdiction.Jurisdiction
+	}
+
+	return func(employeeID uuid.UUID) *domain.ComplianceRuleSet {
+		if jurisdiction, ok := employees[employeeID]; ok {
+			if ruleSet := byJurisdiction[jurisdiction]; ruleSet != nil {
+				return ruleSet
+			}
+		}
+		return byJurisdiction[""]
+	}, nil
+}
+
+// evaluateCompliance checks ordered work pieces of one employee against a
+// rule set. Weekly violations are dated on the day the maximum is crossed,
+// rest violations on the day of the shift that starts too early. Pieces
+// without clock times only count towards the weekly hours.
+//
+// Punched breaks long enough to count are taken to fall in the middle of
+// their piece, since only their length is kept.
+func evaluateCompliance(ruleSet *domain.ComplianceRuleSet, pieces []workPiece, workWeek utils.WorkWeek) []domain.ComplianceViolation {
+	violations := []domain.ComplianceViolation{}
+
+	for _, rule := range ruleSet.Rules {
+		switch rule.Kind {
+		case domain.ComplianceRuleMaxWeeklyHours:
+			var week string
+			var total float64
+			var crossed *time.Time
+			flush := func() {
+				if crossed != nil {
+					violations = append(violations, complianceViolation(ruleSet, rule, *crossed, roundHours(total),
+						fmt.Sprintf("hours worked in the week exceed the maximum of %.2f", rule.Limit)))
+				}
+			}
+			for _, piece := range pieces {
+				if start := utils.FormatDate(workWeek.StartOfWeek(piece.Date)); start != week {
+					flush()
+					week, total, crossed = start, 0, nil
+				}
+				total += piece.Hours
+				if crossed == nil && total > rule.Limit {
+					date := piece.Date
+					crossed = &date
+				}
+			}
+			flush()
+
+		case domain.ComplianceRuleMinRest:
+			var previous *workPiece
+			for i := range pieces {
+				piece := &pieces[i]
+				if piece.Start == nil || piece.End == nil {
+					continue
+				}
+				if previous != nil && !piece.Date.Equal(previous.Date) {
+					rest := piece.Start.Sub(*previous.End).Hours()
+					if rest < rule.Limit {
+						violations = append(violations, complianceViolation(ruleSet, rule, piece.Date, roundHours(rest),
+							fmt.Sprintf("rest between shifts is shorter than %.2f hours", rule.Limit)))
+					}
+				}
+				if previous == nil || !piece.Date.Equal(previous.Date) || piece.End.After(*previous.End) {
+					previous = piece
+				}
+			}
+
+		case domain.ComplianceRuleBreak:
+			minBreak := time.Duration(rule.BreakMinutes) * time.Minute
+			var day string
+			var start, end time.Time
+			longest := 0.0
+			flush := func(date time.Time) {
+				if longest > rule.Limit {
+					violations = append(violations, complianceViolation(ruleSet, rule, date, roundHours(longest),
+						fmt.Sprintf("worked more than %.2f hours without a break of %d minutes", rule.Limit, rule.BreakMinutes)))
+				}
+			}
+			var date time.Time
+			for _, piece := range splitAtBreak(pieces, minBreak) {
+				if piece.Start == nil || piece.End == nil {
+					continue
+				}
+				key := utils.FormatDate(piece.Date)
+				if key != day {
+					if day != "" {
+						flush(date)
+					}
+					day, date, longest = key, piece.Date, 0
+					start, end = *piece.Start, *piece.End
+				} else if piece.Start.Sub(end) >= minBreak {
+					start, end = *piece.Start, *piece.End
+				} else if piece.End.After(end) {
+					end = *piece.End
+				}
+				if stretch := end.Sub(start).Hours(); stretch > longest {
+					longest = stretch
+				}
+			}
+			if day != "" {
+				flush(date)
+			}
+		}
+	}
+	return violations
+}
+
+// splitAtBreak splits each timed piece with punched breaks of at least
+// minBreak into the stretches before and after a single break of that total
+// length in its middle.
+func splitAtBreak(pieces []workPiece, minBreak time.Duration) []workPiece {
+	split := make([]workPiece, 0, len(pieces))
+	for _, piece := range pieces {
+		pause := time.Duration(piece.BreakMinutes) * time.Minute
+		if piece.Start == nil || piece.End == nil || minBreak <= 0 || pause < minBreak || pause >= piece.End.Sub(*piece.Start) {
+			split = append(split, piece)
+			continue
+		}
+		half := (piece.End.Sub(*piece.Start) - pause) / 2
+		firstEnd := piece.Start.Add(half)
+		secondStart := firstEnd.Add(pause)
+		first, second := piece, piece
+		first.End, second.Start = &firstEnd, &secondStart
+		split = append(split, first, second)
+	}
+	return split
+}
+
+func complianceViolation(ruleSet *domain.ComplianceRuleSet, rule domain.ComplianceRule, date time.Time, actual float64, message string) domain.ComplianceViolation {
+	return domain.ComplianceViolation{
+		RuleSetID: ruleSet.ID,
+		Rule:      rule.Kind,
+		Severity:  rule.Severity,
+		Date:      date,
+		Message:   message,
+		Limit:     rule.Limit,
+		Actual:    actual,
+	}
+}
+
+func (s *timeService) getComplianceRuleSet(orgID, id uuid.UUID) (*domain.ComplianceRuleSet, error) {
+	ruleSet, err := s.timeRepo.GetComplianceRuleSet(id)
+	if err != nil || ruleSet.OrganizationID != orgID {
+		return nil, apperrors.NewNotFoundError("compliance rule set not found")
+	}
+	return ruleSet, nil
+}
+
+func (s *timeService) ensureUniqueJurisdiction(orgID, id uuid.UUID, jurisdiction string) error {
+	ruleSets, err := s.timeRepo.ListComplianceRuleSets(orgID)
+	if err != nil {
+		return err
+	}
+	for _, other := range ruleSets {
+		if other.ID != id && other.Jurisdiction == jurisdiction {
+			return apperrors.NewConflictError("a rule set already exists for this jurisdiction")
+		}
+	}
+	return nil
+}
+
+func applyComplianceRuleSetRequest(ruleSet *domain.ComplianceRuleSet, req *domain.ComplianceRuleSetRequest) error {
+	kinds := map[string]bool{}
+	rules := make([]domain.ComplianceRule, 0, len(req.Rules))
+	for _, rule := range req.Rules {
+		if kinds[rule.Kind] {
+			return apperrors.NewBadRequestError("a rule set can only have one " + rule.Kind + " rule")
+		}
+		kinds[rule.Kind] = true
+		if rule.Kind == domain.ComplianceRuleBreak && rule.BreakMinutes <= 0 {
+			return apperrors.NewBadRequestError("break_minutes is required for break rules")
+		}
+
+		severity := rule.Severity
+		if severity == "" {
+			severity = domain.SeverityWarning
+		}
+		rules = append(rules, domain.ComplianceRule{
+			RuleSetID:    ruleSet.ID,
+			Kind:         rule.Kind,
+			Limit:        rule.Limit,
+			BreakMinutes: rule.BreakMinutes,
+			Severity:     severity,
+		})
+	}
+
+	ruleSet.Name = req.Name
+	ruleSet.Jurisdiction = req.Jurisdiction
+	ruleSet.Rules = rules
+	if req.IsActive != nil {
+		ruleSet.IsActive = *req.IsActive
+	}
+	return nil
+}
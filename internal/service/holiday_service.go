@@ -0,0 +1,268 @@
+package service
+
+import (
+	"errors"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/Axontik/comin-time-service/internal/domain"
+	apperrors "github.com/Axontik/comin-time-service/internal/errors"
+	"github.com/Axontik/comin-time-service/pkg/ical"
+	"github.com/Axontik/comin-time-service/utils"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func (s *timeService) CreateHoliday(orgID uuid.UUID, req *domain.HolidayRequest) (*domain.Holiday, error) {
+	holiday := &domain.Holiday{OrganizationID: orgID, Source: domain.HolidaySourceManual}
+	if err := applyHolidayRequest(holiday, req); err != nil {
+		return nil, err
+	}
+	if err := s.ensureUniqueHoliday(holiday); err != nil {
+		return nil, err
+	}
+
+	if err := s.timeRepo.CreateHoliday(holiday); err != nil {
+		return nil, err
+	}
+	return holiday, nil
+}
+
+func (s *timeService) UpdateHoliday(orgID, id uuid.UUID, req *domain.HolidayRequest) (*domain.Holiday, error) {
+	holiday, err := s.getHoliday(orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyHolidayRequest(holiday, req); err != nil {
+		return nil, err
+	}
+	if err := s.ensureUniqueHoliday(holiday); err != nil {
+		return nil, err
+	}
+
+	if err := s.timeRepo.UpdateHoliday(holiday); err != nil {
+		return nil, err
+	}
+	return holiday, nil
+}
+
+func (s *timeService) DeleteHoliday(orgID, id uuid.UUID) error {
+	if _, err := s.getHoliday(orgID, id); err != nil {
+		return err
+	}
+	return s.timeRepo.DeleteHoliday(id)
+}
+
+// ListHolidayOccurrences returns the holidays falling in the range, with
+// recurring holidays expanded. When site is set only the organization-wide
+// holidays and those of the site are returned.
+func (s *timeService) ListHolidayOccurrences(orgID uuid.UUID, startDate, endDate time.Time, site string) ([]domain.HolidayOccurrence, error) {
+	if endDate.Before(startDate) {
+		return nil, apperrors.NewBadRequestError("end_date must not be before start_date")
+	}
+	if endDate.Sub(startDate) > maxReportRange {
+		return nil, apperrors.NewBadRequestError("date range must not exceed two years")
+	}
+
+	calendar, err := s.holidayCalendar(orgID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	occurrences := []domain.HolidayOccurrence{}
+	for day := startDate; !day.After(endDate); day = day.AddDate(0, 0, 1) {
+		for _, holiday := range calendar.holidays {
+			if !holidayFallsOn(holiday, day) || (site != "" && holiday.Site != "" && holiday.Site != site) {
+				continue
+			}
+			occurrences = append(occurrences, domain.HolidayOccurrence{
+				HolidayID: holiday.ID,
+				Date:      day,
+				Name:      holiday.Name,
+				Site:      holiday.Site,
+				HalfDay:   holiday.HalfDay,
+				Recurring: holiday.Recurring,
+			})
+		}
+	}
+	return occurrences, nil
+}
+
+// ImportHolidays reads the events of an iCalendar file into the calendar of
+// the site. Imported holidays already on an event's date are updated in
+// place, those entered by hand are kept; each day of a multi-day event becomes its own holiday.
+func (s *timeService) ImportHolidays(orgID uuid.UUID, site string, r io.Reader) (*domain.HolidayImportResult, error) {
+	events, err := ical.Parse(r)
+	if err != nil {
+		return nil, apperrors.NewBadRequestError("invalid calendar file: " + err.Error())
+	}
+	if len(events) == 0 {
+		return nil, apperrors.NewBadRequestError("calendar file contains no events")
+	}
+
+	result := &domain.HolidayImportResult{}
+	for _, event := range events {
+		days := event.Days()
+		for i, day := range days {
+			uid := event.UID
+			if len(days) > 1 {
+				uid = event.UID + "/" + utils.FormatDate(day)
+			}
+
+			holiday, err := s.timeRepo.FindHoliday(orgID, site, day)
+			if err != nil {
+				if !errors.Is(err, gorm.ErrRecordNotFound) {
+					return nil, err
+				}
+				holiday = nil
+			}
+			if holiday != nil && holiday.Source == domain.HolidaySourceManual {
+				// Holidays entered by hand take precedence over imports
+				continue
+			}
+
+			name := event.Summary
+			if len(days) > 1 {
+				name = event.Summary + " (day " + strconv.Itoa(i+1) + ")"
+			}
+			if holiday == nil {
+				holiday = &domain.Holiday{OrganizationID: orgID, Site: site, Date: day, Source: domain.HolidaySourceICS}
+				holiday.Name, holiday.Recurring, holiday.ExternalUID = name, event.Yearly, uid
+				if err := s.timeRepo.CreateHoliday(holiday); err != nil {
+					return nil, err
+				}
+				result.Created++
+				continue
+			}
+
+			holiday.Name, holiday.Recurring, holiday.ExternalUID = name, event.Yearly, uid
+			if err := s.timeRepo.UpdateHoliday(holiday); err != nil {
+				return nil, err
+			}
+			result.Updated++
+		}
+	}
+	return result, nil
+}
+
+// SetEmployeeSite assigns the employee to a site; an empty site leaves the
+// employee with the organization-wide holidays only.
+func (s *timeService) SetEmployeeSite(orgID, employeeID uuid.UUID, req *domain.EmployeeSiteRequest) (*domain.EmployeeSite, error) {
+	if req.Site == "" {
+		if err := s.timeRepo.DeleteEmployeeSite(orgID, employeeID); err != nil {
+			return nil, err
+		}
+		return &domain.EmployeeSite{OrganizationID: orgID, EmployeeID: employeeID}, nil
+	}
+
+	site, err := s.timeRepo.GetEmployeeSite(orgID, employeeID)
+	if err != nil {
+		site = &domain.EmployeeSite{OrganizationID: orgID, EmployeeID: employeeID}
+	}
+	site.Site = req.Site
+	if err := s.timeRepo.SaveEmployeeSite(site); err != nil {
+		return nil, err
+	}
+	return site, nil
+}
+
+// maxReportRange bounds the date range of holiday listings and reports
+const maxReportRange = 2 * 366 * 24 * time.Hour
+
+// holidayCalendar answers holiday lookups for the employees of an
+// organization, taking their sites into account
+type holidayCalendar struct {
+	holidays []domain.Holiday
+	sites    map[uuid.UUID]string
+}
+
+func (s *timeService) holidayCalendar(orgID uuid.UUID, start, end time.Time) (*holidayCalendar, error) {
+	holidays, err := s.timeRepo.ListHolidays(orgID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	sites, err := s.timeRepo.ListEmployeeSites(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	calendar := &holidayCalendar{holidays: holidays, sites: map[uuid.UUID]string{}}
+	for _, site := range sites {
+		calendar.sites[site.EmployeeID] = site.Site
+	}
+	return calendar, nil
+}
+
+// holidayOn reports whether the date is a holiday for employees of the site,
+// and whether it is only a half-day. A full-day holiday wins over a half-day
+// one on the same date.
+func (c *holidayCalendar) holidayOn(site string, date time.Time) (holiday, halfDay bool) {
+	for _, h := range c.holidays {
+		if (h.Site != "" && h.Site != site) || !holidayFallsOn(h, date) {
+			continue
+		}
+		if !h.HalfDay {
+			return true, false
+		}
+		holiday, halfDay = true, true
+	}
+	return holiday, halfDay
+}
+
+// forEmployee returns the holiday lookup of the employee's site
+func (c *holidayCalendar) forEmployee(employeeID uuid.UUID) func(time.Time) (bool, bool) {
+	site := c.sites[employeeID]
+	return func(date time.Time) (bool, bool) {
+		return c.holidayOn(site, date)
+	}
+}
+
+// fullDayHolidaysFor returns a predicate reporting whether a date is a
+// full-day holiday of the organization or the employee's site
+func (c *holidayCalendar) fullDayHolidaysFor(employeeID uuid.UUID) func(time.Time) bool {
+	holidayOn := c.forEmployee(employeeID)
+	return func(date time.Time) bool {
+		holiday, halfDay := holidayOn(date)
+		return holiday && !halfDay
+	}
+}
+
+// holidayFallsOn reports whether the holiday is observed on the date.
+// Recurring holidays repeat on the same month and day from their first year.
+func holidayFallsOn(holiday domain.Holiday, date time.Time) bool {
+	if !holiday.Recurring {
+		return utils.FormatDate(holiday.Date) == utils.FormatDate(date)
+	}
+	return holiday.Date.Month() == date.Month() && holiday.Date.Day() == date.Day() && date.Year() >= holiday.Date.Year()
+}
+
+func (s *timeService) getHoliday(orgID, id uuid.UUID) (*domain.Holiday, error) {
+	holiday, err := s.timeRepo.GetHoliday(id)
+	if err != nil || holiday.OrganizationID != orgID {
+		return nil, apperrors.NewNotFoundError("holiday not found")
+	}
+	return holiday, nil
+}
+
+func (s *timeService) ensureUniqueHoliday(holiday *domain.Holiday) error {
+	existing, err := s.timeRepo.FindHoliday(holiday.OrganizationID, holiday.Site, holiday.Date)
+	if err == nil && existing.ID != holiday.ID {
+		return apperrors.NewConflictError("a holiday already exists on " + utils.FormatDate(holiday.Date))
+	}
+	return nil
+}
+
+func applyHolidayRequest(holiday *domain.Holiday, req *domain.HolidayRequest) error {
+	date, err := utils.ParseDate(req.Date)
+	if err != nil {
+		return apperrors.NewBadRequestError("invalid date format, expected YYYY-MM-DD")
+	}
+
+	holiday.Date = date
+	holiday.Name = req.Name
+	holiday.Site = req.Site
+	holiday.Recurring = req.Recurring
+	holiday.HalfDay = req.HalfDay
+	return nil
+}
@@ -0,0 +1,325 @@
+package service
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Axontik/comin-time-service/internal/domain"
+	apperrors "github.com/Axontik/comin-time-service/internal/errors"
+	"github.com/Axontik/comin-time-service/internal/repository"
+	"github.com/Axontik/comin-time-service/pkg/pdf"
+	"github.com/Axontik/comin-time-service/utils"
+	"github.com/google/uuid"
+)
+
+// CreateInvoice drafts an invoice from the approved billable entries of a
+// project, or of every project of a client, and marks them as invoiced.
+func (s *timeService) CreateInvoice(orgID, userID uuid.UUID, req *domain.CreateInvoiceRequest) (*domain.Invoice, error) {
+	startDate, err := parseOptionalDate("start_date", req.StartDate)
+	if err != nil {
+		return nil, err
+	}
+	endDate, err := parseOptionalDate("end_date", req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	if endDate.Before(*startDate) {
+		return nil, apperrors.NewBadRequestError("end_date must not be before start_date")
+	}
+
+	projects, err := s.invoiceProjects(orgID, req)
+	if err != nil {
+		return nil, err
+	}
+	projectIDs := make([]uuid.UUID, 0, len(projects))
+	for _, project := range projects {
+		projectIDs = append(projectIDs, project.ID)
+	}
+
+	timesheets, err := s.timeRepo.ListInvoiceableTimesheets(orgID, projectIDs, *startDate, *endDate)
+	if err != nil {
+		return nil, err
+	}
+	if len(timesheets) == 0 {
+		return nil, apperrors.NewBusinessRuleError(apperrors.ErrNothingToInvoice, "there is no approved billable time to invoice in this period", nil)
+	}
+
+	currency := timesheets[0].Currency
+	for _, timesheet := range timesheets {
+		if timesheet.Currency != currency {
+			return nil, apperrors.NewBusinessRuleError(apperrors.ErrCurrencyMismatch, "the entries are billed in more than one currency", map[string]interface{}{
+				"currencies": []string{currency, timesheet.Currency},
+			})
+		}
+	}
+
+	groupBy := req.GroupBy
+	if groupBy == "" {
+		groupBy = domain.InvoiceGroupByTask
+	}
+
+	id := uuid.New()
+	invoice := &domain.Invoice{
+		Base:           domain.Base{ID: id},
+		OrganizationID: orgID,
+		Number:         fmt.Sprintf("DRAFT-%s-%s", time.Now().Format("20060102"), strings.ToUpper(id.String()[:8])),
+		ProjectID:      req.ProjectID,
+		Client:         req.Client,
+		PeriodStart:    *startDate,
+		PeriodEnd:      *endDate,
+		GroupBy:        groupBy,
+		Currency:       currency,
+		TaxRate:        req.TaxRate,
+		Status:         domain.InvoiceStatusDraft,
+		Notes:          req.Notes,
+		CreatedBy:      &userID,
+	}
+	if invoice.Client == "" && len(projects) == 1 {
+		invoice.Client = projects[0].Client
+	}
+
+	invoice.Lines, err = s.invoiceLines(projects, timesheets, groupBy)
+	if err != nil {
+		return nil, err
+	}
+
+	var subtotal float64
+	for _, line := range invoice.Lines {
+		subtotal += line.Amount
+	}
+	invoice.Subtotal = roundAmount(subtotal)
+	invoice.TaxAmount = roundAmount(invoice.Subtotal * invoice.TaxRate / 100)
+	invoice.Total = roundAmount(invoice.Subtotal + invoice.TaxAmount)
+
+	timesheetIDs := make([]uuid.UUID, 0, len(timesheets))
+	for _, timesheet := range timesheets {
+		timesheetIDs = append(timesheetIDs, timesheet.ID)
+	}
+	if err := s.timeRepo.CreateInvoice(invoice, timesheetIDs); err != nil {
+		if errors.Is(err, repository.ErrTimesheetsAlreadyInvoiced) {
+			return nil, apperrors.NewConflictError("some entries were invoiced concurrently, please retry")
+		}
+		return nil, err
+	}
+
+	return s.timeRepo.GetInvoice(invoice.ID)
+}
+
+func (s *timeService) GetInvoice(orgID, id uuid.UUID) (*domain.Invoice, error) {
+	invoice, err := s.timeRepo.GetInvoice(id)
+	if err != nil || invoice.OrganizationID != orgID {
+		return nil, apperrors.NewNotFoundError("invoice not found")
+	}
+	return invoice, nil
+}
+
+func (s *timeService) ListInvoices(orgID uuid.UUID) ([]domain.Invoice, error) {
+	return s.timeRepo.ListInvoices(orgID)
+}
+
+// DeleteInvoice discards a draft and releases its entries for billing
+func (s *timeService) DeleteInvoice(orgID, id uuid.UUID) error {
+	invoice, err := s.GetInvoice(orgID, id)
+	if err != nil {
+		return err
+	}
+	if invoice.Status != domain.InvoiceStatusDraft {
+		return apperrors.NewInvalidStatusError("only draft invoices can be deleted")
+	}
+	return s.timeRepo.DeleteInvoice(id)
+}
+
+func (s *timeService) ExportInvoice(orgID, id uuid.UUID, format string) (*domain.InvoiceExport, error) {
+	invoice, err := s.GetInvoice(orgID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &domain.InvoiceExport{Filename: invoice.Number + "." + format}
+	switch format {
+	case domain.InvoiceFormatJSON:
+		export.ContentType = "application/json"
+		export.Data, err = json.MarshalIndent(invoice, "", "  ")
+	case domain.InvoiceFormatCSV:
+		export.ContentType = "text/csv"
+		export.Data, err = invoiceCSV(invoice)
+	case domain.InvoiceFormatPDF:
+		export.ContentType = "application/pdf"
+		export.Data = invoicePDF(invoice)
+	default:
+		return nil, apperrors.NewBadRequestError("format must be one of json, csv or pdf")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return export, nil
+}
+
+func (s *timeService) invoiceProjects(orgID uuid.UUID, req *domain.CreateInvoiceRequest) ([]domain.Project, error) {
+	if req.ProjectID != nil {
+		project, err := s.getOrganizationProject(orgID, *req.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+		return []domain.Project{*project}, nil
+	}
+
+	if req.Client == "" {
+		return nil, apperrors.NewBadRequestError("project_id or client is required")
+	}
+
+	projects, err := s.timeRepo.ListProjects(orgID, "")
+	if err != nil {
+		return nil, err
+	}
+	clientProjects := []domain.Project{}
+	for _, project := range projects {
+		if strings.EqualFold(project.Client, req.Client) {
+			clientProjects = append(clientProjects, project)
+		}
+	}
+	if len(clientProjects) == 0 {
+		return nil, apperrors.NewNotFoundError("no projects found for client")
+	}
+	return clientProjects, nil
+}
+
+// invoiceLines groups the entries by project and task or employee. Entries
+// billed at different rates are kept on separate lines.
+func (s *timeService) invoiceLines(projects []domain.Project, timesheets []domain.Timesheet, groupBy string) ([]domain.InvoiceLine, error) {
+	projectNames := map[uuid.UUID]string{}
+	for _, project := range projects {
+		projectNames[project.ID] = project.Name
+	}
+
+	taskIDs := []uuid.UUID{}
+	for _, timesheet := range timesheets {
+		if timesheet.TaskID != nil {
+			taskIDs = append(taskIDs, *timesheet.TaskID)
+		}
+	}
+	tasks, err := s.timeRepo.ListTasksByIDs(taskIDs)
+	if err != nil {
+		return nil, err
+	}
+	taskNames := map[uuid.UUID]string{}
+	for _, task := range tasks {
+		taskNames[task.ID] = task.Name
+	}
+
+	lines := map[string]*domain.InvoiceLine{}
+	for _, timesheet := range timesheets {
+		line := domain.InvoiceLine{ProjectID: timesheet.ProjectID, Rate: timesheet.BillRate}
+		description := projectNames[*timesheet.ProjectID]
+		if groupBy == domain.InvoiceGroupByEmployee {
+			employeeID := timesheet.EmployeeID
+			line.EmployeeID = &employeeID
+			description += " - Employee " + employeeID.String()
+		} else if timesheet.TaskID != nil {
+			line.TaskID = timesheet.TaskID
+			description += " - " + taskNames[*timesheet.TaskID]
+		} else {
+			description += " - General"
+		}
+		line.Description = description
+
+		key := fmt.Sprintf("%s|%.2f", description, timesheet.BillRate)
+		if existing, ok := lines[key]; ok {
+			existing.Hours += timesheet.Hours
+			existing.Amount += timesheet.BillableAmount
+			existing.EntryCount++
+			continue
+		}
+		line.Hours = timesheet.Hours
+		line.Amount = timesheet.BillableAmount
+		line.EntryCount = 1
+		lines[key] = &line
+	}
+
+	result := make([]domain.InvoiceLine, 0, len(lines))
+	for _, line := range lines {
+		line.Amount = roundAmount(line.Amount)
+		result = append(result, *line)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Description != result[j].Description {
+			return result[i].Description < result[j].Description
+		}
+		return result[i].Rate < result[j].Rate
+	})
+	return result, nil
+}
+
+func invoiceCSV(invoice *domain.Invoice) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	rows := [][]string{{"description", "hours", "rate", "amount", "currency"}}
+	for _, line := range invoice.Lines {
+		rows = append(rows, []string{
+			line.Description,
+			fmt.Sprintf("%.2f", line.Hours),
+			fmt.Sprintf("%.2f", line.Rate),
+			fmt.Sprintf("%.2f", line.Amount),
+			invoice.Currency,
+		})
+	}
+	rows = append(rows,
+		[]string{"Subtotal", "", "", fmt.Sprintf("%.2f", invoice.Subtotal), invoice.Currency},
+		[]string{fmt.Sprintf("Tax (%.2f%%)", invoice.TaxRate), "", "", fmt.Sprintf("%.2f", invoice.TaxAmount), invoice.Currency},
+		[]string{"Total", "", "", fmt.Sprintf("%.2f", invoice.Total), invoice.Currency},
+	)
+
+	if err := writer.WriteAll(rows); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func invoicePDF(invoice *domain.Invoice) []byte {
+	doc := pdf.NewDocument()
+	doc.AddLine("Invoice "+invoice.Number, 16)
+	doc.AddLine("", 10)
+	if invoice.Client != "" {
+		doc.AddLine("Client: "+invoice.Client, 10)
+	}
+	doc.AddLine(fmt.Sprintf("Period: %s to %s", utils.FormatDate(invoice.PeriodStart), utils.FormatDate(invoice.PeriodEnd)), 10)
+	doc.AddLine("Status: "+invoice.Status, 10)
+	doc.AddLine("", 10)
+
+	doc.AddLine(fmt.Sprintf("%-45s %8s %10s %12s", "Description", "Hours", "Rate", "Amount"), 9)
+	doc.AddLine(strings.Repeat("-", 78), 9)
+	for _, line := range invoice.Lines {
+		description := line.Description
+		if len(description) > 45 {
+			description = description[:42] + "..."
+		}
+		doc.AddLine(fmt.Sprintf("%-45s %8.2f %10.2f %12.2f", description, line.Hours, line.Rate, line.Amount), 9)
+	}
+	doc.AddLine(strings.Repeat("-", 78), 9)
+	doc.AddLine(fmt.Sprintf("%65s %12.2f", "Subtotal", invoice.Subtotal), 9)
+	doc.AddLine(fmt.Sprintf("%65s %12.2f", fmt.Sprintf("Tax (%.2f%%)", invoice.TaxRate), invoice.TaxAmount), 9)
+	doc.AddLine(fmt.Sprintf("%65s %12.2f", "Total "+invoice.Currency, invoice.Total), 9)
+
+	if invoice.Notes != "" {
+		doc.AddLine("", 10)
+		doc.AddLine(invoice.Notes, 9)
+	}
+	return doc.Bytes()
+}
+
+// ensureNotInvoiced rejects changes to entries that have already been billed
+func ensureNotInvoiced(timesheet *domain.Timesheet) error {
+	if timesheet.InvoiceID == nil {
+		return nil
+	}
+	return apperrors.NewBusinessRuleError(apperrors.ErrTimesheetInvoiced, "invoiced timesheets cannot be changed", map[string]interface{}{
+		"invoice_id": timesheet.InvoiceID,
+	})
+}
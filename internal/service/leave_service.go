@@ -0,0 +1,716 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/Axontik/comin-time-service/internal/domain"
+	apperrors "github.com/Axontik/comin-time-service/internal/errors"
+	"github.com/Axontik/comin-time-service/utils"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func (s *timeService) CreateLeaveType(orgID uuid.UUID, req *domain.LeaveTypeRequest) (*domain.LeaveType, error) {
+	leaveType := &domain.LeaveType{OrganizationID: orgID}
+	applyLeaveTypeRequest(leaveType, req)
+
+	if err := s.timeRepo.CreateLeaveType(leaveType); err != nil {
+		return nil, err
+	}
+	return leaveType, nil
+}
+
+func (s *timeService) UpdateLeaveType(orgID, id uuid.UUID, req *domain.LeaveTypeRequest) (*domain.LeaveType, error) {
+	leaveType, err := s.getLeaveType(orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	applyLeaveTypeRequest(leaveType, req)
+
+	if err := s.timeRepo.UpdateLeaveType(leaveType); err != nil {
+		return nil, err
+	}
+	return leaveType, nil
+}
+
+// DeleteLeaveType removes a leave type that has never been requested; used
+// types can only be deactivated.
+func (s *timeService) DeleteLeaveType(orgID, id uuid.UUID) error {
+	if _, err := s.getLeaveType(orgID, id); err != nil {
+		return err
+	}
+	requests, err := s.timeRepo.CountLeaveRequestsByType(id)
+	if err != nil {
+		return err
+	}
+	if requests > 0 {
+		return apperrors.NewConflictError("leave type has requests; deactivate it instead")
+	}
+	return s.timeRepo.DeleteLeaveType(id)
+}
+
+func (s *timeService) ListLeaveTypes(orgID uuid.UUID) ([]domain.LeaveType, error) {
+	return s.timeRepo.ListLeaveTypes(orgID)
+}
+
+// CreateLeaveRequest submits a leave request for approval. The days it takes
+// are the working days of the employee in the range, and must be covered by
+// the balance net of other pending requests.
+func (s *timeService) CreateLeaveRequest(orgID, employeeID uuid.UUID, req *domain.CreateLeaveRequest) (*domain.LeaveRequest, error) {
+	leaveType, err := s.getLeaveType(orgID, req.LeaveTypeID)
+	if err != nil {
+		return nil, err
+	}
+	if !leaveType.IsActive {
+		return nil, apperrors.NewBadRequestError("leave type is not active")
+	}
+
+	request := &domain.LeaveRequest{
+		OrganizationID: orgID,
+		EmployeeID:     employeeID,
+		LeaveTypeID:    leaveType.ID,
+		HalfDay:        req.HalfDay,
+		Reason:         req.Reason,
+		Status:         domain.LeaveStatusPending,
+	}
+	if err := applyLeaveDates(request, req); err != nil {
+		return nil, err
+	}
+	if err := s.ensureRangeNotClosed(orgID, request.StartDate, request.EndDate); err != nil {
+		return nil, err
+	}
+	if err := s.ensureNoOverlappingLeave(request); err != nil {
+		return nil, err
+	}
+
+	days, err := s.leaveDays(request)
+	if err != nil {
+		return nil, err
+	}
+	for _, fraction := range days {
+		request.Days += fraction
+	}
+	if request.Days == 0 {
+		return nil, apperrors.NewBadRequestError("the requested dates contain no working days")
+	}
+
+	if leaveType.TrackBalance {
+		balance, err := s.leaveBalance(leaveType, employeeID, request.StartDate.Year())
+		if err != nil {
+			return nil, err
+		}
+		pending, err := s.pendingLeaveDays(balance)
+		if err != nil {
+			return nil, err
+		}
+		if err := ensureLeaveAvailable(balance, pending, request.Days); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.timeRepo.CreateLeaveRequest(request); err != nil {
+		return nil, err
+	}
+	s.notifyLeaveRequested(request)
+	return request, nil
+}
+
+func (s *timeService) ListLeaveRequests(orgID uuid.UUID, filter *domain.LeaveRequestFilter) ([]domain.LeaveRequest, error) {
+	return s.timeRepo.ListLeaveRequests(orgID, filter)
+}
+
+// ApproveLeaveRequest approves a pending request, takes its days from the
+// balance of the year each falls in and records the leave in attendance. Requests covering a day the
+// employee already has attendance for are refused, since the balance is
+// debited for every day of the request.
+func (s *timeService) ApproveLeaveRequest(orgID, id, reviewerID uuid.UUID, req *domain.ReviewLeaveRequest) (*domain.LeaveRequest, error) {
+	request, err := s.getLeaveRequest(orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	if request.Status != domain.LeaveStatusPending {
+		return nil, apperrors.NewInvalidStatusError("only pending leave requests can be approved")
+	}
+	if err := s.ensureRangeNotClosed(orgID, request.StartDate, request.EndDate); err != nil {
+		return nil, err
+	}
+	leaveType, err := s.getLeaveType(orgID, request.LeaveTypeID)
+	if err != nil {
+		return nil, err
+	}
+
+	days, err := s.leaveDays(request)
+	if err != nil {
+		return nil, err
+	}
+	balances := []domain.LeaveBalance{}
+	if leaveType.TrackBalance {
+		byYear := leaveDaysByYear(request, days)
+		for year := request.StartDate.Year(); year <= request.EndDate.Year(); year++ {
+			if byYear[year] == 0 {
+				continue
+			}
+			balance, err := s.leaveBalance(leaveType, request.EmployeeID, year)
+			if err != nil {
+				return nil, err
+			}
+			if err := ensureLeaveAvailable(balance, 0, byYear[year]); err != nil {
+				return nil, err
+			}
+			balance.Used += byYear[year]
+			balances = append(balances, *balance)
+		}
+	}
+
+	attendances := []domain.Attendance{}
+	for day := request.StartDate; !day.After(request.EndDate); day = day.AddDate(0, 0, 1) {
+		if days[utils.FormatDate(day)] == 0 {
+			continue
+		}
+		if _, err := s.timeRepo.GetAttendanceByDate(request.EmployeeID, day); err == nil {
+			return nil, apperrors.NewConflictError("employee already has attendance on " + utils.FormatDate(day))
+		}
+		status := domain.AttendanceStatusLeave
+		if request.HalfDay {
+			status = domain.AttendanceStatusHalfDay
+		}
+		attendances = append(attendances, domain.Attendance{
+			OrganizationID: orgID,
+			EmployeeID:     request.EmployeeID,
+			Date:           day,
+			Status:         status,
+			LeaveRequestID: &request.ID,
+		})
+	}
+
+	now := time.Now()
+	request.Status = domain.LeaveStatusApproved
+	request.ReviewedBy = &reviewerID
+	request.ReviewedAt = &now
+	request.ReviewNote = req.Note
+	if err := s.timeRepo.ApproveLeaveRequest(request, balances, attendances); err != nil {
+		return nil, err
+	}
+	s.notifyLeaveReviewed(request)
+	return request, nil
+}
+
+func (s *timeService) RejectLeaveRequest(orgID, id, reviewerID uuid.UUID, req *domain.ReviewLeaveRequest) (*domain.LeaveRequest, error) {
+	request, err := s.getLeaveRequest(orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	if request.Status != domain.LeaveStatusPending {
+		return nil, apperrors.NewInvalidStatusError("only pending leave requests can be rejected")
+	}
+
+	now := time.Now()
+	request.Status = domain.LeaveStatusRejected
+	request.ReviewedBy = &reviewerID
+	request.ReviewedAt = &now
+	request.ReviewNote = req.Note
+	if err := s.timeRepo.UpdateLeaveRequest(request); err != nil {
+		return nil, err
+	}
+	s.notifyLeaveReviewed(request)
+	return request, nil
+}
+
+// CancelLeaveRequest withdraws a pending or approved request. Cancelling
+// approved leave returns its days to the balance of the year each falls in
+// and removes it from attendance, unless its payroll period is closed.
+func (s *timeService) CancelLeaveRequest(orgID, employeeID, id uuid.UUID) (*domain.LeaveRequest, error) {
+	request, err := s.getLeaveRequest(orgID, id)
+	if err != nil || request.EmployeeID != employeeID {
+		return nil, apperrors.NewNotFoundError("leave request not found")
+	}
+
+	switch request.Status {
+	case domain.LeaveStatusPending:
+		request.Status = domain.LeaveStatusCancelled
+		if err := s.timeRepo.UpdateLeaveRequest(request); err != nil {
+			return nil, err
+		}
+		return request, nil
+	case domain.LeaveStatusApproved:
+	default:
+		return nil, apperrors.NewInvalidStatusError("only pending or approved leave requests can be cancelled")
+	}
+
+	if err := s.ensureRangeNotClosed(orgID, request.StartDate, request.EndDate); err != nil {
+		return nil, err
+	}
+	leaveType, err := s.getLeaveType(orgID, request.LeaveTypeID)
+	if err != nil {
+		return nil, err
+	}
+	balances := []domain.LeaveBalance{}
+	if leaveType.TrackBalance {
+		days, err := s.leaveDays(request)
+		if err != nil {
+			return nil, err
+		}
+		byYear := leaveDaysByYear(request, days)
+		for year := request.StartDate.Year(); year <= request.EndDate.Year(); year++ {
+			if byYear[year] == 0 {
+				continue
+			}
+			balance, err := s.leaveBalance(leaveType, request.EmployeeID, year)
+			if err != nil {
+				return nil, err
+			}
+			balance.Used -= byYear[year]
+			balances = append(balances, *balance)
+		}
+	}
+
+	request.Status = domain.LeaveStatusCancelled
+	if err := s.timeRepo.CancelLeaveRequest(request, balances); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// ListLeaveBalances returns the employee's balances of every active leave
+// type that tracks one, accrued up to the current month.
+func (s *timeService) ListLeaveBalances(orgID, employeeID uuid.UUID, year int) ([]domain.LeaveBalanceResponse, error) {
+	leaveTypes, err := s.timeRepo.ListLeaveTypes(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	balances := []domain.LeaveBalanceResponse{}
+	for i := range leaveTypes {
+		leaveType := &leaveTypes[i]
+		if !leaveType.IsActive || !leaveType.TrackBalance {
+			continue
+		}
+		balance, err := s.leaveBalance(leaveType, employeeID, year)
+		if err != nil {
+			return nil, err
+		}
+		response, err := s.leaveBalanceResponse(leaveType, balance)
+		if err != nil {
+			return nil, err
+		}
+		balances = append(balances, *response)
+	}
+	return balances, nil
+}
+
+// AdjustLeaveBalance adds days to (or, when negative, removes days from) an
+// employee's balance, e.g. for opening balances or corrections
+func (s *timeService) AdjustLeaveBalance(orgID, employeeID uuid.UUID, req *domain.LeaveBalanceAdjustmentRequest) (*domain.LeaveBalanceResponse, error) {
+	leaveType, err := s.getLeaveType(orgID, req.LeaveTypeID)
+	if err != nil {
+		return nil, err
+	}
+	if !leaveType.TrackBalance {
+		return nil, apperrors.NewBadRequestError("leave type does not track a balance")
+	}
+	year := req.Year
+	if year == 0 {
+		year = time.Now().Year()
+	}
+
+	balance, err := s.leaveBalance(leaveType, employeeID, year)
+	if err != nil {
+		return nil, err
+	}
+	balance.Adjustment += req.Days
+	if err := s.timeRepo.SaveLeaveBalance(balance); err != nil {
+		return nil, err
+	}
+	return s.leaveBalanceResponse(leaveType, balance)
+}
+
+// leaveBalance loads the employee's balance of the leave type for the year,
+// creating it with the capped carry-over of the previous year, and accrues
+// the months due. A first balance accrues from the current month on.
+func (s *timeService) leaveBalance(leaveType *domain.LeaveType, employeeID uuid.UUID, year int) (*domain.LeaveBalance, error) {
+	now := time.Now()
+	balance, err := s.timeRepo.GetLeaveBalance(employeeID, leaveType.ID, year)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+
+		balance = &domain.LeaveBalance{
+			OrganizationID: leaveType.OrganizationID,
+			EmployeeID:     employeeID,
+			LeaveTypeID:    leaveType.ID,
+			Year:           year,
+		}
+		previous, err := s.timeRepo.GetLatestLeaveBalance(employeeID, leaveType.ID, year)
+		switch {
+		case err == nil:
+			if accrueLeaveBalance(leaveType, previous, now) {
+				if err := s.timeRepo.SaveLeaveBalance(previous); err != nil {
+					return nil, err
+				}
+			}
+			if previous.Year == year-1 {
+				balance.CarriedOver = math.Max(0, leaveBalanceTotal(previous))
+				if leaveType.CarryOverCapDays != nil {
+					balance.CarriedOver = math.Min(balance.CarriedOver, *leaveType.CarryOverCapDays)
+				}
+			}
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			if year == now.Year() {
+				balance.AccruedMonths = int(now.Month()) - 1
+			}
+		default:
+			return nil, err
+		}
+
+		accrueLeaveBalance(leaveType, balance, now)
+		if err := s.timeRepo.SaveLeaveBalance(balance); err != nil {
+			return nil, err
+		}
+		return balance, nil
+	}
+
+	if accrueLeaveBalance(leaveType, balance, now) {
+		if err := s.timeRepo.SaveLeaveBalance(balance); err != nil {
+			return nil, err
+		}
+	}
+	return balance, nil
+}
+
+// accrueLeaveBalance credits the months of the balance's year that are due
+// by now and not yet accrued. It reports whether the balance changed.
+func accrueLeaveBalance(leaveType *domain.LeaveType, balance *domain.LeaveBalance, now time.Time) bool {
+	due := 0
+	switch {
+	case balance.Year < now.Year():
+		due = 12
+	case balance.Year == now.Year():
+		due = int(now.Month())
+	}
+	if due <= balance.AccruedMonths {
+		return false
+	}
+
+	balance.Accrued = roundHours(balance.Accrued + leaveType.AccrualDaysPerMonth*float64(due-balance.AccruedMonths))
+	balance.AccruedMonths = due
+	return true
+}
+
+// leaveBalanceTotal returns the days left in a balance
+func leaveBalanceTotal(balance *domain.LeaveBalance) float64 {
+	return roundHours(balance.CarriedOver + balance.Accrued + balance.Adjustment - balance.Used)
+}
+
+func (s *timeService) pendingLeaveDays(balance *domain.LeaveBalance) (float64, error) {
+	startDate := time.Date(balance.Year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	return s.timeRepo.SumPendingLeaveDays(balance.EmployeeID, balance.LeaveTypeID, startDate, startDate.AddDate(1, 0, -1))
+}
+
+func (s *timeService) leaveBalanceResponse(leaveType *domain.LeaveType, balance *domain.LeaveBalance) (*domain.LeaveBalanceResponse, error) {
+	pending, err := s.pendingLeaveDays(balance)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.LeaveBalanceResponse{
+		LeaveBalance:  *balance,
+		LeaveTypeName: leaveType.Name,
+		Pending:       pending,
+		Available:     roundHours(leaveBalanceTotal(balance) - pending),
+	}, nil
+}
+
+func ensureLeaveAvailable(balance *domain.LeaveBalance, pending, days float64) error {
+	available := roundHours(leaveBalanceTotal(balance) - pending)
+	if days <= available {
+		return nil
+	}
+	return apperrors.NewBusinessRuleError(apperrors.ErrInsufficientLeaveBalance, "not enough leave left for this request", map[string]interface{}{
+		"requested": days,
+		"available": available,
+		"pending":   pending,
+	})
+}
+
+// leaveDays returns the part of each day ("2006-01-02") of the request that
+// is taken as leave: nothing on weekends and holidays, half a day on
+// half-day holidays and half-day requests.
+func (s *timeService) leaveDays(request *domain.LeaveRequest) (map[string]float64, error) {
+	policy, err := s.GetTimesheetPolicy(request.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	calendar, err := s.holidayCalendar(request.OrganizationID, request.StartDate, request.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	workWeek := s.employeeWorkWeek(policy, request.EmployeeID)
+	holidayOn := calendar.forEmployee(request.EmployeeID)
+
+	days := map[string]float64{}
+	for day := request.StartDate; !day.After(request.EndDate); day = day.AddDate(0, 0, 1) {
+		days[utils.FormatDate(day)] = workingDayFraction(day, workWeek, holidayOn)
+		if request.HalfDay {
+			days[utils.FormatDate(day)] = math.Min(days[utils.FormatDate(day)], 0.5)
+		}
+	}
+	return days, nil
+}
+
+// leaveDaysByYear splits the request's days between the years they fall
+// in, in proportion to the leave taken on the days of each year. The split
+// always adds up to the request's days, so a cancellation returns what the
+// approval took even when holidays changed in between.
+func leaveDaysByYear(request *domain.LeaveRequest, days map[string]float64) map[int]float64 {
+	first, last := request.StartDate.Year(), request.EndDate.Year()
+	byYear := map[int]float64{}
+	if first == last {
+		byYear[first] = request.Days
+		return byYear
+	}
+
+	taken := map[int]float64{}
+	total := 0.0
+	for day, fraction := range days {
+		date, err := utils.ParseDate(day)
+		if err != nil {
+			continue
+		}
+		taken[date.Year()] += fraction
+		total += fraction
+	}
+	if total == 0 {
+		byYear[first] = request.Days
+		return byYear
+	}
+	remaining := request.Days
+	for year := first; year < last; year++ {
+		byYear[year] = roundHours(request.Days * taken[year] / total)
+		remaining -= byYear[year]
+	}
+	byYear[last] = roundHours(remaining)
+	return byYear
+}
+
+// workingDayFraction returns how much of the date is a working day
+func workingDayFraction(date time.Time, workWeek utils.WorkWeek, holidayOn func(time.Time) (bool, bool)) float64 {
+	if workWeek.IsWeekend(date) {
+		return 0
+	}
+	holiday, halfDay := holidayOn(date)
+	switch {
+	case holiday && halfDay:
+		return 0.5
+	case holiday:
+		return 0
+	}
+	return 1
+}
+
+// expectedHours returns the hours the employee is expected to work in the
+// range: the rostered hours on days with published shifts and the policy's
+// daily hours on other working days, less holidays and approved leave.
+func (s *timeService) expectedHours(policy *domain.TimesheetPolicy, employeeID uuid.UUID, startDate, endDate time.Time) (float64, error) {
+	calendar, err := s.holidayCalendar(policy.OrganizationID, startDate, endDate)
+	if err != nil {
+		return 0, err
+	}
+	leave, err := s.timeRepo.ListLeaveRequests(policy.OrganizationID, &domain.LeaveRequestFilter{
+		EmployeeID: &employeeID,
+		Status:     domain.LeaveStatusApproved,
+		StartDate:  startDate,
+		EndDate:    endDate,
+	})
+	if err != nil {
+		return 0, err
+	}
+	shifts, err := s.timeRepo.ListShifts(policy.OrganizationID, &domain.ShiftFilter{
+		EmployeeID:    &employeeID,
+		PublishedOnly: true,
+		StartDate:     startDate,
+		EndDate:       endDate,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	workWeek := s.employeeWorkWeek(policy, employeeID)
+	expected := expectedHoursIn(policy.ExpectedDailyHours, workWeek, calendar.forEmployee(employeeID), leave, rosteredHours(shifts)[employeeID], startDate, endDate)
+	return roundHours(expected), nil
+}
+
+// expectedHoursIn totals the expected hours of one employee over a range.
+// Rostered days expect their shift hours, even on holidays and weekends;
+// other days the daily hours on the working share of the day. Approved
+// leave takes the day off, or half the daily hours for a half day.
+func expectedHoursIn(dailyHours float64, workWeek utils.WorkWeek, holidayOn func(time.Time) (bool, bool), leave []domain.LeaveRequest, rostered map[string]float64, startDate, endDate time.Time) float64 {
+	total := 0.0
+	for day := startDate; !day.After(endDate); day = day.AddDate(0, 0, 1) {
+		hours, ok := rostered[utils.FormatDate(day)]
+		if !ok {
+			hours = workingDayFraction(day, workWeek, holidayOn) * dailyHours
+		}
+		for _, request := range leave {
+			if !inDateRange(day, request.StartDate, request.EndDate) {
+				continue
+			}
+			if request.HalfDay {
+				hours -= 0.5 * dailyHours
+			} else {
+				hours = 0
+			}
+		}
+		total += math.Max(0, hours)
+	}
+	return total
+}
+
+// rosteredHours totals the hours of assigned shifts per employee and date
+func rosteredHours(shifts []domain.Shift) map[uuid.UUID]map[string]float64 {
+	hours := map[uuid.UUID]map[string]float64{}
+	for _, shift := range shifts {
+		if shift.EmployeeID == nil {
+			continue
+		}
+		if hours[*shift.EmployeeID] == nil {
+			hours[*shift.EmployeeID] = map[string]float64{}
+		}
+		hours[*shift.EmployeeID][utils.FormatDate(shift.Date)] += shift.EndsAt.Sub(shift.StartsAt).Hours()
+	}
+	return hours
+}
+
+// ensureRangeNotClosed rejects ranges overlapping a closed payroll period
+func (s *timeService) ensureRangeNotClosed(orgID uuid.UUID, startDate, endDate time.Time) error {
+	periods, err := s.timeRepo.ListClosedPeriods(orgID, startDate, endDate)
+	if err != nil || len(periods) == 0 {
+		return err
+	}
+	date := startDate
+	if periods[0].StartDate.After(date) {
+		date = periods[0].StartDate
+	}
+	return s.ensurePeriodNotClosed(orgID, date)
+}
+
+// ensureNoOverlappingLeave rejects requests overlapping the employee's
+// pending or approved leave. Half days on opposite halves may share a date.
+func (s *timeService) ensureNoOverlappingLeave(request *domain.LeaveRequest) error {
+	existing, err := s.timeRepo.ListLeaveRequests(request.OrganizationID, &domain.LeaveRequestFilter{
+		EmployeeID: &request.EmployeeID,
+		StartDate:  request.StartDate,
+		EndDate:    request.EndDate,
+	})
+	if err != nil {
+		return err
+	}
+	for _, other := range existing {
+		if other.Status != domain.LeaveStatusPending && other.Status != domain.LeaveStatusApproved {
+			continue
+		}
+		if request.HalfDay && other.HalfDay && request.HalfDayPeriod != other.HalfDayPeriod {
+			continue
+		}
+		return apperrors.NewConflictError(fmt.Sprintf("leave already requested from %s to %s", utils.FormatDate(other.StartDate), utils.FormatDate(other.EndDate)))
+	}
+	return nil
+}
+
+func (s *timeService) notifyLeaveRequested(request *domain.LeaveRequest) {
+	if s.employees == nil {
+		return
+	}
+	managerID, err := s.employees.GetManagerID(request.OrganizationID, request.EmployeeID)
+	if err != nil {
+		log.Printf("Failed to resolve manager for employee %s: %v", request.EmployeeID, err)
+		return
+	}
+	s.emitNotification(request.OrganizationID, managerID, domain.NotificationLeaveRequested, "leave_request", &request.ID, map[string]interface{}{
+		"employee_id": request.EmployeeID,
+		"start_date":  utils.FormatDate(request.StartDate),
+		"end_date":    utils.FormatDate(request.EndDate),
+		"days":        request.Days,
+	})
+}
+
+func (s *timeService) notifyLeaveReviewed(request *domain.LeaveRequest) {
+	s.emitNotification(request.OrganizationID, &request.EmployeeID, domain.NotificationLeaveReviewed, "leave_request", &request.ID, map[string]interface{}{
+		"status":     request.Status,
+		"start_date": utils.FormatDate(request.StartDate),
+		"end_date":   utils.FormatDate(request.EndDate),
+		"note":       request.ReviewNote,
+	})
+}
+
+func (s *timeService) getLeaveType(orgID, id uuid.UUID) (*domain.LeaveType, error) {
+	leaveType, err := s.timeRepo.GetLeaveType(id)
+	if err != nil || leaveType.OrganizationID != orgID {
+		return nil, apperrors.NewNotFoundError("leave type not found")
+	}
+	return leaveType, nil
+}
+
+func (s *timeService) getLeaveRequest(orgID, id uuid.UUID) (*domain.LeaveRequest, error) {
+	request, err := s.timeRepo.GetLeaveRequest(id)
+	if err != nil || request.OrganizationID != orgID {
+		return nil, apperrors.NewNotFoundError("leave request not found")
+	}
+	return request, nil
+}
+
+// applyLeaveDates sets the request's range. Half days cover a single date,
+// in the morning unless stated otherwise.
+func applyLeaveDates(request *domain.LeaveRequest, req *domain.CreateLeaveRequest) error {
+	startDate, err := utils.ParseDate(req.StartDate)
+	if err != nil {
+		return apperrors.NewBadRequestError("start_date must be in YYYY-MM-DD format")
+	}
+	endDate := startDate
+	if req.EndDate != "" {
+		if endDate, err = utils.ParseDate(req.EndDate); err != nil {
+			return apperrors.NewBadRequestError("end_date must be in YYYY-MM-DD format")
+		}
+	}
+	if endDate.Before(startDate) {
+		return apperrors.NewBadRequestError("end_date must not be before start_date")
+	}
+	if endDate.Year() != startDate.Year() {
+		return apperrors.NewBadRequestError("leave requests must not span calendar years")
+	}
+
+	if req.HalfDay {
+		if !endDate.Equal(startDate) {
+			return apperrors.NewBadRequestError("half-day leave must start and end on the same date")
+		}
+		request.HalfDayPeriod = req.HalfDayPeriod
+		if request.HalfDayPeriod == "" {
+			request.HalfDayPeriod = domain.HalfDayMorning
+		}
+	}
+	request.StartDate = startDate
+	request.EndDate = endDate
+	return nil
+}
+
+func applyLeaveTypeRequest(leaveType *domain.LeaveType, req *domain.LeaveTypeRequest) {
+	leaveType.Name = req.Name
+	leaveType.Kind = req.Kind
+	leaveType.Paid = req.Kind != domain.LeaveKindUnpaid
+	if req.Paid != nil {
+		leaveType.Paid = *req.Paid
+	}
+	leaveType.TrackBalance = req.Kind != domain.LeaveKindUnpaid
+	if req.TrackBalance != nil {
+		leaveType.TrackBalance = *req.TrackBalance
+	}
+	leaveType.AccrualDaysPerMonth = req.AccrualDaysPerMonth
+	leaveType.CarryOverCapDays = req.CarryOverCapDays
+	leaveType.IsActive = true
+	if req.IsActive != nil {
+		leaveType.IsActive = *req.IsActive
+	}
+}
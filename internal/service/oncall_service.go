@@ -0,0 +1,411 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Axontik/comin-time-service/internal/domain"
+	apperrors "github.com/Axontik/comin-time-service/internal/errors"
+	"github.com/Axontik/comin-time-service/utils"
+	"github.com/google/uuid"
+)
+
+func (s *timeService) CreateOnCallRotation(orgID uuid.UUID, req *domain.OnCallRotationRequest) (*domain.OnCallRotation, error) {
+	rotation := &domain.OnCallRotation{OrganizationID: orgID}
+	if err := s.applyOnCallRotationRequest(rotation, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.timeRepo.CreateOnCallRotation(rotation); err != nil {
+		return nil, err
+	}
+	return rotation, nil
+}
+
+func (s *timeService) UpdateOnCallRotation(orgID, id uuid.UUID, req *domain.OnCallRotationRequest) (*domain.OnCallRotation, error) {
+	rotation, err := s.getOnCallRotation(orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyOnCallRotationRequest(rotation, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.timeRepo.UpdateOnCallRotation(rotation); err != nil {
+		return nil, err
+	}
+	return rotation, nil
+}
+
+// DeleteOnCallRotation removes a rotation without incidents; rotations with
+// incidents can only be deactivated.
+func (s *timeService) DeleteOnCallRotation(orgID, id uuid.UUID) error {
+	if _, err := s.getOnCallRotation(orgID, id); err != nil {
+		return err
+	}
+	incidents, err := s.timeRepo.CountOnCallIncidents(id)
+	if err != nil {
+		return err
+	}
+	if incidents > 0 {
+		return apperrors.NewConflictError("rotation has incidents; deactivate it instead")
+	}
+	return s.timeRepo.DeleteOnCallRotation(id)
+}
+
+func (s *timeService) ListOnCallRotations(orgID uuid.UUID, teamID *uuid.UUID) ([]domain.OnCallRotation, error) {
+	return s.timeRepo.ListOnCallRotations(orgID, teamID)
+}
+
+// GetOnCallSchedule returns who is on call for a rotation between two dates
+func (s *timeService) GetOnCallSchedule(orgID, id uuid.UUID, startDate, endDate time.Time) ([]domain.OnCallTurn, error) {
+	rotation, err := s.getOnCallRotation(orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := s.policyLocation(orgID)
+	if err != nil {
+		return nil, err
+	}
+	from, to := localDayBounds(startDate, endDate, loc)
+	return rotationTurns(rotation, loc, from, to), nil
+}
+
+// CreateOnCallIncident logs a call-out the employee worked while on call
+// for the rotation. Its time becomes call-out timesheet entries, split at
+// midnight, that are checked and approved like any other entry. The
+// incident and its entries are stored in one transaction.
+func (s *timeService) CreateOnCallIncident(orgID, employeeID uuid.UUID, req *domain.CreateOnCallIncidentRequest) (*domain.OnCallIncident, error) {
+	if !req.EndedAt.After(req.StartedAt) {
+		return nil, apperrors.NewBadRequestError("ended_at must be after started_at")
+	}
+	if req.EndedAt.Sub(req.StartedAt) > domain.MaxOnCallIncidentHours*time.Hour {
+		return nil, apperrors.NewBadRequestError(fmt.Sprintf("call-outs must not last longer than %d hours", domain.MaxOnCallIncidentHours))
+	}
+	if req.EndedAt.After(time.Now()) {
+		return nil, apperrors.NewBadRequestError("call-outs can only be logged once they have ended")
+	}
+	rotation, err := s.getOnCallRotation(orgID, req.RotationID)
+	if err != nil {
+		return nil, err
+	}
+	if !rotation.IsActive {
+		return nil, apperrors.NewInvalidStatusError("rotation is not active")
+	}
+	policy, err := s.GetTimesheetPolicy(orgID)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := time.LoadLocation(policy.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	turns := rotationTurns(rotation, loc, req.StartedAt, req.StartedAt.Add(time.Nanosecond))
+	if len(turns) == 0 || turns[0].EmployeeID != employeeID {
+		return nil, apperrors.NewBusinessRuleError(apperrors.ErrNotOnCall, "employee was not on call when the call-out started", map[string]interface{}{
+			"rotation_id": rotation.ID,
+			"started_at":  req.StartedAt,
+		})
+	}
+
+	incident := &domain.OnCallIncident{
+		OrganizationID: orgID,
+		RotationID:     rotation.ID,
+		EmployeeID:     employeeID,
+		ProjectID:      req.ProjectID,
+		StartedAt:      req.StartedAt,
+		EndedAt:        req.EndedAt,
+		Description:    req.Description,
+	}
+	err = s.inTransaction(func(tx *timeService) error {
+		if err := tx.timeRepo.CreateOnCallIncident(incident); err != nil {
+			return err
+		}
+		start := req.StartedAt.In(loc)
+		end := req.EndedAt.In(loc)
+		for start.Before(end) {
+			pieceEnd := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc).AddDate(0, 0, 1)
+			if pieceEnd.After(end) {
+				pieceEnd = end
+			}
+			timesheet, err := tx.callOutTimesheet(policy, incident, start, pieceEnd)
+			if err != nil {
+				return err
+			}
+			if err := tx.timeRepo.CreateTimesheet(timesheet); err != nil {
+				return err
+			}
+			incident.Timesheets = append(incident.Timesheets, *timesheet)
+			start = pieceEnd
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i := range incident.Timesheets {
+		s.assignInitialApprover(&incident.Timesheets[i])
+	}
+	if incident.ProjectID != nil {
+		s.checkBudgetAlerts(*incident.ProjectID, nil)
+	}
+	return incident, nil
+}
+
+func (s *timeService) ListOnCallIncidents(orgID uuid.UUID, filter *domain.OnCallIncidentFilter) ([]domain.OnCallIncident, error) {
+	return s.timeRepo.ListOnCallIncidents(orgID, filter)
+}
+
+// GetOnCallReport splits each employee's on-call time per rotation into
+// standby and call-out hours between two dates. Call-out hours are those of
+// non-rejected call-out entries; the rest of the scheduled turns is standby.
+// Turns are those of the active rotations as currently configured.
+func (s *timeService) GetOnCallReport(orgID uuid.UUID, employeeID *uuid.UUID, startDate, endDate time.Time) (*domain.OnCallReport, error) {
+	loc, err := s.policyLocation(orgID)
+	if err != nil {
+		return nil, err
+	}
+	from, to := localDayBounds(startDate, endDate, loc)
+	rotations, err := s.timeRepo.ListOnCallRotations(orgID, nil)
+	if err != nil {
+		return nil, err
+	}
+	incidents, err := s.timeRepo.ListOnCallIncidents(orgID, &domain.OnCallIncidentFilter{
+		EmployeeID: employeeID,
+		From:       from,
+		To:         to,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	teams := map[uuid.UUID]uuid.UUID{}
+	rows := map[[2]uuid.UUID]*domain.OnCallSummary{}
+	rowFor := func(employee, rotation uuid.UUID) *domain.OnCallSummary {
+		key := [2]uuid.UUID{employee, rotation}
+		if rows[key] == nil {
+			rows[key] = &domain.OnCallSummary{EmployeeID: employee, RotationID: rotation, TeamID: teams[rotation]}
+		}
+		return rows[key]
+	}
+
+	for i := range rotations {
+		rotation := &rotations[i]
+		teams[rotation.ID] = rotation.TeamID
+		if !rotation.IsActive {
+			continue
+		}
+		for _, turn := range rotationTurns(rotation, loc, from, to) {
+			if employeeID != nil && turn.EmployeeID != *employeeID {
+				continue
+			}
+			start, end := turn.StartsAt, turn.EndsAt
+			if start.Before(from) {
+				start = from
+			}
+			if end.After(to) {
+				end = to
+			}
+			rowFor(turn.EmployeeID, rotation.ID).OnCallHours += end.Sub(start).Hours()
+		}
+	}
+	for _, incident := range incidents {
+		row := rowFor(incident.EmployeeID, incident.RotationID)
+		if !incident.StartedAt.Before(from) {
+			row.CallOuts++
+		}
+		for _, timesheet := range incident.Timesheets {
+			if timesheet.Status != domain.TimesheetStatusRejected && inDateRange(timesheet.Date, startDate, endDate) {
+				row.CallOutHours += timesheet.Hours
+			}
+		}
+	}
+
+	report := &domain.OnCallReport{StartDate: startDate, EndDate: endDate, Rows: []domain.OnCallSummary{}}
+	for _, row := range rows {
+		row.StandbyHours = roundHours(row.OnCallHours - row.CallOutHours)
+		if row.StandbyHours < 0 {
+			row.StandbyHours = 0
+		}
+		row.OnCallHours = roundHours(row.OnCallHours)
+		row.CallOutHours = roundHours(row.CallOutHours)
+		report.Totals.OnCallHours += row.OnCallHours
+		report.Totals.StandbyHours += row.StandbyHours
+		report.Totals.CallOutHours += row.CallOutHours
+		report.Totals.CallOuts += row.CallOuts
+		report.Rows = append(report.Rows, *row)
+	}
+	sort.Slice(report.Rows, func(i, j int) bool {
+		if report.Rows[i].EmployeeID != report.Rows[j].EmployeeID {
+			return report.Rows[i].EmployeeID.String() < report.Rows[j].EmployeeID.String()
+		}
+		return report.Rows[i].RotationID.String() < report.Rows[j].RotationID.String()
+	})
+	report.Totals.OnCallHours = roundHours(report.Totals.OnCallHours)
+	report.Totals.StandbyHours = roundHours(report.Totals.StandbyHours)
+	report.Totals.CallOutHours = roundHours(report.Totals.CallOutHours)
+	return report, nil
+}
+
+// callOutTimesheet builds the call-out entry of an incident for a stretch
+// within a single day, checked like a regular pending entry against the
+// entries already stored, including the incident's earlier ones
+func (s *timeService) callOutTimesheet(policy *domain.TimesheetPolicy, incident *domain.OnCallIncident, start, end time.Time) (*domain.Timesheet, error) {
+	date, _ := utils.ParseDate(utils.FormatDate(start))
+	if err := s.validateTimesheetDate(policy, incident.EmployeeID, date); err != nil {
+		return nil, err
+	}
+
+	timesheet := &domain.Timesheet{
+		OrganizationID:   incident.OrganizationID,
+		EmployeeID:       incident.EmployeeID,
+		ProjectID:        incident.ProjectID,
+		Description:      incident.Description,
+		Date:             date,
+		Hours:            roundHours(end.Sub(start).Hours()),
+		StartTime:        &start,
+		EndTime:          &end,
+		Status:           domain.TimesheetStatusPending,
+		EntryType:        domain.TimesheetEntryCallOut,
+		OnCallIncidentID: &incident.ID,
+	}
+	project, err := s.validateTimesheetProject(timesheet)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyTimesheetRates(timesheet, project, nil); err != nil {
+		return nil, err
+	}
+	if err := s.validateProjectBudget(timesheet); err != nil {
+		return nil, err
+	}
+	if err := s.validateTimesheetOverlap(timesheet); err != nil {
+		return nil, err
+	}
+	if _, err := s.validateTimesheetHours(policy, timesheet, nil); err != nil {
+		return nil, err
+	}
+	if _, err := s.validateTimesheetCompliance(timesheet); err != nil {
+		return nil, err
+	}
+	return timesheet, nil
+}
+
+func (s *timeService) applyOnCallRotationRequest(rotation *domain.OnCallRotation, req *domain.OnCallRotationRequest) error {
+	if _, err := s.getTeam(rotation.OrganizationID, req.TeamID); err != nil {
+		return err
+	}
+	startDate, err := utils.ParseDate(req.StartDate)
+	if err != nil {
+		return apperrors.NewBadRequestError("start_date must be in YYYY-MM-DD format")
+	}
+	if _, ok := parseClock(req.HandoverTime); !ok {
+		return apperrors.NewBadRequestError("handover_time must be in HH:MM format")
+	}
+
+	memberships, err := s.timeRepo.ListEmployeeTeams(rotation.OrganizationID)
+	if err != nil {
+		return err
+	}
+	teamOf := map[uuid.UUID]uuid.UUID{}
+	for _, membership := range memberships {
+		teamOf[membership.EmployeeID] = membership.TeamID
+	}
+	members := []domain.OnCallRotationMember{}
+	seen := map[uuid.UUID]bool{}
+	for i, employeeID := range req.MemberIDs {
+		if seen[employeeID] {
+			return apperrors.NewBadRequestError("member_ids must not repeat")
+		}
+		seen[employeeID] = true
+		if teamOf[employeeID] != req.TeamID {
+			return apperrors.NewBadRequestError(fmt.Sprintf("employee %s is not a member of the team", employeeID))
+		}
+		members = append(members, domain.OnCallRotationMember{EmployeeID: employeeID, Position: i})
+	}
+
+	rotation.TeamID = req.TeamID
+	rotation.Name = req.Name
+	rotation.StartDate = startDate
+	rotation.HandoverTime = req.HandoverTime
+	rotation.PeriodDays = req.PeriodDays
+	if rotation.PeriodDays == 0 {
+		rotation.PeriodDays = domain.DefaultOnCallPeriodDays
+	}
+	rotation.Members = members
+	rotation.IsActive = true
+	if req.IsActive != nil {
+		rotation.IsActive = *req.IsActive
+	}
+	return nil
+}
+
+// rotationTurns returns the turns of a rotation overlapping [from, to).
+// Handovers keep their local time across daylight saving changes.
+func rotationTurns(rotation *domain.OnCallRotation, loc *time.Location, from, to time.Time) []domain.OnCallTurn {
+	turns := []domain.OnCallTurn{}
+	minutes, ok := parseClock(rotation.HandoverTime)
+	if len(rotation.Members) == 0 || !ok {
+		return turns
+	}
+	period := rotation.PeriodDays
+	if period <= 0 {
+		period = domain.DefaultOnCallPeriodDays
+	}
+	turnStart := func(i int) time.Time {
+		day := rotation.StartDate.AddDate(0, 0, i*period)
+		return time.Date(day.Year(), day.Month(), day.Day(), 0, minutes, 0, 0, loc)
+	}
+
+	i := 0
+	if first := turnStart(0); from.After(first) {
+		// Start a turn early to absorb daylight saving shifts
+		i = int(from.Sub(first)/(time.Duration(period)*24*time.Hour)) - 1
+		if i < 0 {
+			i = 0
+		}
+	}
+	for ; turnStart(i).Before(to); i++ {
+		start, end := turnStart(i), turnStart(i+1)
+		if !end.After(from) {
+			continue
+		}
+		turns = append(turns, domain.OnCallTurn{
+			RotationID: rotation.ID,
+			EmployeeID: rotation.Members[i%len(rotation.Members)].EmployeeID,
+			StartsAt:   start,
+			EndsAt:     end,
+		})
+	}
+	return turns
+}
+
+// localDayBounds returns the instants the dates of a range start and end at
+// in loc
+func localDayBounds(startDate, endDate time.Time, loc *time.Location) (time.Time, time.Time) {
+	from := time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, loc)
+	to := time.Date(endDate.Year(), endDate.Month(), endDate.Day(), 0, 0, 0, 0, loc).AddDate(0, 0, 1)
+	return from, to
+}
+
+// policyLocation returns the timezone of the organization's policy
+func (s *timeService) policyLocation(orgID uuid.UUID) (*time.Location, error) {
+	policy, err := s.GetTimesheetPolicy(orgID)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := time.LoadLocation(policy.Timezone)
+	if err != nil {
+		return time.UTC, nil
+	}
+	return loc, nil
+}
+
+func (s *timeService) getOnCallRotation(orgID, id uuid.UUID) (*domain.OnCallRotation, error) {
+	rotation, err := s.timeRepo.GetOnCallRotation(id)
+	if err != nil || rotation.OrganizationID != orgID {
+		return nil, apperrors.NewNotFoundError("on-call rotation not found")
+	}
+	return rotation, nil
+}
@@ -0,0 +1,581 @@
+package service
+
+import (
+	"log"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/Axontik/comin-time-service/internal/domain"
+	apperrors "github.com/Axontik/comin-time-service/internal/errors"
+	"github.com/Axontik/comin-time-service/utils"
+	"github.com/google/uuid"
+)
+
+func (s *timeService) CreateOvertimeRule(orgID uuid.UUID, req *domain.OvertimeRuleRequest) (*domain.OvertimeRule, error) {
+	if err := validateOvertimeRule(req); err != nil {
+		return nil, err
+	}
+
+	rule := &domain.OvertimeRule{OrganizationID: orgID, IsActive: true}
+	applyOvertimeRuleRequest(rule, req)
+
+	if err := s.timeRepo.CreateOvertimeRule(rule); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+func (s *timeService) UpdateOvertimeRule(orgID, id uuid.UUID, req *domain.OvertimeRuleRequest) (*domain.OvertimeRule, error) {
+	if err := validateOvertimeRule(req); err != nil {
+		return nil, err
+	}
+
+	rule, err := s.getOvertimeRule(orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	applyOvertimeRuleRequest(rule, req)
+
+	if err := s.timeRepo.UpdateOvertimeRule(rule); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+func (s *timeService) DeleteOvertimeRule(orgID, id uuid.UUID) error {
+	if _, err := s.getOvertimeRule(orgID, id); err != nil {
+		return err
+	}
+	return s.timeRepo.DeleteOvertimeRule(id)
+}
+
+func (s *timeService) ListOvertimeRules(orgID uuid.UUID) ([]domain.OvertimeRule, error) {
+	return s.timeRepo.ListOvertimeRules(orgID)
+}
+
+// RecomputeOvertime rebuilds the stored overtime of the whole weeks covering
+// the range, e.g. after the rules changed. Days in closed payroll periods
+// keep the overtime they were closed with.
+func (s *timeService) RecomputeOvertime(orgID uuid.UUID, req *domain.RecomputeOvertimeRequest) (*domain.RecomputeOvertimeResult, error) {
+	startDate, err := utils.ParseDate(req.StartDate)
+	if err != nil {
+		return nil, apperrors.NewBadRequestError("start_date must be in YYYY-MM-DD format")
+	}
+	endDate, err := utils.ParseDate(req.EndDate)
+	if err != nil {
+		return nil, apperrors.NewBadRequestError("end_date must be in YYYY-MM-DD format")
+	}
+	if endDate.Before(startDate) {
+		return nil, apperrors.NewBadRequestError("end_date must not be before start_date")
+	}
+
+	employees, err := s.computeOvertime(orgID, req.EmployeeID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.RecomputeOvertimeResult{Employees: employees}, nil
+}
+
+// GetEmployeeOvertime returns the employee's worked time in the range split
+// into regular and overtime hours, per day and in total.
+func (s *timeService) GetEmployeeOvertime(orgID, employeeID uuid.UUID, startDate, endDate time.Time) (*domain.OvertimeSummary, error) {
+	entries, err := s.timeRepo.ListOvertimeEntries(orgID, &employeeID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	return overtimeSummary(employeeID, startDate, endDate, entries, true), nil
+}
+
+// GetOvertimeReport returns the overtime totals of every employee of the
+// organization with worked time in the range.
+func (s *timeService) GetOvertimeReport(orgID uuid.UUID, startDate, endDate time.Time) ([]domain.OvertimeSummary, error) {
+	entries, err := s.timeRepo.ListOvertimeEntries(orgID, nil, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	byEmployee := map[uuid.UUID][]domain.OvertimeEntry{}
+	employeeIDs := []uuid.UUID{}
+	for _, entry := range entries {
+		if _, ok := byEmployee[entry.EmployeeID]; !ok {
+			employeeIDs = append(employeeIDs, entry.EmployeeID)
+		}
+		byEmployee[entry.EmployeeID] = append(byEmployee[entry.EmployeeID], entry)
+	}
+
+	summaries := make([]domain.OvertimeSummary, 0, len(employeeIDs))
+	for _, employeeID := range employeeIDs {
+		summaries = append(summaries, *overtimeSummary(employeeID, startDate, endDate, byEmployee[employeeID], false))
+	}
+	return summaries, nil
+}
+
+// recomputeOvertime refreshes the employee's overtime for the weeks of the
+// given dates when the organization computes overtime from source. The
+// entries are derived data, so failures are logged only; they can be rebuilt
+// with RecomputeOvertime.
+func (s *timeService) recomputeOvertime(orgID, employeeID uuid.UUID, source string, dates ...time.Time) {
+	policy, err := s.GetTimesheetPolicy(orgID)
+	if err != nil {
+		log.Printf("Failed to load policy for overtime: %v", err)
+		return
+	}
+	if policy.OvertimeSource != source {
+		return
+	}
+	for _, date := range dates {
+		if _, err := s.computeOvertime(orgID, &employeeID, date, date); err != nil {
+			log.Printf("Failed to recompute overtime of employee %s for %s: %v", employeeID, utils.FormatDate(date), err)
+		}
+	}
+}
+
+// computeOvertime classifies the worked time of the whole weeks covering
+// the range and replaces the stored entries of their open days. It returns
+// the number of employees recomputed.
+func (s *timeService) computeOvertime(orgID uuid.UUID, employeeID *uuid.UUID, startDate, endDate time.Time) (int, error) {
+	policy, err := s.GetTimesheetPolicy(orgID)
+	if err != nil {
+		return 0, err
+	}
+	loc, err := time.LoadLocation(policy.Timezone)
+	if err != nil {
+		return 0, err
+	}
+	rules, err := s.timeRepo.ListOvertimeRules(orgID)
+	if err != nil {
+		return 0, err
+	}
+
+	workWeekOf, err := s.workWeeks(policy)
+	if err != nil {
+		return 0, err
+	}
+
+	// Employees may start their weeks on different days; six days either
+	// side covers the whole weeks of all of them
+	weekStart := startDate.AddDate(0, 0, -6)
+	weekEnd := endDate.AddDate(0, 0, 6)
+
+	pieces, err := s.overtimeWorkPieces(policy, employeeID, weekStart, weekEnd)
+	if err != nil {
+		return 0, err
+	}
+
+	// Employees whose source data is gone still need their entries cleared
+	existing, err := s.timeRepo.ListOvertimeEntries(orgID, employeeID, weekStart, weekEnd)
+	if err != nil {
+		return 0, err
+	}
+	for _, entry := range existing {
+		if _, ok := pieces[entry.EmployeeID]; !ok {
+			pieces[entry.EmployeeID] = nil
+		}
+	}
+
+	calendar, err := s.holidayCalendar(orgID, weekStart, weekEnd)
+	if err != nil {
+		return 0, err
+	}
+	closed, err := s.timeRepo.ListClosedPeriods(orgID, weekStart, weekEnd)
+	if err != nil {
+		return 0, err
+	}
+
+	classifier := newOvertimeClassifier(rules, loc)
+	for id, employeePieces := range pieces {
+		workWeek := workWeekOf(id)
+		hours := classifier.classify(employeePieces, workWeek, calendar.forEmployee(id))
+
+		dates := []time.Time{}
+		for day := workWeek.StartOfWeek(startDate); !day.After(workWeek.EndOfWeek(endDate)); day = day.AddDate(0, 0, 1) {
+			inClosedPeriod := false
+			for _, period := range closed {
+				if inDateRange(day, period.StartDate, period.EndDate) {
+					inClosedPeriod = true
+					break
+				}
+			}
+			if !inClosedPeriod {
+				dates = append(dates, day)
+			}
+		}
+
+		entries := []domain.OvertimeEntry{}
+		for _, date := range dates {
+			for multiplier, value := range hours[utils.FormatDate(date)] {
+				if value = roundHours(value); value == 0 {
+					continue
+				}
+				entries = append(entries, domain.OvertimeEntry{
+					OrganizationID: orgID,
+					EmployeeID:     id,
+					Date:           date,
+					Multiplier:     multiplier,
+					Hours:          value,
+					Source:         policy.OvertimeSource,
+				})
+			}
+		}
+		if err := s.timeRepo.ReplaceOvertimeEntries(id, dates, entries); err != nil {
+			return 0, err
+		}
+	}
+	return len(pieces), nil
+}
+
+// workPiece is a stretch of worked time on a work date. Start and End are
+// nil when only the number of hours is known. BreakMinutes are the breaks
+// punched within it, whose times are not kept.
+type workPiece struct {
+	Date         time.Time
+	Start, End   *time.Time
+	Hours        float64
+	BreakMinutes int
+}
+
+// overtimeWorkPieces loads the worked time per employee from the source the
+// policy selects, ordered by date and start time.
+func (s *timeService) overtimeWorkPieces(policy *domain.TimesheetPolicy, employeeID *uuid.UUID, startDate, endDate time.Time) (map[uuid.UUID][]workPiece, error) {
+	pieces := map[uuid.UUID][]workPiece{}
+
+	if policy.OvertimeSource == domain.OvertimeSourceTimesheets {
+		timesheets, err := s.timeRepo.ListTimesheetsByStatus(policy.OrganizationID, employeeID, startDate, endDate, domain.TimesheetStatusApproved)
+		if err != nil {
+			return nil, err
+		}
+		for i := range timesheets {
+			pieces[timesheets[i].EmployeeID] = append(pieces[timesheets[i].EmployeeID], timesheetWorkPiece(&timesheets[i]))
+		}
+	} else {
+		attendances, err := s.timeRepo.ListAttendancesInRange(policy.OrganizationID, employeeID, startDate, endDate)
+		if err != nil {
+			return nil, err
+		}
+		for i := range attendances {
+			if piece, ok := attendanceWorkPiece(&attendances[i]); ok {
+				pieces[attendances[i].EmployeeID] = append(pieces[attendances[i].EmployeeID], piece)
+			}
+		}
+	}
+
+	for _, employeePieces := range pieces {
+		sortWorkPieces(employeePieces)
+	}
+	return pieces, nil
+}
+
+func timesheetWorkPiece(timesheet *domain.Timesheet) workPiece {
+	piece := workPiece{Date: timesheet.Date, Hours: timesheet.Hours}
+	if timesheet.StartTime != nil && timesheet.EndTime != nil {
+		piece.Start, piece.End = timesheet.StartTime, timesheet.EndTime
+	}
+	return piece
+}
+
+func attendanceWorkPiece(attendance *domain.Attendance) (workPiece, bool) {
+	hours, ok := attendanceHours(attendance)
+	if !ok {
+		return workPiece{}, false
+	}
+	piece := workPiece{Date: attendance.Date, Start: attendance.CheckIn, End: attendance.CheckOut, Hours: hours, BreakMinutes: attendance.BreakMinutes}
+	if attendance.RoundedCheckIn != nil && attendance.RoundedCheckOut != nil {
+		piece.Start, piece.End = attendance.RoundedCheckIn, attendance.RoundedCheckOut
+	}
+	return piece, true
+}
+
+// sortWorkPieces orders pieces by date and start time, untimed pieces first
+func sortWorkPieces(pieces []workPiece) {
+	sort.SliceStable(pieces, func(i, j int) bool {
+		a, b := pieces[i], pieces[j]
+		if !a.Date.Equal(b.Date) {
+			return a.Date.Before(b.Date)
+		}
+		if a.Start == nil || b.Start == nil {
+			return a.Start == nil && b.Start != nil
+		}
+		return a.Start.Before(*b.Start)
+	})
+}
+
+// nightWindow is a night rule's window in minutes after midnight
+type nightWindow struct {
+	start, end int
+	multiplier float64
+}
+
+func (w nightWindow) contains(t time.Time) bool {
+	minute := t.Hour()*60 + t.Minute()
+	if w.start < w.end {
+		return minute >= w.start && minute < w.end
+	}
+	return minute >= w.start || minute < w.end
+}
+
+type overtimeClassifier struct {
+	daily, weekly    []domain.OvertimeRule
+	weekend, holiday float64
+	nights           []nightWindow
+	loc              *time.Location
+}
+
+func newOvertimeClassifier(rules []domain.OvertimeRule, loc *time.Location) *overtimeClassifier {
+	c := &overtimeClassifier{weekend: 1, holiday: 1, loc: loc}
+	for _, rule := range rules {
+		if !rule.IsActive {
+			continue
+		}
+		switch rule.Kind {
+		case domain.OvertimeRuleDaily:
+			c.daily = append(c.daily, rule)
+		case domain.OvertimeRuleWeekly:
+			c.weekly = append(c.weekly, rule)
+		case domain.OvertimeRuleWeekend:
+			c.weekend = math.Max(c.weekend, rule.Multiplier)
+		case domain.OvertimeRuleHoliday:
+			c.holiday = math.Max(c.holiday, rule.Multiplier)
+		case domain.OvertimeRuleNight:
+			start, okStart := parseClock(rule.NightStart)
+			end, okEnd := parseClock(rule.NightEnd)
+			if okStart && okEnd {
+				c.nights = append(c.nights, nightWindow{start: start, end: end, multiplier: rule.Multiplier})
+			}
+		}
+	}
+	return c
+}
+
+// classify splits ordered work pieces into hours per date ("2006-01-02") and
+// multiplier. Daily and weekly thresholds are counted from the start of the
+// work date and of its week; each hour is paid at the highest applicable
+// multiplier. On half-day holidays the holiday multiplier only applies from
+// noon, and not at all to pieces without clock times.
+func (c *overtimeClassifier) classify(pieces []workPiece, workWeek utils.WorkWeek, holidayOn func(time.Time) (bool, bool)) map[string]map[float64]float64 {
+	const epsilon = 1e-9
+	result := map[string]map[float64]float64{}
+
+	var day, week string
+	var dayHours, weekHours float64
+	for _, piece := range pieces {
+		date := utils.FormatDate(piece.Date)
+		if date != day {
+			day, dayHours = date, 0
+		}
+		if start := utils.FormatDate(workWeek.StartOfWeek(piece.Date)); start != week {
+			week, weekHours = start, 0
+		}
+		if result[date] == nil {
+			result[date] = map[float64]float64{}
+		}
+
+		base := 1.0
+		if workWeek.IsWeekend(piece.Date) {
+			base = math.Max(base, c.weekend)
+		}
+		holiday, halfDay := holidayOn(piece.Date)
+		if holiday && !halfDay {
+			base = math.Max(base, c.holiday)
+		}
+
+		for _, segment := range c.segments(piece, holiday && halfDay) {
+			remaining := segment.hours
+			for remaining > epsilon {
+				step := remaining
+				multiplier := math.Max(base, segment.multiplier)
+				if segment.holiday {
+					multiplier = math.Max(multiplier, c.holiday)
+				}
+				for _, rule := range c.daily {
+					if dayHours+epsilon >= rule.ThresholdHours {
+						multiplier = math.Max(multiplier, rule.Multiplier)
+					} else if rule.ThresholdHours-dayHours < step {
+						step = rule.ThresholdHours - dayHours
+					}
+				}
+				for _, rule := range c.weekly {
+					if weekHours+epsilon >= rule.ThresholdHours {
+						multiplier = math.Max(multiplier, rule.Multiplier)
+					} else if rule.ThresholdHours-weekHours < step {
+						step = rule.ThresholdHours - weekHours
+					}
+				}
+
+				result[date][multiplier] += step
+				dayHours += step
+				weekHours += step
+				remaining -= step
+			}
+		}
+	}
+	return result
+}
+
+type workSegment struct {
+	hours      float64
+	multiplier float64
+	holiday    bool
+}
+
+// segments splits a piece at the boundaries of the night windows, and at
+// noon of a half-day holiday. The segments are scaled to the piece's hours,
+// which may differ from the clock times after rounding.
+func (c *overtimeClassifier) segments(piece workPiece, halfDayHoliday bool) []workSegment {
+	if piece.Start == nil || piece.End == nil || (len(c.nights) == 0 && !halfDayHoliday) || !piece.End.After(*piece.Start) {
+		return []workSegment{{hours: piece.Hours, multiplier: 1}}
+	}
+
+	start, end := piece.Start.In(c.loc), piece.End.In(c.loc)
+	cuts := []time.Time{start, end}
+	noon := time.Date(piece.Date.Year(), piece.Date.Month(), piece.Date.Day(), 12, 0, 0, 0, c.loc)
+	if halfDayHoliday && noon.After(start) && noon.Before(end) {
+		cuts = append(cuts, noon)
+	}
+	for day := start.AddDate(0, 0, -1); !day.After(end); day = day.AddDate(0, 0, 1) {
+		midnight := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, c.loc)
+		for _, window := range c.nights {
+			for _, minute := range []int{window.start, window.end} {
+				cut := midnight.Add(time.Duration(minute) * time.Minute)
+				if cut.After(start) && cut.Before(end) {
+					cuts = append(cuts, cut)
+				}
+			}
+		}
+	}
+	sort.Slice(cuts, func(i, j int) bool { return cuts[i].Before(cuts[j]) })
+
+	scale := piece.Hours / end.Sub(start).Hours()
+	segments := []workSegment{}
+	for i := 1; i < len(cuts); i++ {
+		length := cuts[i].Sub(cuts[i-1])
+		if length <= 0 {
+			continue
+		}
+		middle := cuts[i-1].Add(length / 2)
+		multiplier := 1.0
+		for _, window := range c.nights {
+			if window.contains(middle) {
+				multiplier = math.Max(multiplier, window.multiplier)
+			}
+		}
+		segments = append(segments, workSegment{
+			hours:      length.Hours() * scale,
+			multiplier: multiplier,
+			holiday:    halfDayHoliday && !middle.Before(noon),
+		})
+	}
+	return segments
+}
+
+// overtimeSummary totals stored entries per multiplier, and per day when
+// withDays is set.
+func overtimeSummary(employeeID uuid.UUID, startDate, endDate time.Time, entries []domain.OvertimeEntry, withDays bool) *domain.OvertimeSummary {
+	summary := &domain.OvertimeSummary{
+		EmployeeID: employeeID,
+		StartDate:  startDate,
+		EndDate:    endDate,
+	}
+
+	totals := map[float64]float64{}
+	var days []domain.OvertimeDay
+	for _, entry := range entries {
+		totals[entry.Multiplier] += entry.Hours
+		summary.TotalHours += entry.Hours
+		if entry.Multiplier == 1 {
+			summary.RegularHours += entry.Hours
+		} else {
+			summary.OvertimeHours += entry.Hours
+		}
+
+		if !withDays {
+			continue
+		}
+		if len(days) == 0 || !days[len(days)-1].Date.Equal(entry.Date) {
+			days = append(days, domain.OvertimeDay{Date: entry.Date})
+		}
+		current := &days[len(days)-1]
+		current.TotalHours = roundHours(current.TotalHours + entry.Hours)
+		current.Buckets = append(current.Buckets, overtimeBucket(entry.Multiplier, entry.Hours))
+	}
+
+	summary.TotalHours = roundHours(summary.TotalHours)
+	summary.RegularHours = roundHours(summary.RegularHours)
+	summary.OvertimeHours = roundHours(summary.OvertimeHours)
+	summary.Buckets = []domain.OvertimeBucket{}
+	for multiplier, hours := range totals {
+		summary.Buckets = append(summary.Buckets, overtimeBucket(multiplier, hours))
+	}
+	sort.Slice(summary.Buckets, func(i, j int) bool {
+		return summary.Buckets[i].Multiplier < summary.Buckets[j].Multiplier
+	})
+	if withDays {
+		summary.Days = days
+		if summary.Days == nil {
+			summary.Days = []domain.OvertimeDay{}
+		}
+	}
+	return summary
+}
+
+func overtimeBucket(multiplier, hours float64) domain.OvertimeBucket {
+	label := domain.OvertimeLabelRegular
+	if multiplier != 1 {
+		label = "ot_" + strconv.FormatFloat(multiplier, 'f', -1, 64)
+	}
+	return domain.OvertimeBucket{Label: label, Multiplier: multiplier, Hours: roundHours(hours)}
+}
+
+func (s *timeService) getOvertimeRule(orgID, id uuid.UUID) (*domain.OvertimeRule, error) {
+	rule, err := s.timeRepo.GetOvertimeRule(id)
+	if err != nil || rule.OrganizationID != orgID {
+		return nil, apperrors.NewNotFoundError("overtime rule not found")
+	}
+	return rule, nil
+}
+
+func validateOvertimeRule(req *domain.OvertimeRuleRequest) error {
+	switch req.Kind {
+	case domain.OvertimeRuleDaily, domain.OvertimeRuleWeekly:
+		if req.ThresholdHours <= 0 {
+			return apperrors.NewBadRequestError("threshold_hours is required for " + req.Kind + " rules")
+		}
+	case domain.OvertimeRuleNight:
+		start, okStart := parseClock(req.NightStart)
+		end, okEnd := parseClock(req.NightEnd)
+		if !okStart || !okEnd {
+			return apperrors.NewBadRequestError("night_start and night_end must be in HH:MM format")
+		}
+		if start == end {
+			return apperrors.NewBadRequestError("night_start and night_end must differ")
+		}
+	}
+	return nil
+}
+
+func applyOvertimeRuleRequest(rule *domain.OvertimeRule, req *domain.OvertimeRuleRequest) {
+	rule.Name = req.Name
+	rule.Kind = req.Kind
+	rule.ThresholdHours = 0
+	rule.NightStart, rule.NightEnd = "", ""
+	switch req.Kind {
+	case domain.OvertimeRuleDaily, domain.OvertimeRuleWeekly:
+		rule.ThresholdHours = req.ThresholdHours
+	case domain.OvertimeRuleNight:
+		rule.NightStart, rule.NightEnd = req.NightStart, req.NightEnd
+	}
+	rule.Multiplier = req.Multiplier
+	if req.IsActive != nil {
+		rule.IsActive = *req.IsActive
+	}
+}
+
+// parseClock parses an "HH:MM" time of day into minutes after midnight
+func parseClock(value string) (int, bool) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, false
+	}
+	return t.Hour()*60 + t.Minute(), true
+}
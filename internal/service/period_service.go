@@ -0,0 +1,196 @@
+package service
+
+import (
+	"time"
+
+	"github.com/Axontik/comin-time-service/internal/domain"
+	apperrors "github.com/Axontik/comin-time-service/internal/errors"
+	"github.com/Axontik/comin-time-service/utils"
+	"github.com/google/uuid"
+)
+
+// ClosePayrollPeriod locks attendance and timesheets in the date range. It
+// refuses to run while submitted timesheets in the range are still pending.
+// Closing the exact range of a reopened period closes that period again.
+func (s *timeService) ClosePayrollPeriod(orgID, userID uuid.UUID, req *domain.ClosePeriodRequest) (*domain.PayrollPeriod, error) {
+	startDate, err := parseOptionalDate("start_date", req.StartDate)
+	if err != nil {
+		return nil, err
+	}
+	endDate, err := parseOptionalDate("end_date", req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	if endDate.Before(*startDate) {
+		return nil, apperrors.NewBadRequestError("end_date must not be before start_date")
+	}
+
+	closed, err := s.timeRepo.ListClosedPeriods(orgID, *startDate, *endDate)
+	if err != nil {
+		return nil, err
+	}
+	if len(closed) > 0 {
+		return nil, apperrors.NewConflictError("the range overlaps a closed period")
+	}
+
+	if err := s.ensureNoPendingTimesheets(orgID, *startDate, *endDate); err != nil {
+		return nil, err
+	}
+
+	periods, err := s.timeRepo.ListPayrollPeriods(orgID)
+	if err != nil {
+		return nil, err
+	}
+	var period *domain.PayrollPeriod
+	for i := range periods {
+		if periods[i].StartDate.Equal(*startDate) && periods[i].EndDate.Equal(*endDate) {
+			period = &periods[i]
+			break
+		}
+	}
+
+	now := time.Now()
+	if period == nil {
+		period = &domain.PayrollPeriod{
+			OrganizationID: orgID,
+			StartDate:      *startDate,
+			EndDate:        *endDate,
+			Status:         domain.PeriodStatusClosed,
+			ClosedBy:       userID,
+			ClosedAt:       now,
+		}
+		if err := s.timeRepo.CreatePayrollPeriod(period); err != nil {
+			return nil, err
+		}
+	} else {
+		period.Status = domain.PeriodStatusClosed
+		period.ClosedBy = userID
+		period.ClosedAt = now
+		if err := s.timeRepo.UpdatePayrollPeriod(period); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.auditPayrollPeriod(period.ID, domain.PeriodActionClose, userID, req.Reason); err != nil {
+		return nil, err
+	}
+	return period, nil
+}
+
+// ReopenPayrollPeriod unlocks a closed period; the reason is kept on the
+// period and in its audit trail.
+func (s *timeService) ReopenPayrollPeriod(orgID, id, userID uuid.UUID, req *domain.ReopenPeriodRequest) (*domain.PayrollPeriod, error) {
+	period, err := s.getPayrollPeriod(orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	if period.Status != domain.PeriodStatusClosed {
+		return nil, apperrors.NewInvalidStatusError("only closed periods can be reopened")
+	}
+
+	now := time.Now()
+	period.Status = domain.PeriodStatusReopened
+	period.ReopenedBy = &userID
+	period.ReopenedAt = &now
+	period.ReopenReason = req.Reason
+	if err := s.timeRepo.UpdatePayrollPeriod(period); err != nil {
+		return nil, err
+	}
+
+	if err := s.auditPayrollPeriod(period.ID, domain.PeriodActionReopen, userID, req.Reason); err != nil {
+		return nil, err
+	}
+	return period, nil
+}
+
+func (s *timeService) ListPayrollPeriods(orgID uuid.UUID) ([]domain.PayrollPeriod, error) {
+	return s.timeRepo.ListPayrollPeriods(orgID)
+}
+
+func (s *timeService) ListPayrollPeriodEvents(orgID, id uuid.UUID) ([]domain.PayrollPeriodEvent, error) {
+	if _, err := s.getPayrollPeriod(orgID, id); err != nil {
+		return nil, err
+	}
+	return s.timeRepo.ListPayrollPeriodEvents(id)
+}
+
+// ensurePeriodNotClosed rejects changes dated inside a closed payroll period
+func (s *timeService) ensurePeriodNotClosed(orgID uuid.UUID, date time.Time) error {
+	periods, err := s.timeRepo.ListClosedPeriods(orgID, date, date)
+	if err != nil {
+		return err
+	}
+	if len(periods) == 0 {
+		return nil
+	}
+
+	period := periods[0]
+	return apperrors.NewBusinessRuleError(apperrors.ErrPeriodClosed, "the payroll period containing this date is closed", map[string]interface{}{
+		"rule":       "payroll_period",
+		"date":       utils.FormatDate(date),
+		"period_id":  period.ID,
+		"start_date": utils.FormatDate(period.StartDate),
+		"end_date":   utils.FormatDate(period.EndDate),
+	})
+}
+
+// ensureTimesheetEditable rejects changes to approved entries unless the
+// payroll period containing them was explicitly reopened for corrections
+func (s *timeService) ensureTimesheetEditable(timesheet *domain.Timesheet) error {
+	if timesheet.Status != domain.TimesheetStatusApproved {
+		return nil
+	}
+	reopened, err := s.inReopenedPeriod(timesheet.OrganizationID, timesheet.Date)
+	if err != nil {
+		return err
+	}
+	if !reopened {
+		return apperrors.NewInvalidStatusError("approved timesheets can only be changed once their payroll period is reopened")
+	}
+	return nil
+}
+
+// inReopenedPeriod reports whether the date falls in a payroll period
+// reopened for corrections
+func (s *timeService) inReopenedPeriod(orgID uuid.UUID, date time.Time) (bool, error) {
+	periods, err := s.timeRepo.ListPayrollPeriods(orgID)
+	if err != nil {
+		return false, err
+	}
+	for _, period := range periods {
+		if period.Status == domain.PeriodStatusReopened && inDateRange(date, period.StartDate, period.EndDate) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *timeService) ensureNoPendingTimesheets(orgID uuid.UUID, startDate, endDate time.Time) error {
+	pending, err := s.timeRepo.CountOrganizationTimesheetsByStatus(orgID, startDate, endDate, domain.TimesheetStatusPending)
+	if err != nil {
+		return err
+	}
+	if pending > 0 {
+		return apperrors.NewBusinessRuleError(apperrors.ErrPendingItems, "timesheets in the period are still pending approval", map[string]interface{}{
+			"pending_timesheets": pending,
+		})
+	}
+	return nil
+}
+
+func (s *timeService) auditPayrollPeriod(periodID uuid.UUID, action string, actorID uuid.UUID, reason string) error {
+	return s.timeRepo.CreatePayrollPeriodEvent(&domain.PayrollPeriodEvent{
+		PeriodID: periodID,
+		Action:   action,
+		ActorID:  actorID,
+		Reason:   reason,
+	})
+}
+
+func (s *timeService) getPayrollPeriod(orgID, id uuid.UUID) (*domain.PayrollPeriod, error) {
+	period, err := s.timeRepo.GetPayrollPeriod(id)
+	if err != nil || period.OrganizationID != orgID {
+		return nil, apperrors.NewNotFoundError("payroll period not found")
+	}
+	return period, nil
+}
@@ -0,0 +1,485 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Axontik/comin-time-service/internal/domain"
+	apperrors "github.com/Axontik/comin-time-service/internal/errors"
+	"github.com/Axontik/comin-time-service/utils"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// GetTimesheetPolicy returns the organization's policy, or the defaults when none is configured
+func (s *timeService) GetTimesheetPolicy(orgID uuid.UUID) (*domain.TimesheetPolicy, error) {
+	policy, err := s.timeRepo.GetTimesheetPolicy(orgID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.DefaultTimesheetPolicy(orgID), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// UpdateTimesheetPolicy changes the policy fields set in the request,
+// starting from the defaults for organizations without a policy
+func (s *timeService) UpdateTimesheetPolicy(orgID uuid.UUID, req *domain.TimesheetPolicyRequest) (*domain.TimesheetPolicy, error) {
+	policy, err := s.GetTimesheetPolicy(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.LockedUntil != nil {
+		policy.LockedUntil = nil
+		if *req.LockedUntil != "" {
+			date, err := utils.ParseDate(*req.LockedUntil)
+			if err != nil {
+				return nil, apperrors.NewBadRequestError("locked_until must be in YYYY-MM-DD format")
+			}
+			policy.LockedUntil = &date
+		}
+	}
+
+	if req.MaxBackdateDays != nil {
+		policy.MaxBackdateDays = *req.MaxBackdateDays
+	}
+	if req.AllowFutureDates != nil {
+		policy.AllowFutureDates = *req.AllowFutureDates
+	}
+	if req.LockApprovedPeriods != nil {
+		policy.LockApprovedPeriods = *req.LockApprovedPeriods
+	}
+	if req.MaxDailyHours != nil {
+		policy.MaxDailyHours = *req.MaxDailyHours
+	}
+	if req.MaxWeeklyHours != nil {
+		policy.MaxWeeklyHours = *req.MaxWeeklyHours
+	}
+	if req.RequireAttendanceMatch != nil {
+		policy.RequireAttendanceMatch = *req.RequireAttendanceMatch
+	}
+	if req.AttendanceTolerance != nil {
+		policy.AttendanceTolerance = *req.AttendanceTolerance
+	}
+	if req.HoursEnforcement != nil {
+		policy.HoursEnforcement = *req.HoursEnforcement
+		if policy.HoursEnforcement == "" {
+			policy.HoursEnforcement = domain.HoursEnforcementWarn
+		}
+	}
+	if req.BlockUnreconciledSubmission != nil {
+		policy.BlockUnreconciledSubmission = *req.BlockUnreconciledSubmission
+	}
+	if req.OvertimeSource != nil {
+		policy.OvertimeSource = *req.OvertimeSource
+		if policy.OvertimeSource == "" {
+			policy.OvertimeSource = domain.OvertimeSourceAttendance
+		}
+	}
+
+	if req.Timezone != nil {
+		policy.Timezone = *req.Timezone
+		if policy.Timezone == "" {
+			policy.Timezone = domain.DefaultTimezone
+		}
+		if _, err := time.LoadLocation(policy.Timezone); err != nil {
+			return nil, apperrors.NewBadRequestError("unknown timezone " + policy.Timezone)
+		}
+	}
+	if req.TimerRoundingMinutes != nil {
+		policy.TimerRoundingMinutes = *req.TimerRoundingMinutes
+	}
+	if req.TimerRoundingMode != nil {
+		policy.TimerRoundingMode = *req.TimerRoundingMode
+		if policy.TimerRoundingMode == "" {
+			policy.TimerRoundingMode = domain.RoundingModeNearest
+		}
+	}
+	if req.DeletedRetentionDays != nil {
+		policy.DeletedRetentionDays = *req.DeletedRetentionDays
+		if policy.DeletedRetentionDays == 0 {
+			policy.DeletedRetentionDays = domain.DefaultDeletedRetentionDays
+		}
+	}
+	if req.PunchRoundingMinutes != nil {
+		policy.PunchRoundingMinutes = *req.PunchRoundingMinutes
+	}
+	if req.PunchRoundingMode != nil {
+		policy.PunchRoundingMode = *req.PunchRoundingMode
+		if policy.PunchRoundingMode == "" {
+			policy.PunchRoundingMode = domain.PunchRoundingNearest
+		}
+	}
+	if req.LunchDeductionMinutes != nil {
+		policy.LunchDeductionMinutes = *req.LunchDeductionMinutes
+	}
+	if req.LunchThresholdHours != nil {
+		policy.LunchThresholdHours = *req.LunchThresholdHours
+		if policy.LunchThresholdHours == 0 {
+			policy.LunchThresholdHours = domain.DefaultLunchThresholdHours
+		}
+	}
+	if req.WeekStart != nil {
+		policy.WeekStart = *req.WeekStart
+	}
+	if req.WeekendDays != nil {
+		if err := validateWeekdays(req.WeekendDays); err != nil {
+			return nil, err
+		}
+		policy.WeekendDays = domain.Weekdays(req.WeekendDays)
+	}
+	if req.ExpectedDailyHours != nil {
+		policy.ExpectedDailyHours = *req.ExpectedDailyHours
+		if policy.ExpectedDailyHours == 0 {
+			policy.ExpectedDailyHours = domain.DefaultExpectedDailyHours
+		}
+	}
+	if req.LateGraceMinutes != nil {
+		policy.LateGraceMinutes = *req.LateGraceMinutes
+	}
+
+	if err := s.timeRepo.SaveTimesheetPolicy(policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// parseTimesheetDate parses the requested entry date
+func parseTimesheetDate(value string) (time.Time, error) {
+	date, err := utils.ParseDate(value)
+	if err != nil {
+		return time.Time{}, apperrors.NewBusinessRuleError(apperrors.ErrInvalidDate, "date must be in YYYY-MM-DD format", map[string]string{
+			"date": value,
+		})
+	}
+	return date, nil
+}
+
+// validateTimesheetDate checks a timesheet date against the organization's
+// backdating rules and returns an error naming the rule that failed.
+func (s *timeService) validateTimesheetDate(policy *domain.TimesheetPolicy, employeeID uuid.UUID, date time.Time) error {
+	today := time.Now().Truncate(24 * time.Hour)
+
+	if date.After(today) && !policy.AllowFutureDates {
+		return apperrors.NewBusinessRuleError(apperrors.ErrFutureDate, "timesheets cannot be logged for future dates", map[string]string{
+			"rule":  "allow_future_dates",
+			"date":  utils.FormatDate(date),
+			"today": utils.FormatDate(today),
+		})
+	}
+
+	earliest := today.AddDate(0, 0, -policy.MaxBackdateDays)
+	if date.Before(earliest) {
+		return apperrors.NewBusinessRuleError(apperrors.ErrBackdateLimit, fmt.Sprintf("timesheets cannot be logged more than %d days back", policy.MaxBackdateDays), map[string]interface{}{
+			"rule":              "max_backdate_days",
+			"date":              utils.FormatDate(date),
+			"max_backdate_days": policy.MaxBackdateDays,
+			"earliest_allowed":  utils.FormatDate(earliest),
+		})
+	}
+
+	return s.validatePeriodOpen(policy, employeeID, date)
+}
+
+// validatePeriodOpen rejects changes in periods that are locked, either by a
+// closed payroll period, up to the policy's lock date or because every entry
+// of the week has been approved. Approved weeks in a payroll period reopened
+// for corrections stay open.
+func (s *timeService) validatePeriodOpen(policy *domain.TimesheetPolicy, employeeID uuid.UUID, date time.Time) error {
+	if err := s.ensurePeriodNotClosed(policy.OrganizationID, date); err != nil {
+		return err
+	}
+
+	if policy.LockedUntil != nil && !date.After(*policy.LockedUntil) {
+		return apperrors.NewBusinessRuleError(apperrors.ErrPeriodLocked, "the period containing this date is locked", map[string]string{
+			"rule":         "locked_until",
+			"date":         utils.FormatDate(date),
+			"locked_until": utils.FormatDate(*policy.LockedUntil),
+		})
+	}
+
+	if policy.LockApprovedPeriods {
+		week := s.employeeWorkWeek(policy, employeeID)
+		periodStart := week.StartOfWeek(date)
+		periodEnd := week.EndOfWeek(date)
+		approved, err := s.weekFullyApproved(policy.OrganizationID, employeeID, periodStart, periodEnd)
+		if err != nil {
+			return err
+		}
+		if approved {
+			reopened, err := s.inReopenedPeriod(policy.OrganizationID, date)
+			if err != nil {
+				return err
+			}
+			if reopened {
+				return nil
+			}
+			return apperrors.NewBusinessRuleError(apperrors.ErrPeriodApproved, "the period containing this date has already been approved", map[string]string{
+				"rule":         "lock_approved_periods",
+				"date":         utils.FormatDate(date),
+				"period_start": utils.FormatDate(periodStart),
+				"period_end":   utils.FormatDate(periodEnd),
+			})
+		}
+	}
+
+	return nil
+}
+
+// weekFullyApproved reports whether the employee has approved entries in the
+// week and no drafts or entries awaiting approval; rejected entries are ignored
+func (s *timeService) weekFullyApproved(orgID, employeeID uuid.UUID, startDate, endDate time.Time) (bool, error) {
+	approved, err := s.timeRepo.CountTimesheetsByStatus(orgID, employeeID, startDate, endDate, domain.TimesheetStatusApproved)
+	if err != nil || approved == 0 {
+		return false, err
+	}
+	for _, status := range []string{domain.TimesheetStatusDraft, domain.TimesheetStatusPending} {
+		count, err := s.timeRepo.CountTimesheetsByStatus(orgID, employeeID, startDate, endDate, status)
+		if err != nil || count > 0 {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// validateTimesheetHours checks the employee's daily and weekly totals,
+// including the given entry, against the organization's caps. previous is the
+// stored version of the entry when it is being updated. Violations are returned
+// as warnings, or as an error when the policy rejects them.
+func (s *timeService) validateTimesheetHours(policy *domain.TimesheetPolicy, timesheet, previous *domain.Timesheet) ([]domain.TimesheetWarning, error) {
+	violations := []domain.TimesheetWarning{}
+	warnings := []domain.TimesheetWarning{}
+
+	dayTotal, err := s.totalHoursWith(timesheet, previous, timesheet.Date, timesheet.Date)
+	if err != nil {
+		return nil, err
+	}
+	if policy.MaxDailyHours > 0 && dayTotal > policy.MaxDailyHours {
+		violations = append(violations, domain.TimesheetWarning{
+			Rule:    "max_daily_hours",
+			Message: fmt.Sprintf("total hours on %s exceed the daily limit of %.2f", utils.FormatDate(timesheet.Date), policy.MaxDailyHours),
+			Limit:   policy.MaxDailyHours,
+			Actual:  dayTotal,
+		})
+	}
+
+	if policy.MaxWeeklyHours > 0 {
+		week := s.employeeWorkWeek(policy, timesheet.EmployeeID)
+		weekStart := week.StartOfWeek(timesheet.Date)
+		weekTotal, err := s.totalHoursWith(timesheet, previous, weekStart, week.EndOfWeek(timesheet.Date))
+		if err != nil {
+			return nil, err
+		}
+		if weekTotal > policy.MaxWeeklyHours {
+			violations = append(violations, domain.TimesheetWarning{
+				Rule:    "max_weekly_hours",
+				Message: fmt.Sprintf("total hours in the week of %s exceed the weekly limit of %.2f", utils.FormatDate(weekStart), policy.MaxWeeklyHours),
+				Limit:   policy.MaxWeeklyHours,
+				Actual:  weekTotal,
+			})
+		}
+	}
+
+	if policy.RequireAttendanceMatch && timesheet.EntryType != domain.TimesheetEntryCallOut {
+		attended, ok := s.attendedHours(timesheet.EmployeeID, timesheet.Date)
+		if ok {
+			dayTotal, err := s.workHoursWith(timesheet, previous)
+			if err != nil {
+				return nil, err
+			}
+			warning := domain.TimesheetWarning{
+				Rule:   "attendance_match",
+				Limit:  attended,
+				Actual: dayTotal,
+			}
+			switch {
+			case dayTotal > attended+policy.AttendanceTolerance:
+				warning.Message = fmt.Sprintf("logged hours exceed the %.2f attended hours on %s", attended, utils.FormatDate(timesheet.Date))
+				violations = append(violations, warning)
+			case dayTotal < attended-policy.AttendanceTolerance:
+				// More entries may follow, so under-logging is only a warning
+				warning.Message = fmt.Sprintf("logged hours are below the %.2f attended hours on %s", attended, utils.FormatDate(timesheet.Date))
+				warnings = append(warnings, warning)
+			}
+		}
+	}
+
+	if len(violations) > 0 && policy.HoursEnforcement == domain.HoursEnforcementReject {
+		return nil, apperrors.NewBusinessRuleError(apperrors.ErrLimitExceeded, "timesheet hours exceed the organization's limits", violations)
+	}
+
+	return append(violations, warnings...), nil
+}
+
+// totalHoursWith sums the employee's logged hours between two dates as they
+// would be after saving the given entry.
+func (s *timeService) totalHoursWith(timesheet, previous *domain.Timesheet, startDate, endDate time.Time) (float64, error) {
+	total, err := s.timeRepo.GetTimesheetSummary(timesheet.OrganizationID, timesheet.EmployeeID, startDate, endDate)
+	if err != nil {
+		return 0, err
+	}
+	if previous != nil && previous.Status != domain.TimesheetStatusRejected && inDateRange(previous.Date, startDate, endDate) {
+		total -= previous.Hours
+	}
+	return total + timesheet.Hours, nil
+}
+
+// workHoursWith sums the employee's regular work hours on the entry's date
+// as they would be after saving it, leaving call-outs out.
+func (s *timeService) workHoursWith(timesheet, previous *domain.Timesheet) (float64, error) {
+	totals, err := s.timeRepo.SumTimesheetHoursByDay(timesheet.OrganizationID, &timesheet.EmployeeID, timesheet.Date, timesheet.Date)
+	if err != nil {
+		return 0, err
+	}
+	total := 0.0
+	for _, day := range totals {
+		total += day.Hours
+	}
+	if previous != nil && previous.Status != domain.TimesheetStatusRejected && previous.EntryType != domain.TimesheetEntryCallOut && previous.Date.Equal(timesheet.Date) {
+		total -= previous.Hours
+	}
+	return total + timesheet.Hours, nil
+}
+
+// attendedHours returns the hours between check-in and check-out on the
+// given date, and false when the day has no completed attendance record.
+func (s *timeService) attendedHours(employeeID uuid.UUID, date time.Time) (float64, bool) {
+	attendance, err := s.timeRepo.GetAttendanceByDate(employeeID, date)
+	if err != nil {
+		return 0, false
+	}
+	return attendanceHours(attendance)
+}
+
+// attendanceHours returns the worked hours of a completed attendance record
+func attendanceHours(attendance *domain.Attendance) (float64, bool) {
+	if attendance.CheckIn == nil || attendance.CheckOut == nil {
+		return 0, false
+	}
+	// Worked hours are computed at check-out; older records only have the punches
+	if attendance.RoundedCheckOut != nil {
+		return attendance.WorkedHours, true
+	}
+	return attendance.CheckOut.Sub(*attendance.CheckIn).Hours(), true
+}
+
+// roundPunch rounds a punch to the policy's interval in its timezone. When
+// rounding favors the employee check-ins round down and check-outs up.
+func roundPunch(policy *domain.TimesheetPolicy, punch time.Time, checkIn bool) time.Time {
+	loc, err := time.LoadLocation(policy.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	local := punch.In(loc)
+	midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+
+	mode := domain.RoundingModeNearest
+	if policy.PunchRoundingMode == domain.PunchRoundingFavorEmployee {
+		mode = domain.RoundingModeUp
+		if checkIn {
+			mode = domain.RoundingModeDown
+		}
+	}
+	return midnight.Add(utils.RoundDuration(local.Sub(midnight), policy.PunchRoundingMinutes, mode))
+}
+
+// applyAttendanceHours computes the worked hours of a completed attendance
+// from its rounded punches, less punched breaks or else the lunch deduction.
+func applyAttendanceHours(policy *domain.TimesheetPolicy, attendance *domain.Attendance) {
+	checkIn := roundPunch(policy, *attendance.CheckIn, true)
+	checkOut := roundPunch(policy, *attendance.CheckOut, false)
+	if checkOut.Before(checkIn) {
+		checkOut = checkIn
+	}
+	attendance.RoundedCheckIn = &checkIn
+	attendance.RoundedCheckOut = &checkOut
+
+	worked := checkOut.Sub(checkIn) - time.Duration(attendance.BreakMinutes)*time.Minute
+	attendance.LunchDeductionMinutes = 0
+	if attendance.BreakMinutes == 0 && policy.LunchDeductionMinutes > 0 && worked.Hours() > policy.LunchThresholdHours {
+		attendance.LunchDeductionMinutes = policy.LunchDeductionMinutes
+		worked -= time.Duration(policy.LunchDeductionMinutes) * time.Minute
+	}
+	if worked < 0 {
+		worked = 0
+	}
+	attendance.WorkedHours = roundHours(worked.Hours())
+}
+
+func inDateRange(date, startDate, endDate time.Time) bool {
+	return !date.Before(startDate) && !date.After(endDate)
+}
+
+// applyTimesheetTimes sets the entry's hours, or derives them from the
+// requested start and end clock times on the entry date. An end time earlier
+// than the start time is treated as an overnight entry. Drafts may be saved
+// without hours.
+func applyTimesheetTimes(policy *domain.TimesheetPolicy, timesheet *domain.Timesheet, req *domain.CreateTimesheetRequest) error {
+	if req.StartTime == "" && req.EndTime == "" {
+		if req.Hours <= 0 && timesheet.Status != domain.TimesheetStatusDraft {
+			return apperrors.NewValidationError(map[string]string{
+				"hours": "either hours or start_time and end_time are required",
+			})
+		}
+		timesheet.Hours = req.Hours
+		timesheet.StartTime = nil
+		timesheet.EndTime = nil
+		return nil
+	}
+
+	hours, err := utils.CalculateDuration(req.StartTime, req.EndTime)
+	if err != nil {
+		return apperrors.NewBusinessRuleError(apperrors.ErrInvalidTime, "start_time and end_time must be in HH:MM:SS format", map[string]string{
+			"start_time": req.StartTime,
+			"end_time":   req.EndTime,
+		})
+	}
+	if hours <= 0 {
+		return apperrors.NewBusinessRuleError(apperrors.ErrInvalidTime, "end_time must differ from start_time", map[string]string{
+			"start_time": req.StartTime,
+			"end_time":   req.EndTime,
+		})
+	}
+
+	loc, err := time.LoadLocation(policy.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	clock, _ := time.Parse("15:04:05", req.StartTime)
+	start := time.Date(timesheet.Date.Year(), timesheet.Date.Month(), timesheet.Date.Day(), clock.Hour(), clock.Minute(), clock.Second(), 0, loc)
+	end := start.Add(time.Duration(hours * float64(time.Hour)))
+
+	timesheet.Hours = hours
+	timesheet.StartTime = &start
+	timesheet.EndTime = &end
+	return nil
+}
+
+// validateTimesheetOverlap rejects clock-time entries that overlap another
+// entry of the same employee.
+func (s *timeService) validateTimesheetOverlap(timesheet *domain.Timesheet) error {
+	if timesheet.StartTime == nil || timesheet.EndTime == nil {
+		return nil
+	}
+
+	overlapping, err := s.timeRepo.ListOverlappingTimesheets(timesheet.EmployeeID, *timesheet.StartTime, *timesheet.EndTime, timesheet.ID)
+	if err != nil {
+		return err
+	}
+	if len(overlapping) == 0 {
+		return nil
+	}
+
+	conflicts := []map[string]interface{}{}
+	for _, other := range overlapping {
+		conflicts = append(conflicts, map[string]interface{}{
+			"id":         other.ID,
+			"start_time": other.StartTime,
+			"end_time":   other.EndTime,
+		})
+	}
+	return apperrors.NewBusinessRuleError(apperrors.ErrTimeOverlap, "the entry overlaps another timesheet entry", conflicts)
+}
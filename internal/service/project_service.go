@@ -0,0 +1,350 @@
+package service
+
+import (
+	"time"
+
+	"github.com/Axontik/comin-time-service/internal/domain"
+	apperrors "github.com/Axontik/comin-time-service/internal/errors"
+	"github.com/Axontik/comin-time-service/utils"
+	"github.com/google/uuid"
+)
+
+func (s *timeService) CreateProject(orgID uuid.UUID, req *domain.ProjectRequest) (*domain.Project, error) {
+	project := &domain.Project{OrganizationID: orgID, IsBillable: true}
+	if err := applyProjectRequest(project, req); err != nil {
+		return nil, err
+	}
+	if err := s.ensureUniqueProjectCode(orgID, project); err != nil {
+		return nil, err
+	}
+	if err := s.timeRepo.CreateProject(project); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+func (s *timeService) GetProject(orgID, id uuid.UUID) (*domain.Project, error) {
+	return s.getOrganizationProject(orgID, id)
+}
+
+func (s *timeService) UpdateProject(orgID, id uuid.UUID, req *domain.ProjectRequest) (*domain.Project, error) {
+	project, err := s.getOrganizationProject(orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyProjectRequest(project, req); err != nil {
+		return nil, err
+	}
+	if err := s.ensureUniqueProjectCode(orgID, project); err != nil {
+		return nil, err
+	}
+	if err := s.timeRepo.UpdateProject(project); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+func (s *timeService) ListProjects(orgID uuid.UUID, status string) ([]domain.Project, error) {
+	return s.timeRepo.ListProjects(orgID, status)
+}
+
+func (s *timeService) CreateTask(orgID, projectID uuid.UUID, req *domain.TaskRequest) (*domain.Task, error) {
+	if _, err := s.getOrganizationProject(orgID, projectID); err != nil {
+		return nil, err
+	}
+
+	task := &domain.Task{ProjectID: projectID}
+	applyTaskRequest(task, req)
+	if err := s.timeRepo.CreateTask(task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+func (s *timeService) UpdateTask(orgID, projectID, id uuid.UUID, req *domain.TaskRequest) (*domain.Task, error) {
+	if _, err := s.getOrganizationProject(orgID, projectID); err != nil {
+		return nil, err
+	}
+
+	task, err := s.timeRepo.GetTask(id)
+	if err != nil || task.ProjectID != projectID {
+		return nil, apperrors.NewNotFoundError("task not found")
+	}
+
+	applyTaskRequest(task, req)
+	if err := s.timeRepo.UpdateTask(task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+func (s *timeService) ListTasks(orgID, projectID uuid.UUID) ([]domain.Task, error) {
+	if _, err := s.getOrganizationProject(orgID, projectID); err != nil {
+		return nil, err
+	}
+	return s.timeRepo.ListTasks(projectID)
+}
+
+func (s *timeService) AddProjectMember(orgID, projectID uuid.UUID, req *domain.ProjectMemberRequest) (*domain.ProjectMember, error) {
+	if _, err := s.getOrganizationProject(orgID, projectID); err != nil {
+		return nil, err
+	}
+
+	isMember, err := s.timeRepo.IsProjectMember(projectID, req.EmployeeID)
+	if err != nil {
+		return nil, err
+	}
+	if isMember {
+		return nil, apperrors.NewConflictError("employee is already assigned to this project")
+	}
+
+	member := &domain.ProjectMember{
+		ProjectID:  projectID,
+		EmployeeID: req.EmployeeID,
+		Role:       req.Role,
+	}
+	if err := s.timeRepo.AddProjectMember(member); err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+func (s *timeService) RemoveProjectMember(orgID, projectID, employeeID uuid.UUID) error {
+	if _, err := s.getOrganizationProject(orgID, projectID); err != nil {
+		return err
+	}
+	return s.timeRepo.RemoveProjectMember(projectID, employeeID)
+}
+
+// validateTimesheetProject checks that the entry's project and task exist in
+// the organization, are open on the entry date and that the employee is
+// assigned to the project. A task given without a project sets the project.
+// It returns the entry's project, or nil when it has none.
+func (s *timeService) validateTimesheetProject(timesheet *domain.Timesheet) (*domain.Project, error) {
+	if timesheet.TaskID != nil {
+		task, err := s.timeRepo.GetTask(*timesheet.TaskID)
+		if err != nil {
+			return nil, apperrors.NewBusinessRuleError(apperrors.ErrTaskNotFound, "task does not exist", map[string]interface{}{
+				"task_id": timesheet.TaskID,
+			})
+		}
+		if timesheet.ProjectID == nil {
+			timesheet.ProjectID = &task.ProjectID
+		}
+		if task.ProjectID != *timesheet.ProjectID {
+			return nil, apperrors.NewBusinessRuleError(apperrors.ErrTaskNotFound, "task does not belong to the project", map[string]interface{}{
+				"task_id":    task.ID,
+				"project_id": timesheet.ProjectID,
+			})
+		}
+		if task.Status == domain.TaskStatusClosed {
+			return nil, apperrors.NewBusinessRuleError(apperrors.ErrTaskClosed, "task is closed", map[string]interface{}{
+				"task_id": task.ID,
+			})
+		}
+	}
+
+	if timesheet.ProjectID == nil {
+		return nil, nil
+	}
+
+	project, err := s.timeRepo.GetProject(*timesheet.ProjectID)
+	if err != nil || project.OrganizationID != timesheet.OrganizationID {
+		return nil, apperrors.NewBusinessRuleError(apperrors.ErrProjectNotFound, "project does not exist", map[string]interface{}{
+			"project_id": timesheet.ProjectID,
+		})
+	}
+
+	switch {
+	case project.Status == domain.ProjectStatusClosed:
+		return nil, apperrors.NewBusinessRuleError(apperrors.ErrProjectClosed, "project is closed", map[string]interface{}{
+			"project_id": project.ID,
+		})
+	case project.Status != domain.ProjectStatusActive:
+		return nil, apperrors.NewBusinessRuleError(apperrors.ErrProjectInactive, "project is not active", map[string]interface{}{
+			"project_id": project.ID,
+			"status":     project.Status,
+		})
+	case project.StartDate != nil && timesheet.Date.Before(*project.StartDate),
+		project.EndDate != nil && timesheet.Date.After(*project.EndDate):
+		return nil, apperrors.NewBusinessRuleError(apperrors.ErrProjectInactive, "date is outside the project's duration", map[string]interface{}{
+			"project_id": project.ID,
+			"date":       utils.FormatDate(timesheet.Date),
+			"start_date": project.StartDate,
+			"end_date":   project.EndDate,
+		})
+	}
+
+	isMember, err := s.timeRepo.IsProjectMember(project.ID, timesheet.EmployeeID)
+	if err != nil {
+		return nil, err
+	}
+	if !isMember {
+		return nil, apperrors.NewBusinessRuleError(apperrors.ErrProjectNotAssigned, "employee is not assigned to the project", map[string]interface{}{
+			"project_id":  project.ID,
+			"employee_id": timesheet.EmployeeID,
+		})
+	}
+
+	return project, nil
+}
+
+// timesheetResponses converts timesheets to responses with project and task names filled in
+func (s *timeService) timesheetResponses(timesheets []domain.Timesheet) ([]domain.TimesheetResponse, error) {
+	projectIDs := []uuid.UUID{}
+	taskIDs := []uuid.UUID{}
+	for _, timesheet := range timesheets {
+		if timesheet.ProjectID != nil {
+			projectIDs = append(projectIDs, *timesheet.ProjectID)
+		}
+		if timesheet.TaskID != nil {
+			taskIDs = append(taskIDs, *timesheet.TaskID)
+		}
+	}
+
+	projects, err := s.timeRepo.ListProjectsByIDs(projectIDs)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := s.timeRepo.ListTasksByIDs(taskIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	projectNames := map[uuid.UUID]string{}
+	for _, project := range projects {
+		projectNames[project.ID] = project.Name
+	}
+	taskNames := map[uuid.UUID]string{}
+	for _, task := range tasks {
+		taskNames[task.ID] = task.Name
+	}
+
+	responses := make([]domain.TimesheetResponse, 0, len(timesheets))
+	for _, timesheet := range timesheets {
+		response := domain.TimesheetResponse{
+			ID:          timesheet.ID,
+			EmployeeID:  timesheet.EmployeeID,
+			ProjectID:   timesheet.ProjectID,
+			TaskID:      timesheet.TaskID,
+			Description: timesheet.Description,
+			Date:        timesheet.Date,
+			Hours:       timesheet.Hours,
+			StartTime:   timesheet.StartTime,
+			EndTime:     timesheet.EndTime,
+			Status:      timesheet.Status,
+			Notes:       timesheet.Notes,
+			ApprovedBy:  timesheet.ApprovedBy,
+			ApprovedAt:  timesheet.ApprovedAt,
+
+			IsBillable:     timesheet.IsBillable,
+			BillRate:       timesheet.BillRate,
+			CostRate:       timesheet.CostRate,
+			BillableAmount: timesheet.BillableAmount,
+			CostAmount:     timesheet.CostAmount,
+			Currency:       timesheet.Currency,
+			InvoiceID:      timesheet.InvoiceID,
+
+			EntryType:        timesheet.EntryType,
+			OnCallIncidentID: timesheet.OnCallIncidentID,
+		}
+		if timesheet.DeletedAt.Valid {
+			deletedAt := timesheet.DeletedAt.Time
+			response.DeletedAt = &deletedAt
+		}
+		if timesheet.ProjectID != nil {
+			response.ProjectName = projectNames[*timesheet.ProjectID]
+		}
+		if timesheet.TaskID != nil {
+			response.TaskName = taskNames[*timesheet.TaskID]
+		}
+		responses = append(responses, response)
+	}
+	return responses, nil
+}
+
+func (s *timeService) timesheetResponse(timesheet *domain.Timesheet) (*domain.TimesheetResponse, error) {
+	responses, err := s.timesheetResponses([]domain.Timesheet{*timesheet})
+	if err != nil {
+		return nil, err
+	}
+	return &responses[0], nil
+}
+
+func (s *timeService) timesheetResult(timesheet *domain.Timesheet, warnings []domain.TimesheetWarning) (*domain.TimesheetResult, error) {
+	response, err := s.timesheetResponse(timesheet)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.TimesheetResult{TimesheetResponse: *response, Warnings: warnings}, nil
+}
+
+func (s *timeService) getOrganizationProject(orgID, id uuid.UUID) (*domain.Project, error) {
+	project, err := s.timeRepo.GetProject(id)
+	if err != nil || project.OrganizationID != orgID {
+		return nil, apperrors.NewNotFoundError("project not found")
+	}
+	return project, nil
+}
+
+func (s *timeService) ensureUniqueProjectCode(orgID uuid.UUID, project *domain.Project) error {
+	projects, err := s.timeRepo.ListProjects(orgID, "")
+	if err != nil {
+		return err
+	}
+	for _, other := range projects {
+		if other.ID != project.ID && other.Code == project.Code {
+			return apperrors.NewConflictError("a project with this code already exists")
+		}
+	}
+	return nil
+}
+
+func applyProjectRequest(project *domain.Project, req *domain.ProjectRequest) error {
+	startDate, err := parseOptionalDate("start_date", req.StartDate)
+	if err != nil {
+		return err
+	}
+	endDate, err := parseOptionalDate("end_date", req.EndDate)
+	if err != nil {
+		return err
+	}
+	if startDate != nil && endDate != nil && endDate.Before(*startDate) {
+		return apperrors.NewBadRequestError("end_date must not be before start_date")
+	}
+
+	project.Name = req.Name
+	project.Code = req.Code
+	project.Client = req.Client
+	project.Status = req.Status
+	if project.Status == "" {
+		project.Status = domain.ProjectStatusActive
+	}
+	project.StartDate = startDate
+	project.EndDate = endDate
+	if req.Billable != nil {
+		project.IsBillable = *req.Billable
+	}
+	return nil
+}
+
+func applyTaskRequest(task *domain.Task, req *domain.TaskRequest) {
+	task.Name = req.Name
+	task.Code = req.Code
+	task.Status = req.Status
+	if task.Status == "" {
+		task.Status = domain.TaskStatusActive
+	}
+}
+
+// parseOptionalDate parses a YYYY-MM-DD request field that may be empty
+func parseOptionalDate(field, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	date, err := utils.ParseDate(value)
+	if err != nil {
+		return nil, apperrors.NewBadRequestError(field + " must be in YYYY-MM-DD format")
+	}
+	return &date, nil
+}
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"math"
+	"strings"
+
+	"github.com/Axontik/comin-time-service/internal/domain"
+	apperrors "github.com/Axontik/comin-time-service/internal/errors"
+	"github.com/google/uuid"
+)
+
+func (s *timeService) CreateRateCard(orgID uuid.UUID, req *domain.RateCardRequest) (*domain.RateCard, error) {
+	rateCard := &domain.RateCard{OrganizationID: orgID}
+	if err := s.applyRateCardRequest(rateCard, req); err != nil {
+		return nil, err
+	}
+	if err := s.timeRepo.CreateRateCard(rateCard); err != nil {
+		return nil, err
+	}
+	return rateCard, nil
+}
+
+func (s *timeService) UpdateRateCard(orgID, id uuid.UUID, req *domain.RateCardRequest) (*domain.RateCard, error) {
+	rateCard, err := s.getOrganizationRateCard(orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyRateCardRequest(rateCard, req); err != nil {
+		return nil, err
+	}
+	if err := s.timeRepo.UpdateRateCard(rateCard); err != nil {
+		return nil, err
+	}
+	return rateCard, nil
+}
+
+func (s *timeService) DeleteRateCard(orgID, id uuid.UUID) error {
+	if _, err := s.getOrganizationRateCard(orgID, id); err != nil {
+		return err
+	}
+	return s.timeRepo.DeleteRateCard(id)
+}
+
+func (s *timeService) ListRateCards(orgID uuid.UUID) ([]domain.RateCard, error) {
+	return s.timeRepo.ListRateCards(orgID)
+}
+
+// applyTimesheetRates sets whether the entry is billable, defaulting from its
+// project, and computes its billable amount and cost. Approved entries keep
+// the rates they were approved with so rate card changes never rewrite them.
+func (s *timeService) applyTimesheetRates(timesheet *domain.Timesheet, project *domain.Project, billable *bool) error {
+	switch {
+	case billable != nil:
+		timesheet.IsBillable = *billable
+	case project != nil:
+		timesheet.IsBillable = project.IsBillable
+	default:
+		timesheet.IsBillable = false
+	}
+
+	if timesheet.Status != domain.TimesheetStatusApproved {
+		rateCard, err := s.resolveRateCard(timesheet)
+		if err != nil {
+			return err
+		}
+		if rateCard != nil {
+			timesheet.RateCardID = &rateCard.ID
+			timesheet.BillRate = rateCard.BillRate
+			timesheet.CostRate = rateCard.CostRate
+			timesheet.Currency = rateCard.Currency
+		} else {
+			timesheet.RateCardID = nil
+			timesheet.BillRate = 0
+			timesheet.CostRate = 0
+			timesheet.Currency = ""
+		}
+	}
+
+	timesheet.CostAmount = roundAmount(timesheet.Hours * timesheet.CostRate)
+	timesheet.BillableAmount = 0
+	if timesheet.IsBillable {
+		timesheet.BillableAmount = roundAmount(timesheet.Hours * timesheet.BillRate)
+	}
+	return nil
+}
+
+// resolveRateCard picks the most specific rate card in effect on the entry
+// date: employee over project role over project over organization default.
+func (s *timeService) resolveRateCard(timesheet *domain.Timesheet) (*domain.RateCard, error) {
+	rateCards, err := s.timeRepo.ListEffectiveRateCards(timesheet.OrganizationID, timesheet.Date)
+	if err != nil {
+		return nil, err
+	}
+	if len(rateCards) == 0 {
+		return nil, nil
+	}
+
+	role := ""
+	if timesheet.ProjectID != nil {
+		if member, err := s.timeRepo.GetProjectMember(*timesheet.ProjectID, timesheet.EmployeeID); err == nil {
+			role = member.Role
+		}
+	}
+
+	var best *domain.RateCard
+	bestScore := -1
+	for i := range rateCards {
+		rateCard := &rateCards[i]
+		score := 0
+		if rateCard.EmployeeID != nil {
+			if *rateCard.EmployeeID != timesheet.EmployeeID {
+				continue
+			}
+			score += 4
+		}
+		if rateCard.Role != "" {
+			if rateCard.Role != role {
+				continue
+			}
+			score += 2
+		}
+		if rateCard.ProjectID != nil {
+			if timesheet.ProjectID == nil || *rateCard.ProjectID != *timesheet.ProjectID {
+				continue
+			}
+			score++
+		}
+		// Rate cards are ordered by effective date, so ties keep the newest
+		if score > bestScore {
+			best = rateCard
+			bestScore = score
+		}
+	}
+	return best, nil
+}
+
+func (s *timeService) applyRateCardRequest(rateCard *domain.RateCard, req *domain.RateCardRequest) error {
+	effectiveFrom, err := parseOptionalDate("effective_from", req.EffectiveFrom)
+	if err != nil {
+		return err
+	}
+	effectiveTo, err := parseOptionalDate("effective_to", req.EffectiveTo)
+	if err != nil {
+		return err
+	}
+	if effectiveTo != nil && effectiveTo.Before(*effectiveFrom) {
+		return apperrors.NewBadRequestError("effective_to must not be before effective_from")
+	}
+
+	if req.ProjectID != nil {
+		if _, err := s.getOrganizationProject(rateCard.OrganizationID, *req.ProjectID); err != nil {
+			return err
+		}
+	}
+
+	rateCard.ProjectID = req.ProjectID
+	rateCard.Role = req.Role
+	rateCard.EmployeeID = req.EmployeeID
+	rateCard.BillRate = req.BillRate
+	rateCard.CostRate = req.CostRate
+	rateCard.Currency = strings.ToUpper(req.Currency)
+	rateCard.EffectiveFrom = *effectiveFrom
+	rateCard.EffectiveTo = effectiveTo
+	return nil
+}
+
+func (s *timeService) getOrganizationRateCard(orgID, id uuid.UUID) (*domain.RateCard, error) {
+	rateCard, err := s.timeRepo.GetRateCard(id)
+	if err != nil || rateCard.OrganizationID != orgID {
+		return nil, apperrors.NewNotFoundError("rate card not found")
+	}
+	return rateCard, nil
+}
+
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
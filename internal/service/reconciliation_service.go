@@ -0,0 +1,172 @@
+package service
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/Axontik/comin-time-service/internal/domain"
+	apperrors "github.com/Axontik/comin-time-service/internal/errors"
+	"github.com/Axontik/comin-time-service/utils"
+	"github.com/google/uuid"
+)
+
+// GetReconciliationReport compares the daily timesheet totals of the
+// organization's employees with their attendance and flags the days that
+// differ by more than the tolerance.
+func (s *timeService) GetReconciliationReport(orgID uuid.UUID, filter *domain.ReconciliationFilter) (*domain.ReconciliationReport, error) {
+	tolerance := 0.0
+	if filter.Tolerance != nil {
+		tolerance = *filter.Tolerance
+	} else {
+		policy, err := s.GetTimesheetPolicy(orgID)
+		if err != nil {
+			return nil, err
+		}
+		tolerance = policy.AttendanceTolerance
+	}
+
+	days, err := s.reconcileDays(orgID, filter.EmployeeID, filter.StartDate, filter.EndDate, tolerance)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.ReconciliationReport{
+		StartDate: filter.StartDate,
+		EndDate:   filter.EndDate,
+		Tolerance: tolerance,
+		Days:      []domain.ReconciliationDay{},
+	}
+	for _, day := range days {
+		summary := &report.Summary
+		summary.Days++
+		summary.TimesheetHours += day.TimesheetHours
+		summary.AttendedHours += day.AttendedHours
+		switch day.Status {
+		case domain.ReconciliationMatched:
+			summary.Matched++
+		case domain.ReconciliationOverLogged:
+			summary.OverLogged++
+		case domain.ReconciliationUnderLogged:
+			summary.UnderLogged++
+		case domain.ReconciliationMissingAttendance:
+			summary.MissingAttendance++
+		case domain.ReconciliationMissingTimesheet:
+			summary.MissingTimesheet++
+		}
+
+		if filter.DiscrepanciesOnly && day.Status == domain.ReconciliationMatched {
+			continue
+		}
+		report.Days = append(report.Days, day)
+	}
+	report.Summary.TimesheetHours = roundHours(report.Summary.TimesheetHours)
+	report.Summary.AttendedHours = roundHours(report.Summary.AttendedHours)
+
+	return report, nil
+}
+
+func (s *timeService) reconcileDays(orgID uuid.UUID, employeeID *uuid.UUID, startDate, endDate time.Time, tolerance float64) ([]domain.ReconciliationDay, error) {
+	totals, err := s.timeRepo.SumTimesheetHoursByDay(orgID, employeeID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	attendances, err := s.timeRepo.ListAttendancesInRange(orgID, employeeID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	days := map[string]*domain.ReconciliationDay{}
+	dayFor := func(employeeID uuid.UUID, date time.Time) *domain.ReconciliationDay {
+		key := employeeID.String() + "|" + utils.FormatDate(date)
+		if day, ok := days[key]; ok {
+			return day
+		}
+		day := &domain.ReconciliationDay{EmployeeID: employeeID, Date: date}
+		days[key] = day
+		return day
+	}
+
+	for _, total := range totals {
+		dayFor(total.EmployeeID, total.Date).TimesheetHours += total.Hours
+	}
+	attended := map[*domain.ReconciliationDay]bool{}
+	for i := range attendances {
+		hours, ok := attendanceHours(&attendances[i])
+		if !ok {
+			continue
+		}
+		day := dayFor(attendances[i].EmployeeID, attendances[i].Date)
+		day.AttendedHours += hours
+		attended[day] = true
+	}
+
+	result := make([]domain.ReconciliationDay, 0, len(days))
+	for _, day := range days {
+		day.TimesheetHours = roundHours(day.TimesheetHours)
+		day.AttendedHours = roundHours(day.AttendedHours)
+		day.Difference = roundHours(day.TimesheetHours - day.AttendedHours)
+
+		switch {
+		case !attended[day]:
+			day.Status = domain.ReconciliationMissingAttendance
+		case day.TimesheetHours == 0:
+			day.Status = domain.ReconciliationMissingTimesheet
+		case day.Difference > tolerance:
+			day.Status = domain.ReconciliationOverLogged
+		case day.Difference < -tolerance:
+			day.Status = domain.ReconciliationUnderLogged
+		default:
+			day.Status = domain.ReconciliationMatched
+		}
+		result = append(result, *day)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].Date.Equal(result[j].Date) {
+			return result[i].Date.Before(result[j].Date)
+		}
+		return result[i].EmployeeID.String() < result[j].EmployeeID.String()
+	})
+	return result, nil
+}
+
+// validateSubmissionReconciled rejects submitting entries on days whose
+// logged hours, as saved, do not match the employee's attendance.
+func (s *timeService) validateSubmissionReconciled(policy *domain.TimesheetPolicy, employeeID uuid.UUID, entries []domain.Timesheet) error {
+	if !policy.BlockUnreconciledSubmission || len(entries) == 0 {
+		return nil
+	}
+
+	startDate, endDate := entries[0].Date, entries[0].Date
+	dates := map[string]bool{}
+	for _, entry := range entries {
+		if entry.Date.Before(startDate) {
+			startDate = entry.Date
+		}
+		if entry.Date.After(endDate) {
+			endDate = entry.Date
+		}
+		dates[utils.FormatDate(entry.Date)] = true
+	}
+
+	days, err := s.reconcileDays(policy.OrganizationID, &employeeID, startDate, endDate, policy.AttendanceTolerance)
+	if err != nil {
+		return err
+	}
+
+	mismatched := []domain.ReconciliationDay{}
+	for _, day := range days {
+		if dates[utils.FormatDate(day.Date)] && day.Status != domain.ReconciliationMatched {
+			mismatched = append(mismatched, day)
+		}
+	}
+	if len(mismatched) > 0 {
+		return apperrors.NewBusinessRuleError(apperrors.ErrAttendanceMismatch, "timesheet hours do not match attendance", mismatched)
+	}
+	return nil
+}
+
+func roundHours(hours float64) float64 {
+	return math.Round(hours*100) / 100
+}
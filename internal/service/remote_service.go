@@ -0,0 +1,299 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Axontik/comin-time-service/internal/domain"
+	apperrors "github.com/Axontik/comin-time-service/internal/errors"
+	"github.com/Axontik/comin-time-service/utils"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func (s *timeService) GetRemoteWorkPolicy(orgID uuid.UUID) (*domain.RemoteWorkPolicy, error) {
+	policy, err := s.timeRepo.GetRemoteWorkPolicy(orgID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.DefaultRemoteWorkPolicy(orgID), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+func (s *timeService) UpdateRemoteWorkPolicy(orgID uuid.UUID, req *domain.RemoteWorkPolicyRequest) (*domain.RemoteWorkPolicy, error) {
+	policy, err := s.GetRemoteWorkPolicy(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	days := domain.Weekdays{}
+	seen := map[int]bool{}
+	for _, day := range req.PreApprovalDays {
+		if day < 0 || day > 6 {
+			return nil, apperrors.NewBadRequestError("weekdays must be between 0 (Sunday) and 6 (Saturday)")
+		}
+		if !seen[day] {
+			seen[day] = true
+			days = append(days, day)
+		}
+	}
+	sort.Ints(days)
+
+	policy.MaxRemoteDaysPerWeek = req.MaxRemoteDaysPerWeek
+	policy.PreApprovalDays = days
+	policy.RequireApproval = req.RequireApproval
+	policy.Enforcement = req.Enforcement
+	if policy.Enforcement == "" {
+		policy.Enforcement = domain.RemoteEnforcementFlag
+	}
+	if err := s.timeRepo.SaveRemoteWorkPolicy(policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// CreateRemoteWorkRequest submits a request to work remotely on the days
+// of a range for the employee's manager to review
+func (s *timeService) CreateRemoteWorkRequest(orgID, employeeID uuid.UUID, req *domain.CreateRemoteWorkRequest) (*domain.RemoteWorkRequest, error) {
+	startDate, err := utils.ParseDate(req.StartDate)
+	if err != nil {
+		return nil, apperrors.NewBadRequestError("start_date must be in YYYY-MM-DD format")
+	}
+	endDate := startDate
+	if req.EndDate != "" {
+		if endDate, err = utils.ParseDate(req.EndDate); err != nil {
+			return nil, apperrors.NewBadRequestError("end_date must be in YYYY-MM-DD format")
+		}
+	}
+	if endDate.Before(startDate) {
+		return nil, apperrors.NewBadRequestError("end_date must not be before start_date")
+	}
+
+	request := &domain.RemoteWorkRequest{
+		OrganizationID: orgID,
+		EmployeeID:     employeeID,
+		StartDate:      startDate,
+		EndDate:        endDate,
+		Reason:         req.Reason,
+		Status:         domain.RemoteRequestPending,
+	}
+	existing, err := s.timeRepo.ListRemoteWorkRequests(orgID, &domain.RemoteWorkRequestFilter{
+		EmployeeID: &employeeID,
+		StartDate:  startDate,
+		EndDate:    endDate,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, other := range existing {
+		if other.Status == domain.RemoteRequestPending || other.Status == domain.RemoteRequestApproved {
+			return nil, apperrors.NewConflictError(fmt.Sprintf("remote work already requested from %s to %s", utils.FormatDate(other.StartDate), utils.FormatDate(other.EndDate)))
+		}
+	}
+
+	if err := s.timeRepo.CreateRemoteWorkRequest(request); err != nil {
+		return nil, err
+	}
+	s.notifyRemoteWorkRequested(request)
+	return request, nil
+}
+
+func (s *timeService) ListRemoteWorkRequests(orgID uuid.UUID, filter *domain.RemoteWorkRequestFilter) ([]domain.RemoteWorkRequest, error) {
+	return s.timeRepo.ListRemoteWorkRequests(orgID, filter)
+}
+
+func (s *timeService) ApproveRemoteWorkRequest(orgID, id, reviewerID uuid.UUID, req *domain.ReviewRemoteWorkRequest) (*domain.RemoteWorkRequest, error) {
+	return s.reviewRemoteWorkRequest(orgID, id, reviewerID, domain.RemoteRequestApproved, req)
+}
+
+func (s *timeService) RejectRemoteWorkRequest(orgID, id, reviewerID uuid.UUID, req *domain.ReviewRemoteWorkRequest) (*domain.RemoteWorkRequest, error) {
+	return s.reviewRemoteWorkRequest(orgID, id, reviewerID, domain.RemoteRequestRejected, req)
+}
+
+// CancelRemoteWorkRequest withdraws a pending or approved request. Remote
+// check-ins already made under it are left as they are.
+func (s *timeService) CancelRemoteWorkRequest(orgID, employeeID, id uuid.UUID) (*domain.RemoteWorkRequest, error) {
+	request, err := s.getRemoteWorkRequest(orgID, id)
+	if err != nil || request.EmployeeID != employeeID {
+		return nil, apperrors.NewNotFoundError("remote work request not found")
+	}
+	if request.Status != domain.RemoteRequestPending && request.Status != domain.RemoteRequestApproved {
+		return nil, apperrors.NewInvalidStatusError("only pending or approved remote work requests can be cancelled")
+	}
+
+	request.Status = domain.RemoteRequestCancelled
+	if err := s.timeRepo.UpdateRemoteWorkRequest(request); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+func (s *timeService) ListRemoteWorkViolations(orgID uuid.UUID, filter *domain.RemoteWorkViolationFilter) ([]domain.RemoteWorkViolation, error) {
+	return s.timeRepo.ListRemoteWorkViolations(orgID, filter)
+}
+
+func (s *timeService) reviewRemoteWorkRequest(orgID, id, reviewerID uuid.UUID, status string, req *domain.ReviewRemoteWorkRequest) (*domain.RemoteWorkRequest, error) {
+	request, err := s.getRemoteWorkRequest(orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	if request.Status != domain.RemoteRequestPending {
+		return nil, apperrors.NewInvalidStatusError("only pending remote work requests can be reviewed")
+	}
+
+	now := time.Now()
+	request.Status = status
+	request.ReviewedBy = &reviewerID
+	request.ReviewedAt = &now
+	request.ReviewNote = req.Note
+	if err := s.timeRepo.UpdateRemoteWorkRequest(request); err != nil {
+		return nil, err
+	}
+	s.emitNotification(orgID, &request.EmployeeID, domain.NotificationRemoteWorkReviewed, "remote_work_request", &request.ID, map[string]interface{}{
+		"status":     request.Status,
+		"start_date": utils.FormatDate(request.StartDate),
+		"end_date":   utils.FormatDate(request.EndDate),
+		"note":       request.ReviewNote,
+	})
+	return request, nil
+}
+
+// validateRemoteCheckIn checks a remote check-in against the organization's
+// remote-work policy. Members of always-remote teams and days covered by an
+// approved request are exempt. Breaches block the check-in when the policy
+// rejects them and are returned for flagging otherwise.
+func (s *timeService) validateRemoteCheckIn(policy *domain.TimesheetPolicy, attendance *domain.Attendance) ([]domain.RemoteWorkViolation, error) {
+	if attendance.WorkMode != domain.WorkModeRemote {
+		return nil, nil
+	}
+	remotePolicy, err := s.GetRemoteWorkPolicy(attendance.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	if remotePolicy.MaxRemoteDaysPerWeek == 0 && !remotePolicy.RequireApproval && len(remotePolicy.PreApprovalDays) == 0 {
+		return nil, nil
+	}
+	if team := s.employeeTeam(attendance.OrganizationID, attendance.EmployeeID); team != nil && team.AlwaysRemote {
+		return nil, nil
+	}
+	approved, err := s.timeRepo.ListRemoteWorkRequests(attendance.OrganizationID, &domain.RemoteWorkRequestFilter{
+		EmployeeID: &attendance.EmployeeID,
+		Status:     domain.RemoteRequestApproved,
+		StartDate:  attendance.Date,
+		EndDate:    attendance.Date,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(approved) > 0 {
+		return nil, nil
+	}
+
+	violations := []domain.RemoteWorkViolation{}
+	newViolation := func(rule, message string, limit, actual int) {
+		violations = append(violations, domain.RemoteWorkViolation{
+			OrganizationID: attendance.OrganizationID,
+			EmployeeID:     attendance.EmployeeID,
+			Date:           attendance.Date,
+			Rule:           rule,
+			Message:        message,
+			Limit:          limit,
+			Actual:         actual,
+		})
+	}
+
+	weekday := attendance.Date.Weekday()
+	if remotePolicy.RequireApproval || containsWeekday(remotePolicy.PreApprovalDays, weekday) {
+		newViolation(domain.RemoteRulePreApproval, fmt.Sprintf("remote work on %s requires an approved request", weekday), 0, 0)
+	}
+	if remotePolicy.MaxRemoteDaysPerWeek > 0 {
+		workWeek := s.employeeWorkWeek(policy, attendance.EmployeeID)
+		count, err := s.timeRepo.CountRemoteAttendances(attendance.EmployeeID, workWeek.StartOfWeek(attendance.Date), workWeek.EndOfWeek(attendance.Date))
+		if err != nil {
+			return nil, err
+		}
+		if remoteDays := int(count) + 1; remoteDays > remotePolicy.MaxRemoteDaysPerWeek {
+			newViolation(domain.RemoteRuleMaxDays, fmt.Sprintf("the weekly maximum of %d remote days is exceeded", remotePolicy.MaxRemoteDaysPerWeek), remotePolicy.MaxRemoteDaysPerWeek, remoteDays)
+		}
+	}
+
+	if len(violations) > 0 && remotePolicy.Enforcement == domain.RemoteEnforcementReject {
+		messages := make([]string, len(violations))
+		for i, violation := range violations {
+			messages[i] = violation.Message
+		}
+		return nil, apperrors.NewBusinessRuleError(apperrors.ErrRemoteWorkViolation, strings.Join(messages, "; "), violations)
+	}
+	return violations, nil
+}
+
+// flagRemoteWorkViolations records the violations of a completed check-in
+// and notifies the employee's manager. Failures are logged only.
+func (s *timeService) flagRemoteWorkViolations(attendance *domain.Attendance, violations []domain.RemoteWorkViolation) {
+	if len(violations) == 0 {
+		return
+	}
+	for i := range violations {
+		violations[i].AttendanceID = &attendance.ID
+	}
+	if err := s.timeRepo.CreateRemoteWorkViolations(violations); err != nil {
+		log.Printf("Failed to record remote work violations: %v", err)
+		return
+	}
+	if s.employees == nil {
+		return
+	}
+	managerID, err := s.employees.GetManagerID(attendance.OrganizationID, attendance.EmployeeID)
+	if err != nil {
+		log.Printf("Failed to resolve manager for employee %s: %v", attendance.EmployeeID, err)
+		return
+	}
+	rules := make([]string, len(violations))
+	for i, violation := range violations {
+		rules[i] = violation.Rule
+	}
+	s.emitNotification(attendance.OrganizationID, managerID, domain.NotificationRemoteWorkViolation, "attendance", &attendance.ID, map[string]interface{}{
+		"employee_id": attendance.EmployeeID,
+		"date":        utils.FormatDate(attendance.Date),
+		"rules":       rules,
+	})
+}
+
+func (s *timeService) notifyRemoteWorkRequested(request *domain.RemoteWorkRequest) {
+	if s.employees == nil {
+		return
+	}
+	managerID, err := s.employees.GetManagerID(request.OrganizationID, request.EmployeeID)
+	if err != nil {
+		log.Printf("Failed to resolve manager for employee %s: %v", request.EmployeeID, err)
+		return
+	}
+	s.emitNotification(request.OrganizationID, managerID, domain.NotificationRemoteWorkRequested, "remote_work_request", &request.ID, map[string]interface{}{
+		"employee_id": request.EmployeeID,
+		"start_date":  utils.FormatDate(request.StartDate),
+		"end_date":    utils.FormatDate(request.EndDate),
+	})
+}
+
+func (s *timeService) getRemoteWorkRequest(orgID, id uuid.UUID) (*domain.RemoteWorkRequest, error) {
+	request, err := s.timeRepo.GetRemoteWorkRequest(id)
+	if err != nil || request.OrganizationID != orgID {
+		return nil, apperrors.NewNotFoundError("remote work request not found")
+	}
+	return request, nil
+}
+
+func containsWeekday(days domain.Weekdays, weekday time.Weekday) bool {
+	for _, day := range days {
+		if time.Weekday(day) == weekday {
+			return true
+		}
+	}
+	return false
+}
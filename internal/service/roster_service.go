@@ -0,0 +1,564 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Axontik/comin-time-service/internal/domain"
+	apperrors "github.com/Axontik/comin-time-service/internal/errors"
+	"github.com/Axontik/comin-time-service/utils"
+	"github.com/google/uuid"
+)
+
+// lateDetectionWindow bounds how far a rostered shift may start from a
+// check-in for the check-in to be held to it
+const lateDetectionWindow = 12 * time.Hour
+
+func (s *timeService) CreateRoster(orgID uuid.UUID, req *domain.RosterRequest) (*domain.Roster, error) {
+	roster := &domain.Roster{OrganizationID: orgID, Status: domain.RosterStatusDraft}
+	if err := s.applyRosterRequest(roster, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.timeRepo.CreateRoster(roster); err != nil {
+		return nil, err
+	}
+	return roster, nil
+}
+
+func (s *timeService) GetRoster(orgID, id uuid.UUID) (*domain.Roster, error) {
+	return s.getRoster(orgID, id)
+}
+
+func (s *timeService) UpdateRoster(orgID, id uuid.UUID, req *domain.RosterRequest) (*domain.Roster, error) {
+	roster, err := s.draftRoster(orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyRosterRequest(roster, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.timeRepo.UpdateRoster(roster); err != nil {
+		return nil, err
+	}
+	return roster, nil
+}
+
+// DeleteRoster removes a draft roster with its shifts
+func (s *timeService) DeleteRoster(orgID, id uuid.UUID) error {
+	if _, err := s.draftRoster(orgID, id); err != nil {
+		return err
+	}
+	return s.timeRepo.DeleteRoster(id)
+}
+
+func (s *timeService) ListRosters(orgID uuid.UUID, startDate, endDate time.Time) ([]domain.Roster, error) {
+	return s.timeRepo.ListRosters(orgID, startDate, endDate)
+}
+
+// PublishRoster makes the roster's shifts visible to employees and binding
+// for late detection, and notifies the employees on it
+func (s *timeService) PublishRoster(orgID, id, userID uuid.UUID) (*domain.Roster, error) {
+	roster, err := s.draftRoster(orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(roster.Shifts) == 0 {
+		return nil, apperrors.NewBadRequestError("roster has no shifts")
+	}
+
+	now := time.Now()
+	roster.Status = domain.RosterStatusPublished
+	roster.PublishedAt = &now
+	roster.PublishedBy = &userID
+	if err := s.timeRepo.UpdateRoster(roster); err != nil {
+		return nil, err
+	}
+
+	shifts := map[uuid.UUID]int{}
+	for _, shift := range roster.Shifts {
+		if shift.EmployeeID != nil {
+			shifts[*shift.EmployeeID]++
+		}
+	}
+	for employeeID, count := range shifts {
+		employeeID := employeeID
+		s.emitNotification(orgID, &employeeID, domain.NotificationRosterPublished, "roster", &roster.ID, map[string]interface{}{
+			"name":       roster.Name,
+			"week_start": utils.FormatDate(roster.WeekStart),
+			"shifts":     count,
+		})
+	}
+	return roster, nil
+}
+
+// UnpublishRoster returns a published roster to draft for editing. Pending
+// swaps of its shifts are cancelled.
+func (s *timeService) UnpublishRoster(orgID, id uuid.UUID) (*domain.Roster, error) {
+	roster, err := s.getRoster(orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	if roster.Status != domain.RosterStatusPublished {
+		return nil, apperrors.NewInvalidStatusError("only published rosters can be unpublished")
+	}
+	if err := s.timeRepo.CancelPendingShiftSwaps(roster.ID); err != nil {
+		return nil, err
+	}
+
+	roster.Status = domain.RosterStatusDraft
+	roster.PublishedAt = nil
+	roster.PublishedBy = nil
+	if err := s.timeRepo.UpdateRoster(roster); err != nil {
+		return nil, err
+	}
+	return roster, nil
+}
+
+func (s *timeService) AddShift(orgID, rosterID uuid.UUID, req *domain.ShiftRequest) (*domain.Shift, error) {
+	roster, err := s.draftRoster(orgID, rosterID)
+	if err != nil {
+		return nil, err
+	}
+	shift := &domain.Shift{OrganizationID: orgID, RosterID: roster.ID}
+	if err := s.applyShiftRequest(roster, shift, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.timeRepo.CreateShift(shift); err != nil {
+		return nil, err
+	}
+	return shift, nil
+}
+
+func (s *timeService) UpdateShift(orgID, rosterID, id uuid.UUID, req *domain.ShiftRequest) (*domain.Shift, error) {
+	roster, err := s.draftRoster(orgID, rosterID)
+	if err != nil {
+		return nil, err
+	}
+	shift, err := s.getShift(orgID, id)
+	if err != nil || shift.RosterID != roster.ID {
+		return nil, apperrors.NewNotFoundError("shift not found")
+	}
+	if err := s.applyShiftRequest(roster, shift, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.timeRepo.UpdateShift(shift); err != nil {
+		return nil, err
+	}
+	return shift, nil
+}
+
+func (s *timeService) DeleteShift(orgID, rosterID, id uuid.UUID) error {
+	roster, err := s.draftRoster(orgID, rosterID)
+	if err != nil {
+		return err
+	}
+	shift, err := s.getShift(orgID, id)
+	if err != nil || shift.RosterID != roster.ID {
+		return apperrors.NewNotFoundError("shift not found")
+	}
+	return s.timeRepo.DeleteShift(id)
+}
+
+// ListEmployeeShifts returns the employee's schedule from published rosters
+func (s *timeService) ListEmployeeShifts(orgID, employeeID uuid.UUID, startDate, endDate time.Time) ([]domain.Shift, error) {
+	return s.timeRepo.ListShifts(orgID, &domain.ShiftFilter{
+		EmployeeID:    &employeeID,
+		PublishedOnly: true,
+		StartDate:     startDate,
+		EndDate:       endDate,
+	})
+}
+
+// ListOpenShifts returns the unassigned shifts of published rosters
+func (s *timeService) ListOpenShifts(orgID uuid.UUID, startDate, endDate time.Time) ([]domain.Shift, error) {
+	return s.timeRepo.ListShifts(orgID, &domain.ShiftFilter{
+		OpenOnly:      true,
+		PublishedOnly: true,
+		StartDate:     startDate,
+		EndDate:       endDate,
+	})
+}
+
+// ClaimOpenShift assigns an open shift of a published roster to the
+// employee, first come first served
+func (s *timeService) ClaimOpenShift(orgID, employeeID, id uuid.UUID) (*domain.Shift, error) {
+	shift, err := s.publishedShift(orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	if shift.EmployeeID != nil {
+		return nil, apperrors.NewConflictError("shift is not open")
+	}
+	if err := s.ensureNoShiftOverlap(employeeID, shift.StartsAt, shift.EndsAt); err != nil {
+		return nil, err
+	}
+
+	claimed, err := s.timeRepo.ClaimShift(shift.ID, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return nil, apperrors.NewConflictError("shift is not open")
+	}
+	shift.EmployeeID = &employeeID
+	return shift, nil
+}
+
+// CreateShiftSwapRequest asks to hand the employee's shift over to a
+// colleague, or to exchange it for one of theirs, pending manager approval
+func (s *timeService) CreateShiftSwapRequest(orgID, employeeID uuid.UUID, req *domain.CreateShiftSwapRequest) (*domain.ShiftSwapRequest, error) {
+	if req.TargetEmployeeID == employeeID {
+		return nil, apperrors.NewBadRequestError("target_employee_id must be a colleague")
+	}
+	shift, err := s.swappableShift(orgID, req.ShiftID, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	if req.TargetShiftID != nil {
+		if _, err := s.swappableShift(orgID, *req.TargetShiftID, req.TargetEmployeeID); err != nil {
+			return nil, err
+		}
+	}
+	pending, err := s.timeRepo.CountPendingShiftSwaps(shift.ID)
+	if err != nil {
+		return nil, err
+	}
+	if pending > 0 {
+		return nil, apperrors.NewConflictError("shift already has a pending swap request")
+	}
+
+	request := &domain.ShiftSwapRequest{
+		OrganizationID:   orgID,
+		ShiftID:          shift.ID,
+		RequesterID:      employeeID,
+		TargetEmployeeID: req.TargetEmployeeID,
+		TargetShiftID:    req.TargetShiftID,
+		Reason:           req.Reason,
+		Status:           domain.ShiftSwapPending,
+	}
+	if err := s.timeRepo.CreateShiftSwapRequest(request); err != nil {
+		return nil, err
+	}
+	s.notifyShiftSwapRequested(request, shift)
+	return request, nil
+}
+
+func (s *timeService) ListShiftSwapRequests(orgID uuid.UUID, filter *domain.ShiftSwapFilter) ([]domain.ShiftSwapRequest, error) {
+	return s.timeRepo.ListShiftSwapRequests(orgID, filter)
+}
+
+// ApproveShiftSwapRequest reassigns the shifts of a pending swap, provided
+// both employees still hold them and neither ends up double-booked
+func (s *timeService) ApproveShiftSwapRequest(orgID, id, reviewerID uuid.UUID, req *domain.ReviewShiftSwapRequest) (*domain.ShiftSwapRequest, error) {
+	request, err := s.pendingShiftSwap(orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	shift, err := s.swappableShift(orgID, request.ShiftID, request.RequesterID)
+	if err != nil {
+		return nil, err
+	}
+	var targetShift *domain.Shift
+	if request.TargetShiftID != nil {
+		if targetShift, err = s.swappableShift(orgID, *request.TargetShiftID, request.TargetEmployeeID); err != nil {
+			return nil, err
+		}
+	}
+
+	shift.EmployeeID = &request.TargetEmployeeID
+	shifts := []domain.Shift{*shift}
+	if targetShift != nil {
+		if err := s.ensureNoShiftOverlap(request.TargetEmployeeID, shift.StartsAt, shift.EndsAt, targetShift.ID); err != nil {
+			return nil, err
+		}
+		if err := s.ensureNoShiftOverlap(request.RequesterID, targetShift.StartsAt, targetShift.EndsAt, shift.ID); err != nil {
+			return nil, err
+		}
+		targetShift.EmployeeID = &request.RequesterID
+		shifts = append(shifts, *targetShift)
+	} else if err := s.ensureNoShiftOverlap(request.TargetEmployeeID, shift.StartsAt, shift.EndsAt); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	request.Status = domain.ShiftSwapApproved
+	request.ReviewedBy = &reviewerID
+	request.ReviewedAt = &now
+	request.ReviewNote = req.Note
+	if err := s.timeRepo.ApplyShiftSwap(request, shifts); err != nil {
+		return nil, err
+	}
+	s.notifyShiftSwapReviewed(request)
+	return request, nil
+}
+
+func (s *timeService) RejectShiftSwapRequest(orgID, id, reviewerID uuid.UUID, req *domain.ReviewShiftSwapRequest) (*domain.ShiftSwapRequest, error) {
+	request, err := s.pendingShiftSwap(orgID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	request.Status = domain.ShiftSwapRejected
+	request.ReviewedBy = &reviewerID
+	request.ReviewedAt = &now
+	request.ReviewNote = req.Note
+	if err := s.timeRepo.UpdateShiftSwapRequest(request); err != nil {
+		return nil, err
+	}
+	s.notifyShiftSwapReviewed(request)
+	return request, nil
+}
+
+func (s *timeService) CancelShiftSwapRequest(orgID, employeeID, id uuid.UUID) (*domain.ShiftSwapRequest, error) {
+	request, err := s.getShiftSwapRequest(orgID, id)
+	if err != nil || request.RequesterID != employeeID {
+		return nil, apperrors.NewNotFoundError("shift swap request not found")
+	}
+	if request.Status != domain.ShiftSwapPending {
+		return nil, apperrors.NewInvalidStatusError("only pending shift swap requests can be cancelled")
+	}
+
+	request.Status = domain.ShiftSwapCancelled
+	if err := s.timeRepo.UpdateShiftSwapRequest(request); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// applyRosteredShift holds a check-in to the employee's published shift
+// starting closest to it: check-ins after the shift start plus the grace
+// period are late. Lookup failures are logged and skip late detection.
+func (s *timeService) applyRosteredShift(policy *domain.TimesheetPolicy, attendance *domain.Attendance) {
+	checkIn := *attendance.CheckIn
+	shifts, err := s.timeRepo.ListScheduledShifts(attendance.EmployeeID, checkIn.Add(-lateDetectionWindow), checkIn.Add(lateDetectionWindow))
+	if err != nil {
+		log.Printf("Failed to load rostered shifts: %v", err)
+		return
+	}
+	var closest *domain.Shift
+	for i := range shifts {
+		if closest == nil || absDuration(shifts[i].StartsAt.Sub(checkIn)) < absDuration(closest.StartsAt.Sub(checkIn)) {
+			closest = &shifts[i]
+		}
+	}
+	if closest == nil {
+		return
+	}
+
+	attendance.ShiftID = &closest.ID
+	grace := time.Duration(policy.LateGraceMinutes) * time.Minute
+	if attendance.Status == domain.AttendanceStatusPresent && checkIn.After(closest.StartsAt.Add(grace)) {
+		attendance.Status = domain.AttendanceStatusLate
+	}
+}
+
+func (s *timeService) applyRosterRequest(roster *domain.Roster, req *domain.RosterRequest) error {
+	date, err := utils.ParseDate(req.WeekStart)
+	if err != nil {
+		return apperrors.NewBadRequestError("week_start must be in YYYY-MM-DD format")
+	}
+	if req.TeamID != nil {
+		if _, err := s.getTeam(roster.OrganizationID, *req.TeamID); err != nil {
+			return err
+		}
+	}
+	policy, err := s.GetTimesheetPolicy(roster.OrganizationID)
+	if err != nil {
+		return err
+	}
+
+	weekStart := policyWorkWeek(policy).StartOfWeek(date)
+	if len(roster.Shifts) > 0 && !weekStart.Equal(roster.WeekStart) {
+		return apperrors.NewBadRequestError("week_start cannot change while the roster has shifts")
+	}
+	roster.Name = req.Name
+	roster.TeamID = req.TeamID
+	roster.WeekStart = weekStart
+	return nil
+}
+
+// applyShiftRequest sets the shift's times in the policy timezone. Shifts
+// must start in the roster's week and must not overlap the employee's
+// other shifts.
+func (s *timeService) applyShiftRequest(roster *domain.Roster, shift *domain.Shift, req *domain.ShiftRequest) error {
+	date, err := utils.ParseDate(req.Date)
+	if err != nil {
+		return apperrors.NewBadRequestError("date must be in YYYY-MM-DD format")
+	}
+	if date.Before(roster.WeekStart) || !date.Before(roster.WeekStart.AddDate(0, 0, 7)) {
+		return apperrors.NewBadRequestError(fmt.Sprintf("date must be in the roster's week starting %s", utils.FormatDate(roster.WeekStart)))
+	}
+	startMinutes, ok := parseClock(req.StartTime)
+	if !ok {
+		return apperrors.NewBadRequestError("start_time must be in HH:MM format")
+	}
+	endMinutes, ok := parseClock(req.EndTime)
+	if !ok {
+		return apperrors.NewBadRequestError("end_time must be in HH:MM format")
+	}
+
+	policy, err := s.GetTimesheetPolicy(roster.OrganizationID)
+	if err != nil {
+		return err
+	}
+	loc, err := time.LoadLocation(policy.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	startsAt := time.Date(date.Year(), date.Month(), date.Day(), 0, startMinutes, 0, 0, loc)
+	endDay := date
+	if endMinutes <= startMinutes {
+		endDay = date.AddDate(0, 0, 1)
+	}
+	endsAt := time.Date(endDay.Year(), endDay.Month(), endDay.Day(), 0, endMinutes, 0, 0, loc)
+
+	if req.EmployeeID != nil {
+		if err := s.ensureNoShiftOverlap(*req.EmployeeID, startsAt, endsAt, shift.ID); err != nil {
+			return err
+		}
+	}
+	shift.EmployeeID = req.EmployeeID
+	shift.Date = date
+	shift.StartsAt = startsAt
+	shift.EndsAt = endsAt
+	shift.Role = req.Role
+	shift.Notes = req.Notes
+	return nil
+}
+
+// ensureNoShiftOverlap rejects double-booking the employee, ignoring the
+// excluded shifts
+func (s *timeService) ensureNoShiftOverlap(employeeID uuid.UUID, startsAt, endsAt time.Time, excluded ...uuid.UUID) error {
+	shifts, err := s.timeRepo.ListOverlappingShifts(employeeID, startsAt, endsAt)
+	if err != nil {
+		return err
+	}
+	for _, other := range shifts {
+		skip := false
+		for _, id := range excluded {
+			skip = skip || other.ID == id
+		}
+		if !skip {
+			return apperrors.NewConflictError(fmt.Sprintf("employee already has a shift from %s to %s",
+				other.StartsAt.Format(time.RFC3339), other.EndsAt.Format(time.RFC3339)))
+		}
+	}
+	return nil
+}
+
+// swappableShift returns a published shift of the employee that has not
+// started yet
+func (s *timeService) swappableShift(orgID, id, employeeID uuid.UUID) (*domain.Shift, error) {
+	shift, err := s.publishedShift(orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	if shift.EmployeeID == nil || *shift.EmployeeID != employeeID {
+		return nil, apperrors.NewConflictError("shift is not assigned to the employee")
+	}
+	if !shift.StartsAt.After(time.Now()) {
+		return nil, apperrors.NewInvalidStatusError("shift has already started")
+	}
+	return shift, nil
+}
+
+// publishedShift returns a shift of a published roster
+func (s *timeService) publishedShift(orgID, id uuid.UUID) (*domain.Shift, error) {
+	shift, err := s.getShift(orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	roster, err := s.getRoster(orgID, shift.RosterID)
+	if err != nil || roster.Status != domain.RosterStatusPublished {
+		return nil, apperrors.NewNotFoundError("shift not found")
+	}
+	return shift, nil
+}
+
+func (s *timeService) draftRoster(orgID, id uuid.UUID) (*domain.Roster, error) {
+	roster, err := s.getRoster(orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	if roster.Status != domain.RosterStatusDraft {
+		return nil, apperrors.NewInvalidStatusError("published rosters must be unpublished before they are changed")
+	}
+	return roster, nil
+}
+
+func (s *timeService) pendingShiftSwap(orgID, id uuid.UUID) (*domain.ShiftSwapRequest, error) {
+	request, err := s.getShiftSwapRequest(orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	if request.Status != domain.ShiftSwapPending {
+		return nil, apperrors.NewInvalidStatusError("only pending shift swap requests can be reviewed")
+	}
+	return request, nil
+}
+
+func (s *timeService) notifyShiftSwapRequested(request *domain.ShiftSwapRequest, shift *domain.Shift) {
+	if s.employees == nil {
+		return
+	}
+	managerID, err := s.employees.GetManagerID(request.OrganizationID, request.RequesterID)
+	if err != nil {
+		log.Printf("Failed to resolve manager for employee %s: %v", request.RequesterID, err)
+		return
+	}
+	s.emitNotification(request.OrganizationID, managerID, domain.NotificationShiftSwapRequested, "shift_swap_request", &request.ID, map[string]interface{}{
+		"requester_id":       request.RequesterID,
+		"target_employee_id": request.TargetEmployeeID,
+		"shift_id":           shift.ID,
+		"starts_at":          shift.StartsAt,
+	})
+}
+
+func (s *timeService) notifyShiftSwapReviewed(request *domain.ShiftSwapRequest) {
+	payload := map[string]interface{}{
+		"status":   request.Status,
+		"shift_id": request.ShiftID,
+		"note":     request.ReviewNote,
+	}
+	s.emitNotification(request.OrganizationID, &request.RequesterID, domain.NotificationShiftSwapReviewed, "shift_swap_request", &request.ID, payload)
+	if request.Status == domain.ShiftSwapApproved {
+		s.emitNotification(request.OrganizationID, &request.TargetEmployeeID, domain.NotificationShiftSwapReviewed, "shift_swap_request", &request.ID, payload)
+	}
+}
+
+func (s *timeService) getRoster(orgID, id uuid.UUID) (*domain.Roster, error) {
+	roster, err := s.timeRepo.GetRoster(id)
+	if err != nil || roster.OrganizationID != orgID {
+		return nil, apperrors.NewNotFoundError("roster not found")
+	}
+	return roster, nil
+}
+
+func (s *timeService) getShift(orgID, id uuid.UUID) (*domain.Shift, error) {
+	shift, err := s.timeRepo.GetShift(id)
+	if err != nil || shift.OrganizationID != orgID {
+		return nil, apperrors.NewNotFoundError("shift not found")
+	}
+	return shift, nil
+}
+
+func (s *timeService) getShiftSwapRequest(orgID, id uuid.UUID) (*domain.ShiftSwapRequest, error) {
+	request, err := s.timeRepo.GetShiftSwapRequest(id)
+	if err != nil || request.OrganizationID != orgID {
+		return nil, apperrors.NewNotFoundError("shift swap request not found")
+	}
+	return request, nil
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"github.com/Axontik/comin-time-service/internal/domain"
+	apperrors "github.com/Axontik/comin-time-service/internal/errors"
+	"github.com/google/uuid"
+)
+
+func (s *timeService) CreateTeam(orgID uuid.UUID, req *domain.TeamRequest) (*domain.Team, error) {
+	team := &domain.Team{
+		OrganizationID: orgID,
+		Name:           req.Name,
+		AlwaysRemote:   req.AlwaysRemote,
+	}
+	if err := s.timeRepo.CreateTeam(team); err != nil {
+		return nil, err
+	}
+	return team, nil
+}
+
+func (s *timeService) UpdateTeam(orgID, id uuid.UUID, req *domain.TeamRequest) (*domain.Team, error) {
+	team, err := s.getTeam(orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	team.Name = req.Name
+	team.AlwaysRemote = req.AlwaysRemote
+
+	if err := s.timeRepo.UpdateTeam(team); err != nil {
+		return nil, err
+	}
+	return team, nil
+}
+
+// DeleteTeam removes a team; its members are left without a team
+func (s *timeService) DeleteTeam(orgID, id uuid.UUID) error {
+	if _, err := s.getTeam(orgID, id); err != nil {
+		return err
+	}
+	return s.timeRepo.DeleteTeam(id)
+}
+
+func (s *timeService) ListTeams(orgID uuid.UUID) ([]domain.Team, error) {
+	return s.timeRepo.ListTeams(orgID)
+}
+
+// SetEmployeeTeam assigns the employee to a team; no team removes the
+// employee from their current one.
+func (s *timeService) SetEmployeeTeam(orgID, employeeID uuid.UUID, req *domain.EmployeeTeamRequest) (*domain.EmployeeTeam, error) {
+	if req.TeamID == nil {
+		if err := s.timeRepo.DeleteEmployeeTeam(orgID, employeeID); err != nil {
+			return nil, err
+		}
+		return &domain.EmployeeTeam{OrganizationID: orgID, EmployeeID: employeeID}, nil
+	}
+	if _, err := s.getTeam(orgID, *req.TeamID); err != nil {
+		return nil, err
+	}
+
+	membership, err := s.timeRepo.GetEmployeeTeam(orgID, employeeID)
+	if err != nil {
+		membership = &domain.EmployeeTeam{OrganizationID: orgID, EmployeeID: employeeID}
+	}
+	membership.TeamID = *req.TeamID
+	if err := s.timeRepo.SaveEmployeeTeam(membership); err != nil {
+		return nil, err
+	}
+	return membership, nil
+}
+
+// employeeTeam returns the employee's team, or nil when they have none
+func (s *timeService) employeeTeam(orgID, employeeID uuid.UUID) *domain.Team {
+	membership, err := s.timeRepo.GetEmployeeTeam(orgID, employeeID)
+	if err != nil {
+		return nil
+	}
+	team, err := s.timeRepo.GetTeam(membership.TeamID)
+	if err != nil {
+		return nil
+	}
+	return team
+}
+
+func (s *timeService) getTeam(orgID, id uuid.UUID) (*domain.Team, error) {
+	team, err := s.timeRepo.GetTeam(id)
+	if err != nil || team.OrganizationID != orgID {
+		return nil, apperrors.NewNotFoundError("team not found")
+	}
+	return team, nil
+}
@@ -0,0 +1,283 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Axontik/comin-time-service/internal/domain"
+	apperrors "github.com/Axontik/comin-time-service/internal/errors"
+	"github.com/Axontik/comin-time-service/utils"
+	"github.com/google/uuid"
+)
+
+func (s *timeService) CreateTimesheetTemplate(orgID, employeeID uuid.UUID, req *domain.TimesheetTemplateRequest) (*domain.TimesheetTemplate, error) {
+	template := &domain.TimesheetTemplate{
+		OrganizationID: orgID,
+		EmployeeID:     employeeID,
+	}
+	applyTimesheetTemplateRequest(template, req)
+
+	if err := s.timeRepo.CreateTimesheetTemplate(template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+func (s *timeService) UpdateTimesheetTemplate(orgID, employeeID, id uuid.UUID, req *domain.TimesheetTemplateRequest) (*domain.TimesheetTemplate, error) {
+	template, err := s.getEmployeeTemplate(orgID, employeeID, id)
+	if err != nil {
+		return nil, err
+	}
+	applyTimesheetTemplateRequest(template, req)
+
+	if err := s.timeRepo.UpdateTimesheetTemplate(template); err != nil {
+		return nil, err
+	}
+	return s.timeRepo.GetTimesheetTemplate(id)
+}
+
+func (s *timeService) DeleteTimesheetTemplate(orgID, employeeID, id uuid.UUID) error {
+	if _, err := s.getEmployeeTemplate(orgID, employeeID, id); err != nil {
+		return err
+	}
+	return s.timeRepo.DeleteTimesheetTemplate(id)
+}
+
+func (s *timeService) ListTimesheetTemplates(orgID, employeeID uuid.UUID) ([]domain.TimesheetTemplate, error) {
+	return s.timeRepo.ListTimesheetTemplates(orgID, employeeID)
+}
+
+// ApplyTimesheetTemplate creates draft entries from the template lines on
+// the matching days of the requested week.
+func (s *timeService) ApplyTimesheetTemplate(orgID, employeeID, id uuid.UUID, req *domain.ApplyTemplateRequest) (*domain.BulkTimesheetResult, error) {
+	template, err := s.getEmployeeTemplate(orgID, employeeID, id)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := s.GetTimesheetPolicy(orgID)
+	if err != nil {
+		return nil, err
+	}
+	weekStart, err := parseWeekStart(req.WeekStart, s.employeeWorkWeek(policy, employeeID))
+	if err != nil {
+		return nil, err
+	}
+
+	requests := make([]domain.CreateTimesheetRequest, 0, len(template.Lines))
+	for _, line := range template.Lines {
+		offset := (line.Weekday - int(weekStart.Weekday()) + 7) % 7
+		requests = append(requests, domain.CreateTimesheetRequest{
+			ProjectID:   line.ProjectID,
+			TaskID:      line.TaskID,
+			Description: line.Description,
+			Date:        utils.FormatDate(weekStart.AddDate(0, 0, offset)),
+			Hours:       line.Hours,
+			StartTime:   line.StartTime,
+			EndTime:     line.EndTime,
+			Billable:    line.Billable,
+			Notes:       line.Notes,
+		})
+	}
+
+	return s.createDraftTimesheets(orgID, employeeID, requests), nil
+}
+
+// CopyPreviousWeek clones the entries of the week before the requested week
+// as drafts, shifted by seven days and optionally without their hours.
+func (s *timeService) CopyPreviousWeek(orgID, employeeID uuid.UUID, req *domain.CopyPreviousWeekRequest) (*domain.BulkTimesheetResult, error) {
+	policy, err := s.GetTimesheetPolicy(orgID)
+	if err != nil {
+		return nil, err
+	}
+	weekStart, err := parseWeekStart(req.WeekStart, s.employeeWorkWeek(policy, employeeID))
+	if err != nil {
+		return nil, err
+	}
+	loc, err := time.LoadLocation(policy.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	previousStart := weekStart.AddDate(0, 0, -7)
+	timesheets, err := s.timeRepo.ListTimesheets(orgID, employeeID, previousStart, weekStart.AddDate(0, 0, -1))
+	if err != nil {
+		return nil, err
+	}
+
+	requests := []domain.CreateTimesheetRequest{}
+	for _, timesheet := range timesheets {
+		if timesheet.Status == domain.TimesheetStatusRejected {
+			continue
+		}
+
+		billable := timesheet.IsBillable
+		request := domain.CreateTimesheetRequest{
+			ProjectID:   timesheet.ProjectID,
+			TaskID:      timesheet.TaskID,
+			Description: timesheet.Description,
+			Date:        utils.FormatDate(timesheet.Date.AddDate(0, 0, 7)),
+			Billable:    &billable,
+		}
+		if !req.ZeroHours {
+			request.Hours = timesheet.Hours
+			if timesheet.StartTime != nil && timesheet.EndTime != nil {
+				request.StartTime = timesheet.StartTime.In(loc).Format("15:04:05")
+				request.EndTime = timesheet.EndTime.In(loc).Format("15:04:05")
+			}
+		}
+		requests = append(requests, request)
+	}
+
+	return s.createDraftTimesheets(orgID, employeeID, requests), nil
+}
+
+// SubmitTimesheets sends the employee's drafts in the date range for
+// approval. Drafts must have hours before they can be submitted.
+func (s *timeService) SubmitTimesheets(orgID, employeeID, userID uuid.UUID, startDate, endDate time.Time) ([]domain.TimesheetResponse, error) {
+	timesheets, err := s.timeRepo.ListTimesheets(orgID, employeeID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := s.GetTimesheetPolicy(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	drafts := []domain.Timesheet{}
+	missingHours := []uuid.UUID{}
+	for _, timesheet := range timesheets {
+		if timesheet.Status != domain.TimesheetStatusDraft {
+			continue
+		}
+		if timesheet.Hours <= 0 {
+			missingHours = append(missingHours, timesheet.ID)
+		}
+		drafts = append(drafts, timesheet)
+	}
+	if len(missingHours) > 0 {
+		return nil, apperrors.NewValidationError(map[string]interface{}{
+			"hours":         "drafts must have hours before they are submitted",
+			"timesheet_ids": missingHours,
+		})
+	}
+
+	if err := s.validateSubmissionReconciled(policy, employeeID, drafts); err != nil {
+		return nil, err
+	}
+
+	// Drafts may have been saved with partial data, so each one goes through
+	// the rules of a pending entry again. The range is submitted in one
+	// transaction: later drafts are checked against the earlier ones and
+	// nothing is submitted unless every draft passes.
+	err = s.inTransaction(func(tx *timeService) error {
+		for i := range drafts {
+			timesheet := &drafts[i]
+			previous := *timesheet
+			timesheet.Status = domain.TimesheetStatusPending
+			if err := tx.validateSubmittedDraft(policy, timesheet, &previous); err != nil {
+				return err
+			}
+			if err := tx.timeRepo.UpdateTimesheet(timesheet); err != nil {
+				return err
+			}
+			tx.recordTimesheetChanges(&previous, timesheet, userID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Approvers are notified and budget alerts raised only once the
+	// submission is committed
+	for i := range drafts {
+		s.assignInitialApprover(&drafts[i])
+		if drafts[i].ProjectID != nil {
+			s.checkBudgetAlerts(*drafts[i].ProjectID, drafts[i].TaskID)
+		}
+	}
+
+	return s.timesheetResponses(drafts)
+}
+
+// validateSubmittedDraft runs the checks a draft skipped or may have failed
+// since it was saved, for the entry as it will be submitted
+func (s *timeService) validateSubmittedDraft(policy *domain.TimesheetPolicy, timesheet, previous *domain.Timesheet) error {
+	if err := s.validateTimesheetDate(policy, timesheet.EmployeeID, timesheet.Date); err != nil {
+		return err
+	}
+	if _, err := s.validateTimesheetProject(timesheet); err != nil {
+		return err
+	}
+	if err := s.validateProjectBudget(timesheet); err != nil {
+		return err
+	}
+	if err := s.validateTimesheetOverlap(timesheet); err != nil {
+		return err
+	}
+	if _, err := s.validateTimesheetHours(policy, timesheet, previous); err != nil {
+		return err
+	}
+	_, err := s.validateTimesheetCompliance(timesheet)
+	return err
+}
+
+// createDraftTimesheets runs every request through the CreateTimesheet rules
+// and reports the ones that failed instead of stopping at the first error.
+func (s *timeService) createDraftTimesheets(orgID, employeeID uuid.UUID, requests []domain.CreateTimesheetRequest) *domain.BulkTimesheetResult {
+	result := &domain.BulkTimesheetResult{
+		Created: []domain.TimesheetResult{},
+		Failed:  []domain.FailedTimesheet{},
+	}
+
+	for i := range requests {
+		created, err := s.createTimesheet(orgID, employeeID, &requests[i], domain.TimesheetStatusDraft)
+		if err != nil {
+			var appErr *apperrors.AppError
+			failure := domain.FailedTimesheet{Request: requests[i], Error: map[string]string{"message": err.Error()}}
+			if errors.As(err, &appErr) {
+				failure.Error = appErr
+			}
+			result.Failed = append(result.Failed, failure)
+			continue
+		}
+		result.Created = append(result.Created, *created)
+	}
+
+	return result
+}
+
+func (s *timeService) getEmployeeTemplate(orgID, employeeID, id uuid.UUID) (*domain.TimesheetTemplate, error) {
+	template, err := s.timeRepo.GetTimesheetTemplate(id)
+	if err != nil || template.OrganizationID != orgID || template.EmployeeID != employeeID {
+		return nil, apperrors.NewNotFoundError("template not found")
+	}
+	return template, nil
+}
+
+func applyTimesheetTemplateRequest(template *domain.TimesheetTemplate, req *domain.TimesheetTemplateRequest) {
+	template.Name = req.Name
+	template.Lines = make([]domain.TimesheetTemplateLine, 0, len(req.Lines))
+	for _, line := range req.Lines {
+		template.Lines = append(template.Lines, domain.TimesheetTemplateLine{
+			Weekday:     line.Weekday,
+			ProjectID:   line.ProjectID,
+			TaskID:      line.TaskID,
+			Description: line.Description,
+			Hours:       line.Hours,
+			StartTime:   line.StartTime,
+			EndTime:     line.EndTime,
+			Billable:    line.Billable,
+			Notes:       line.Notes,
+		})
+	}
+}
+
+// parseWeekStart returns the first day of the work week containing the date
+func parseWeekStart(value string, week utils.WorkWeek) (time.Time, error) {
+	date, err := utils.ParseDate(value)
+	if err != nil {
+		return time.Time{}, apperrors.NewBadRequestError("week_start must be in YYYY-MM-DD format")
+	}
+	return week.StartOfWeek(date), nil
+}
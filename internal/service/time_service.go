@@ -4,12 +4,17 @@ import (
 	"crypto/rand"
 	"encoding/base64"
 	"errors"
+	"io"
 	"log"
+	"math"
 	"time"
 
 	"github.com/Axontik/comin-time-service/internal/domain"
+	apperrors "github.com/Axontik/comin-time-service/internal/errors"
 	"github.com/Axontik/comin-time-service/internal/repository"
+	"github.com/Axontik/comin-time-service/utils"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type TimeService interface {
@@ -19,30 +24,233 @@ type TimeService interface {
 	GetEmployeeQRCodes(orgID, employeeID uuid.UUID) ([]domain.QRCode, error)
 
 	// Attendance methods
-	CheckIn(req *domain.CheckInRequest) (*domain.Attendance, error)
-	CheckOut(req *domain.CheckOutRequest) (*domain.Attendance, error)
+	CheckIn(req *domain.CheckInRequest) (*domain.AttendanceResult, error)
+	CheckOut(req *domain.CheckOutRequest) (*domain.AttendanceResult, error)
+	StartBreak(req *domain.BreakRequest) (*domain.Attendance, error)
+	EndBreak(req *domain.BreakRequest) (*domain.Attendance, error)
 	GetAttendanceByDate(employeeID uuid.UUID, date time.Time) (*domain.Attendance, error)
-	GetAttendanceSummary(employeeID uuid.UUID, month, year int) (map[string]int, error)
-	ListAttendances(orgID uuid.UUID) ([]domain.Attendance, error)
+	GetAttendanceSummary(orgID, employeeID uuid.UUID, month, year int) (map[string]int, error)
+	ListAttendances(orgID uuid.UUID, filter *domain.AttendanceFilter) ([]domain.Attendance, error)
 
 	// Timesheet methods
-	CreateTimesheet(orgID, employeeID uuid.UUID, req *domain.CreateTimesheetRequest) (*domain.Timesheet, error)
-	GetTimesheet(id uuid.UUID) (*domain.Timesheet, error)
-	UpdateTimesheet(id uuid.UUID, req *domain.CreateTimesheetRequest) (*domain.Timesheet, error)
-	DeleteTimesheet(id uuid.UUID) error
-	ListTimesheets(orgID, employeeID uuid.UUID, startDate, endDate time.Time) ([]domain.Timesheet, error)
-	ApproveTimesheet(id, approverID uuid.UUID) error
-	RejectTimesheet(id, approverID uuid.UUID, reason string) error
+	CreateTimesheet(orgID, employeeID uuid.UUID, req *domain.CreateTimesheetRequest) (*domain.TimesheetResult, error)
+	GetTimesheet(id uuid.UUID) (*domain.TimesheetResponse, error)
+	UpdateTimesheet(id, userID uuid.UUID, req *domain.CreateTimesheetRequest) (*domain.TimesheetResult, error)
+	DeleteTimesheet(id, userID uuid.UUID) error
+	RestoreTimesheet(orgID, employeeID, id, userID uuid.UUID) (*domain.TimesheetResult, error)
+	ListDeletedTimesheets(orgID, employeeID uuid.UUID, startDate, endDate time.Time) ([]domain.TimesheetResponse, error)
+	PurgeDeletedTimesheets(orgID uuid.UUID) (int64, error)
+	ListTimesheets(orgID, employeeID uuid.UUID, startDate, endDate time.Time) ([]domain.TimesheetResponse, error)
+	ApproveTimesheet(orgID, employeeID, id, approverID uuid.UUID, isAdmin bool) error
+	RejectTimesheet(orgID, employeeID, id, approverID uuid.UUID, isAdmin bool, reason string) error
+
+	// Project methods
+	CreateProject(orgID uuid.UUID, req *domain.ProjectRequest) (*domain.Project, error)
+	GetProject(orgID, id uuid.UUID) (*domain.Project, error)
+	UpdateProject(orgID, id uuid.UUID, req *domain.ProjectRequest) (*domain.Project, error)
+	ListProjects(orgID uuid.UUID, status string) ([]domain.Project, error)
+	CreateTask(orgID, projectID uuid.UUID, req *domain.TaskRequest) (*domain.Task, error)
+	UpdateTask(orgID, projectID, id uuid.UUID, req *domain.TaskRequest) (*domain.Task, error)
+	ListTasks(orgID, projectID uuid.UUID) ([]domain.Task, error)
+	AddProjectMember(orgID, projectID uuid.UUID, req *domain.ProjectMemberRequest) (*domain.ProjectMember, error)
+	RemoveProjectMember(orgID, projectID, employeeID uuid.UUID) error
+
+	// Budget methods
+	CreateProjectBudget(orgID, projectID uuid.UUID, req *domain.ProjectBudgetRequest) (*domain.ProjectBudget, error)
+	UpdateProjectBudget(orgID, projectID, id uuid.UUID, req *domain.ProjectBudgetRequest) (*domain.ProjectBudget, error)
+	DeleteProjectBudget(orgID, projectID, id uuid.UUID) error
+	ListProjectBudgets(orgID, projectID uuid.UUID) ([]domain.BudgetStatus, error)
+	GetProjectBurnDown(orgID, projectID uuid.UUID, taskID *uuid.UUID) (*domain.BurnDownResponse, error)
+
+	// Rate card methods
+	CreateRateCard(orgID uuid.UUID, req *domain.RateCardRequest) (*domain.RateCard, error)
+	UpdateRateCard(orgID, id uuid.UUID, req *domain.RateCardRequest) (*domain.RateCard, error)
+	DeleteRateCard(orgID, id uuid.UUID) error
+	ListRateCards(orgID uuid.UUID) ([]domain.RateCard, error)
+
+	// Invoice methods
+	CreateInvoice(orgID, userID uuid.UUID, req *domain.CreateInvoiceRequest) (*domain.Invoice, error)
+	GetInvoice(orgID, id uuid.UUID) (*domain.Invoice, error)
+	ListInvoices(orgID uuid.UUID) ([]domain.Invoice, error)
+	DeleteInvoice(orgID, id uuid.UUID) error
+	ExportInvoice(orgID, id uuid.UUID, format string) (*domain.InvoiceExport, error)
+
+	// Template methods
+	CreateTimesheetTemplate(orgID, employeeID uuid.UUID, req *domain.TimesheetTemplateRequest) (*domain.TimesheetTemplate, error)
+	UpdateTimesheetTemplate(orgID, employeeID, id uuid.UUID, req *domain.TimesheetTemplateRequest) (*domain.TimesheetTemplate, error)
+	DeleteTimesheetTemplate(orgID, employeeID, id uuid.UUID) error
+	ListTimesheetTemplates(orgID, employeeID uuid.UUID) ([]domain.TimesheetTemplate, error)
+	ApplyTimesheetTemplate(orgID, employeeID, id uuid.UUID, req *domain.ApplyTemplateRequest) (*domain.BulkTimesheetResult, error)
+	CopyPreviousWeek(orgID, employeeID uuid.UUID, req *domain.CopyPreviousWeekRequest) (*domain.BulkTimesheetResult, error)
+	SubmitTimesheets(orgID, employeeID, userID uuid.UUID, startDate, endDate time.Time) ([]domain.TimesheetResponse, error)
+
+	// Comment methods
+	AddTimesheetComment(orgID, employeeID, id, authorID uuid.UUID, req *domain.TimesheetCommentRequest) (*domain.TimesheetComment, error)
+	ListTimesheetComments(orgID, employeeID, id uuid.UUID) ([]domain.TimesheetComment, error)
+	AddPeriodComment(orgID, employeeID, authorID uuid.UUID, periodStart time.Time, req *domain.TimesheetCommentRequest) (*domain.TimesheetComment, error)
+	ListPeriodComments(orgID, employeeID uuid.UUID, periodStart time.Time) ([]domain.TimesheetComment, error)
+	ListTimesheetHistory(orgID, employeeID, id uuid.UUID) ([]domain.TimesheetChange, error)
+
+	// Timer methods
+	StartTimer(orgID, employeeID uuid.UUID, req *domain.StartTimerRequest) (*domain.Timer, error)
+	GetTimer(orgID, employeeID, id uuid.UUID) (*domain.Timer, error)
+	ListActiveTimers(orgID, employeeID uuid.UUID) ([]domain.Timer, error)
+	PauseTimer(orgID, employeeID, id uuid.UUID) (*domain.Timer, error)
+	ResumeTimer(orgID, employeeID, id uuid.UUID) (*domain.Timer, error)
+	StopTimer(orgID, employeeID, id uuid.UUID) (*domain.StopTimerResponse, error)
+	DiscardTimer(orgID, employeeID, id uuid.UUID) (*domain.Timer, error)
+
+	// Policy methods
+	GetTimesheetPolicy(orgID uuid.UUID) (*domain.TimesheetPolicy, error)
+	UpdateTimesheetPolicy(orgID uuid.UUID, req *domain.TimesheetPolicyRequest) (*domain.TimesheetPolicy, error)
+	GetEmployeeWorkWeek(orgID, employeeID uuid.UUID) (*domain.WorkWeek, error)
+	SetEmployeeWorkWeek(orgID, employeeID uuid.UUID, req *domain.EmployeeWorkWeekRequest) (*domain.WorkWeek, error)
+
+	// Report methods
+	GetReconciliationReport(orgID uuid.UUID, filter *domain.ReconciliationFilter) (*domain.ReconciliationReport, error)
+	GetOvertimeReport(orgID uuid.UUID, startDate, endDate time.Time) ([]domain.OvertimeSummary, error)
+	GetComplianceReport(orgID uuid.UUID, filter *domain.ComplianceFilter) (*domain.ComplianceReport, error)
+	GetOnCallReport(orgID uuid.UUID, employeeID *uuid.UUID, startDate, endDate time.Time) (*domain.OnCallReport, error)
+	GetUtilizationReport(orgID uuid.UUID, filter *domain.UtilizationFilter) (*domain.UtilizationReport, error)
+	GetAttendanceReport(orgID uuid.UUID, filter *domain.AttendanceReportFilter) (*domain.AttendanceReport, error)
+
+	// Compliance methods
+	CreateComplianceRuleSet(orgID uuid.UUID, req *domain.ComplianceRuleSetRequest) (*domain.ComplianceRuleSet, error)
+	UpdateComplianceRuleSet(orgID, id uuid.UUID, req *domain.ComplianceRuleSetRequest) (*domain.ComplianceRuleSet, error)
+	DeleteComplianceRuleSet(orgID, id uuid.UUID) error
+	ListComplianceRuleSets(orgID uuid.UUID) ([]domain.ComplianceRuleSet, error)
+	SetEmployeeJurisdiction(orgID, employeeID uuid.UUID, req *domain.EmployeeJurisdictionRequest) (*domain.EmployeeJurisdiction, error)
+
+	// Holiday methods
+	CreateHoliday(orgID uuid.UUID, req *domain.HolidayRequest) (*domain.Holiday, error)
+	UpdateHoliday(orgID, id uuid.UUID, req *domain.HolidayRequest) (*domain.Holiday, error)
+	DeleteHoliday(orgID, id uuid.UUID) error
+	ListHolidayOccurrences(orgID uuid.UUID, startDate, endDate time.Time, site string) ([]domain.HolidayOccurrence, error)
+	ImportHolidays(orgID uuid.UUID, site string, r io.Reader) (*domain.HolidayImportResult, error)
+	SetEmployeeSite(orgID, employeeID uuid.UUID, req *domain.EmployeeSiteRequest) (*domain.EmployeeSite, error)
+
+	// Team methods
+	CreateTeam(orgID uuid.UUID, req *domain.TeamRequest) (*domain.Team, error)
+	UpdateTeam(orgID, id uuid.UUID, req *domain.TeamRequest) (*domain.Team, error)
+	DeleteTeam(orgID, id uuid.UUID) error
+	ListTeams(orgID uuid.UUID) ([]domain.Team, error)
+	SetEmployeeTeam(orgID, employeeID uuid.UUID, req *domain.EmployeeTeamRequest) (*domain.EmployeeTeam, error)
+
+	// Remote work methods
+	GetRemoteWorkPolicy(orgID uuid.UUID) (*domain.RemoteWorkPolicy, error)
+	UpdateRemoteWorkPolicy(orgID uuid.UUID, req *domain.RemoteWorkPolicyRequest) (*domain.RemoteWorkPolicy, error)
+	CreateRemoteWorkRequest(orgID, employeeID uuid.UUID, req *domain.CreateRemoteWorkRequest) (*domain.RemoteWorkRequest, error)
+	ListRemoteWorkRequests(orgID uuid.UUID, filter *domain.RemoteWorkRequestFilter) ([]domain.RemoteWorkRequest, error)
+	ApproveRemoteWorkRequest(orgID, id, reviewerID uuid.UUID, req *domain.ReviewRemoteWorkRequest) (*domain.RemoteWorkRequest, error)
+	RejectRemoteWorkRequest(orgID, id, reviewerID uuid.UUID, req *domain.ReviewRemoteWorkRequest) (*domain.RemoteWorkRequest, error)
+	CancelRemoteWorkRequest(orgID, employeeID, id uuid.UUID) (*domain.RemoteWorkRequest, error)
+	ListRemoteWorkViolations(orgID uuid.UUID, filter *domain.RemoteWorkViolationFilter) ([]domain.RemoteWorkViolation, error)
+
+	// Roster methods
+	CreateRoster(orgID uuid.UUID, req *domain.RosterRequest) (*domain.Roster, error)
+	GetRoster(orgID, id uuid.UUID) (*domain.Roster, error)
+	UpdateRoster(orgID, id uuid.UUID, req *domain.RosterRequest) (*domain.Roster, error)
+	DeleteRoster(orgID, id uuid.UUID) error
+	ListRosters(orgID uuid.UUID, startDate, endDate time.Time) ([]domain.Roster, error)
+	PublishRoster(orgID, id, userID uuid.UUID) (*domain.Roster, error)
+	UnpublishRoster(orgID, id uuid.UUID) (*domain.Roster, error)
+	AddShift(orgID, rosterID uuid.UUID, req *domain.ShiftRequest) (*domain.Shift, error)
+	UpdateShift(orgID, rosterID, id uuid.UUID, req *domain.ShiftRequest) (*domain.Shift, error)
+	DeleteShift(orgID, rosterID, id uuid.UUID) error
+	ListEmployeeShifts(orgID, employeeID uuid.UUID, startDate, endDate time.Time) ([]domain.Shift, error)
+	ListOpenShifts(orgID uuid.UUID, startDate, endDate time.Time) ([]domain.Shift, error)
+	ClaimOpenShift(orgID, employeeID, id uuid.UUID) (*domain.Shift, error)
+	CreateShiftSwapRequest(orgID, employeeID uuid.UUID, req *domain.CreateShiftSwapRequest) (*domain.ShiftSwapRequest, error)
+	ListShiftSwapRequests(orgID uuid.UUID, filter *domain.ShiftSwapFilter) ([]domain.ShiftSwapRequest, error)
+	ApproveShiftSwapRequest(orgID, id, reviewerID uuid.UUID, req *domain.ReviewShiftSwapRequest) (*domain.ShiftSwapRequest, error)
+	RejectShiftSwapRequest(orgID, id, reviewerID uuid.UUID, req *domain.ReviewShiftSwapRequest) (*domain.ShiftSwapRequest, error)
+	CancelShiftSwapRequest(orgID, employeeID, id uuid.UUID) (*domain.ShiftSwapRequest, error)
+
+	// On-call methods
+	CreateOnCallRotation(orgID uuid.UUID, req *domain.OnCallRotationRequest) (*domain.OnCallRotation, error)
+	UpdateOnCallRotation(orgID, id uuid.UUID, req *domain.OnCallRotationRequest) (*domain.OnCallRotation, error)
+	DeleteOnCallRotation(orgID, id uuid.UUID) error
+	ListOnCallRotations(orgID uuid.UUID, teamID *uuid.UUID) ([]domain.OnCallRotation, error)
+	GetOnCallSchedule(orgID, id uuid.UUID, startDate, endDate time.Time) ([]domain.OnCallTurn, error)
+	CreateOnCallIncident(orgID, employeeID uuid.UUID, req *domain.CreateOnCallIncidentRequest) (*domain.OnCallIncident, error)
+	ListOnCallIncidents(orgID uuid.UUID, filter *domain.OnCallIncidentFilter) ([]domain.OnCallIncident, error)
+
+	// Leave methods
+	CreateLeaveType(orgID uuid.UUID, req *domain.LeaveTypeRequest) (*domain.LeaveType, error)
+	UpdateLeaveType(orgID, id uuid.UUID, req *domain.LeaveTypeRequest) (*domain.LeaveType, error)
+	DeleteLeaveType(orgID, id uuid.UUID) error
+	ListLeaveTypes(orgID uuid.UUID) ([]domain.LeaveType, error)
+	CreateLeaveRequest(orgID, employeeID uuid.UUID, req *domain.CreateLeaveRequest) (*domain.LeaveRequest, error)
+	ListLeaveRequests(orgID uuid.UUID, filter *domain.LeaveRequestFilter) ([]domain.LeaveRequest, error)
+	ApproveLeaveRequest(orgID, id, reviewerID uuid.UUID, req *domain.ReviewLeaveRequest) (*domain.LeaveRequest, error)
+	RejectLeaveRequest(orgID, id, reviewerID uuid.UUID, req *domain.ReviewLeaveRequest) (*domain.LeaveRequest, error)
+	CancelLeaveRequest(orgID, employeeID, id uuid.UUID) (*domain.LeaveRequest, error)
+	ListLeaveBalances(orgID, employeeID uuid.UUID, year int) ([]domain.LeaveBalanceResponse, error)
+	AdjustLeaveBalance(orgID, employeeID uuid.UUID, req *domain.LeaveBalanceAdjustmentRequest) (*domain.LeaveBalanceResponse, error)
+
+	// Overtime methods
+	CreateOvertimeRule(orgID uuid.UUID, req *domain.OvertimeRuleRequest) (*domain.OvertimeRule, error)
+	UpdateOvertimeRule(orgID, id uuid.UUID, req *domain.OvertimeRuleRequest) (*domain.OvertimeRule, error)
+	DeleteOvertimeRule(orgID, id uuid.UUID) error
+	ListOvertimeRules(orgID uuid.UUID) ([]domain.OvertimeRule, error)
+	RecomputeOvertime(orgID uuid.UUID, req *domain.RecomputeOvertimeRequest) (*domain.RecomputeOvertimeResult, error)
+	GetEmployeeOvertime(orgID, employeeID uuid.UUID, startDate, endDate time.Time) (*domain.OvertimeSummary, error)
+
+	// Payroll period methods
+	ClosePayrollPeriod(orgID, userID uuid.UUID, req *domain.ClosePeriodRequest) (*domain.PayrollPeriod, error)
+	ReopenPayrollPeriod(orgID, id, userID uuid.UUID, req *domain.ReopenPeriodRequest) (*domain.PayrollPeriod, error)
+	ListPayrollPeriods(orgID uuid.UUID) ([]domain.PayrollPeriod, error)
+	ListPayrollPeriodEvents(orgID, id uuid.UUID) ([]domain.PayrollPeriodEvent, error)
+
+	// Approval methods
+	CreateApprovalSLARule(orgID uuid.UUID, req *domain.ApprovalSLARuleRequest) (*domain.ApprovalSLARule, error)
+	UpdateApprovalSLARule(orgID, id uuid.UUID, req *domain.ApprovalSLARuleRequest) (*domain.ApprovalSLARule, error)
+	DeleteApprovalSLARule(orgID, id uuid.UUID) error
+	ListApprovalSLARules(orgID uuid.UUID) ([]domain.ApprovalSLARule, error)
+	ListTimesheetApprovers(timesheetID uuid.UUID) ([]domain.TimesheetApprover, error)
+	EscalateOverdueTimesheets(now time.Time) (int, error)
+}
+
+// EmployeeDirectory looks up reporting lines in the employee service
+type EmployeeDirectory interface {
+	GetManagerID(orgID, employeeID uuid.UUID) (*uuid.UUID, error)
 }
 
 type timeService struct {
-	timeRepo repository.TimeRepository
+	timeRepo  repository.TimeRepository
+	employees EmployeeDirectory
 }
 
-func NewTimeService(timeRepo repository.TimeRepository) TimeService {
+func NewTimeService(timeRepo repository.TimeRepository, employees EmployeeDirectory) TimeService {
 	return &timeService{
-		timeRepo: timeRepo,
+		timeRepo:  timeRepo,
+		employees: employees,
+	}
+}
+
+// saveReconciled saves a submitted entry with save and, when the policy
+// blocks unreconciled submissions, checks its day against attendance with
+// the entry in place, undoing the save if they do not match. Drafts and
+// call-outs are not reconciled.
+func (s *timeService) saveReconciled(policy *domain.TimesheetPolicy, timesheet *domain.Timesheet, save func(*domain.Timesheet) error) error {
+	if !policy.BlockUnreconciledSubmission || timesheet.Status == domain.TimesheetStatusDraft || timesheet.EntryType == domain.TimesheetEntryCallOut {
+		return save(timesheet)
 	}
+	return s.inTransaction(func(tx *timeService) error {
+		if err := save(timesheet); err != nil {
+			return err
+		}
+		return tx.validateSubmissionReconciled(policy, timesheet.EmployeeID, []domain.Timesheet{*timesheet})
+	})
+}
+
+// inTransaction runs fn with a service whose repository works in a single
+// database transaction, so everything fn saves is kept or discarded together
+// and later checks in fn see what earlier steps saved.
+func (s *timeService) inTransaction(fn func(tx *timeService) error) error {
+	return s.timeRepo.Transaction(func(repo repository.TimeRepository) error {
+		return fn(&timeService{timeRepo: repo, employees: s.employees})
+	})
 }
 
 // Generate QR Code for employee
@@ -96,7 +304,7 @@ func (s *timeService) ValidateQRCode(code string) (*domain.QRCode, error) {
 }
 
 // Check-in employee
-func (s *timeService) CheckIn(req *domain.CheckInRequest) (*domain.Attendance, error) {
+func (s *timeService) CheckIn(req *domain.CheckInRequest) (*domain.AttendanceResult, error) {
 	// Validate QR code
 	qrCode, err := s.ValidateQRCode(req.QRCode)
 	if err != nil {
@@ -111,24 +319,56 @@ func (s *timeService) CheckIn(req *domain.CheckInRequest) (*domain.Attendance, e
 
 	// Check if employee already checked in today
 	today := time.Now().Truncate(24 * time.Hour)
-	attendance, err := s.timeRepo.GetAttendanceByDate(qrCode.EmployeeID, today)
-	if err == nil && attendance.CheckIn != nil {
+	if err := s.ensurePeriodNotClosed(qrCode.OrganizationID, today); err != nil {
+		return nil, err
+	}
+	existing, existingErr := s.timeRepo.GetAttendanceByDate(qrCode.EmployeeID, today)
+	if existingErr == nil && existing.CheckIn != nil {
 		return nil, errors.New("already checked in today")
 	}
+	if existingErr == nil && existing.Status == domain.AttendanceStatusLeave {
+		return nil, apperrors.NewBusinessRuleError(apperrors.ErrOnLeave, "employee is on approved leave today", map[string]interface{}{
+			"date":             utils.FormatDate(today),
+			"leave_request_id": existing.LeaveRequestID,
+		})
+	}
+	policy, err := s.GetTimesheetPolicy(qrCode.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	roundedCheckIn := roundPunch(policy, checkInTime, true)
 
-	// Create new attendance record
-	attendance = &domain.Attendance{
+	// Create new attendance record, or punch on the record of a half-day leave
+	attendance := &domain.Attendance{
 		OrganizationID: qrCode.OrganizationID,
 		EmployeeID:     qrCode.EmployeeID,
-		CheckIn:        &checkInTime,
 		Date:           today,
 		Status:         domain.AttendanceStatusPresent,
-		WorkMode:       req.WorkMode,
-		Location:       req.Location,
-		DeviceInfo:     req.DeviceInfo,
+	}
+	if existingErr == nil && existing.LeaveRequestID != nil {
+		attendance = existing
+	}
+	attendance.CheckIn = &checkInTime
+	attendance.RoundedCheckIn = &roundedCheckIn
+	attendance.WorkMode = req.WorkMode
+	attendance.Location = req.Location
+	attendance.DeviceInfo = req.DeviceInfo
+	s.applyRosteredShift(policy, attendance)
+	violations, err := s.validateCheckInCompliance(attendance)
+	if err != nil {
+		return nil, err
+	}
+	remoteViolations, err := s.validateRemoteCheckIn(policy, attendance)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := s.timeRepo.CreateAttendance(attendance); err != nil {
+	if attendance.ID != uuid.Nil {
+		err = s.timeRepo.UpdateAttendance(attendance)
+	} else {
+		err = s.timeRepo.CreateAttendance(attendance)
+	}
+	if err != nil {
 		return nil, err
 	}
 
@@ -138,11 +378,13 @@ func (s *timeService) CheckIn(req *domain.CheckInRequest) (*domain.Attendance, e
 		log.Printf("Failed to update LastUsed: %v", err)
 	}
 
-	return attendance, nil
+	s.flagRemoteWorkViolations(attendance, remoteViolations)
+
+	return &domain.AttendanceResult{Attendance: *attendance, Violations: violations, RemoteWorkViolations: remoteViolations}, nil
 }
 
 // Check-out employee
-func (s *timeService) CheckOut(req *domain.CheckOutRequest) (*domain.Attendance, error) {
+func (s *timeService) CheckOut(req *domain.CheckOutRequest) (*domain.AttendanceResult, error) {
 	// Validate QR code
 	qrCode, err := s.ValidateQRCode(req.QRCode)
 	if err != nil {
@@ -165,15 +407,29 @@ func (s *timeService) CheckOut(req *domain.CheckOutRequest) (*domain.Attendance,
 	if attendance.CheckOut != nil {
 		return nil, errors.New("already checked out today")
 	}
+	if err := s.ensurePeriodNotClosed(attendance.OrganizationID, attendance.Date); err != nil {
+		return nil, err
+	}
 
-	// Update check-out time
+	policy, err := s.GetTimesheetPolicy(attendance.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update check-out time, closing a break still in progress
+	if attendance.BreakStartedAt != nil {
+		attendance.BreakMinutes += breakMinutes(*attendance.BreakStartedAt, checkOutTime)
+		attendance.BreakStartedAt = nil
+	}
 	attendance.CheckOut = &checkOutTime
 	attendance.Location = req.Location
 	attendance.DeviceInfo = req.DeviceInfo
+	applyAttendanceHours(policy, attendance)
 
 	if err := s.timeRepo.UpdateAttendance(attendance); err != nil {
 		return nil, err
 	}
+	s.recomputeOvertime(attendance.OrganizationID, attendance.EmployeeID, domain.OvertimeSourceAttendance, attendance.Date)
 
 	// Update QR code last used
 	qrCode.LastUsed = &checkOutTime
@@ -181,91 +437,501 @@ func (s *timeService) CheckOut(req *domain.CheckOutRequest) (*domain.Attendance,
 		return nil, err
 	}
 
+	return &domain.AttendanceResult{Attendance: *attendance, Violations: s.checkOutViolations(attendance)}, nil
+}
+
+// StartBreak records the start of a break in today's attendance
+func (s *timeService) StartBreak(req *domain.BreakRequest) (*domain.Attendance, error) {
+	attendance, breakTime, err := s.openAttendance(req)
+	if err != nil {
+		return nil, err
+	}
+	if attendance.BreakStartedAt != nil {
+		return nil, errors.New("break already started")
+	}
+
+	attendance.BreakStartedAt = &breakTime
+	if err := s.timeRepo.UpdateAttendance(attendance); err != nil {
+		return nil, err
+	}
+	return attendance, nil
+}
+
+// EndBreak adds the finished break to today's attendance
+func (s *timeService) EndBreak(req *domain.BreakRequest) (*domain.Attendance, error) {
+	attendance, breakTime, err := s.openAttendance(req)
+	if err != nil {
+		return nil, err
+	}
+	if attendance.BreakStartedAt == nil {
+		return nil, errors.New("no break in progress")
+	}
+
+	attendance.BreakMinutes += breakMinutes(*attendance.BreakStartedAt, breakTime)
+	attendance.BreakStartedAt = nil
+	if err := s.timeRepo.UpdateAttendance(attendance); err != nil {
+		return nil, err
+	}
 	return attendance, nil
 }
 
+// openAttendance returns today's attendance of the QR code's employee when
+// checked in and not yet checked out, with the punch time of the request.
+func (s *timeService) openAttendance(req *domain.BreakRequest) (*domain.Attendance, time.Time, error) {
+	qrCode, err := s.ValidateQRCode(req.QRCode)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	punchTime := req.Timestamp
+	if punchTime.IsZero() {
+		punchTime = time.Now()
+	}
+
+	today := time.Now().Truncate(24 * time.Hour)
+	attendance, err := s.timeRepo.GetAttendanceByDate(qrCode.EmployeeID, today)
+	if err != nil {
+		return nil, time.Time{}, errors.New("no check-in record found for today")
+	}
+	if attendance.CheckOut != nil {
+		return nil, time.Time{}, errors.New("already checked out today")
+	}
+	if err := s.ensurePeriodNotClosed(attendance.OrganizationID, attendance.Date); err != nil {
+		return nil, time.Time{}, err
+	}
+	return attendance, punchTime, nil
+}
+
+func breakMinutes(start, end time.Time) int {
+	if !end.After(start) {
+		return 0
+	}
+	return int(math.Round(end.Sub(start).Minutes()))
+}
+
 func (s *timeService) GetAttendanceByDate(employeeID uuid.UUID, date time.Time) (*domain.Attendance, error) {
 	return s.timeRepo.GetAttendanceByDate(employeeID, date)
 }
 
-func (s *timeService) GetAttendanceSummary(employeeID uuid.UUID, month, year int) (map[string]int, error) {
-	// TO DO: implement attendance summary logic
-	return nil, nil
+// GetAttendanceSummary counts the employee's attendance by status over a
+// month. Working days before today without attendance count as absent;
+// weekends, full-day holidays and approved leave are never absences.
+func (s *timeService) GetAttendanceSummary(orgID, employeeID uuid.UUID, month, year int) (map[string]int, error) {
+	if month < 1 || month > 12 || year < 1 {
+		return nil, apperrors.NewBadRequestError("invalid month or year")
+	}
+	policy, err := s.GetTimesheetPolicy(orgID)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := time.LoadLocation(policy.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	startDate := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	endDate := utils.GetEndOfMonth(startDate)
+	attendances, err := s.timeRepo.ListAttendancesInRange(orgID, &employeeID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	calendar, err := s.holidayCalendar(orgID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	holidayOn := calendar.forEmployee(employeeID)
+	workWeek := s.employeeWorkWeek(policy, employeeID)
+	expected, err := s.expectedHours(policy, employeeID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := map[string]string{}
+	for _, attendance := range attendances {
+		statuses[utils.FormatDate(attendance.Date)] = attendance.Status
+	}
+
+	now := time.Now().In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	summary := map[string]int{
+		"working_days":      0,
+		"present":           0,
+		"late":              0,
+		"half_day":          0,
+		"leave":             0,
+		"absent":            0,
+		"holidays":          0,
+		"half_day_holidays": 0,
+		"weekends":          0,
+		"expected_hours":    int(math.Round(expected)),
+	}
+	for day := startDate; !day.After(endDate); day = day.AddDate(0, 0, 1) {
+		status, attended := statuses[utils.FormatDate(day)]
+		switch status {
+		case domain.AttendanceStatusPresent, domain.AttendanceStatusLate:
+			summary[status]++
+		case domain.AttendanceStatusHalfDay:
+			summary["half_day"]++
+		case domain.AttendanceStatusAbsent:
+			summary["absent"]++
+		case domain.AttendanceStatusLeave:
+			summary["leave"]++
+		}
+
+		holiday, halfDay := holidayOn(day)
+		switch {
+		case workWeek.IsWeekend(day):
+			summary["weekends"]++
+		case holiday && !halfDay:
+			summary["holidays"]++
+		default:
+			if halfDay {
+				summary["half_day_holidays"]++
+			}
+			summary["working_days"]++
+			if !attended && day.Before(today) {
+				summary["absent"]++
+			}
+		}
+	}
+	return summary, nil
+}
+
+func (s *timeService) ListAttendances(orgID uuid.UUID, filter *domain.AttendanceFilter) ([]domain.Attendance, error) {
+	return s.timeRepo.ListAttendances(orgID, filter)
 }
 
-func (s *timeService) ListAttendances(orgID uuid.UUID) ([]domain.Attendance, error) {
-	return s.timeRepo.ListAttendances(orgID)
+func (s *timeService) CreateTimesheet(orgID, employeeID uuid.UUID, req *domain.CreateTimesheetRequest) (*domain.TimesheetResult, error) {
+	return s.createTimesheet(orgID, employeeID, req, domain.TimesheetStatusPending)
 }
 
-func (s *timeService) CreateTimesheet(orgID, employeeID uuid.UUID, req *domain.CreateTimesheetRequest) (*domain.Timesheet, error) {
-	today := time.Now().Truncate(24 * time.Hour)
+// createTimesheet validates and stores an entry in the given status. Drafts
+// may have no hours yet and are only routed for approval once submitted.
+func (s *timeService) createTimesheet(orgID, employeeID uuid.UUID, req *domain.CreateTimesheetRequest, status string) (*domain.TimesheetResult, error) {
+	date, err := parseTimesheetDate(req.Date)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := s.GetTimesheetPolicy(orgID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateTimesheetDate(policy, employeeID, date); err != nil {
+		return nil, err
+	}
+
 	timesheet := &domain.Timesheet{
 		OrganizationID: orgID,
 		EmployeeID:     employeeID,
 		ProjectID:      req.ProjectID,
 		TaskID:         req.TaskID,
 		Description:    req.Description,
-		Date:           today,
-		Hours:          req.Hours,
+		Date:           date,
+		Notes:          req.Notes,
+		Status:         status,
+	}
+	if err := applyTimesheetTimes(policy, timesheet, req); err != nil {
+		return nil, err
+	}
+	project, err := s.validateTimesheetProject(timesheet)
+	if err != nil {
+		return nil, err
 	}
-	if err := s.timeRepo.CreateTimesheet(timesheet); err != nil {
+	if err := s.applyTimesheetRates(timesheet, project, req.Billable); err != nil {
 		return nil, err
 	}
-	return timesheet, nil
+	if status != domain.TimesheetStatusDraft {
+		if err := s.validateProjectBudget(timesheet); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.validateTimesheetOverlap(timesheet); err != nil {
+		return nil, err
+	}
+	warnings, err := s.validateTimesheetHours(policy, timesheet, nil)
+	if err != nil {
+		return nil, err
+	}
+	if status != domain.TimesheetStatusDraft {
+		complianceWarnings, err := s.validateTimesheetCompliance(timesheet)
+		if err != nil {
+			return nil, err
+		}
+		warnings = append(warnings, complianceWarnings...)
+	}
+	if err := s.saveReconciled(policy, timesheet, s.timeRepo.CreateTimesheet); err != nil {
+		return nil, err
+	}
+	if status != domain.TimesheetStatusDraft {
+		s.assignInitialApprover(timesheet)
+		if timesheet.ProjectID != nil {
+			s.checkBudgetAlerts(*timesheet.ProjectID, timesheet.TaskID)
+		}
+	}
+	return s.timesheetResult(timesheet, warnings)
 }
 
-func (s *timeService) GetTimesheet(id uuid.UUID) (*domain.Timesheet, error) {
-	return s.timeRepo.GetTimesheet(id)
+func (s *timeService) GetTimesheet(id uuid.UUID) (*domain.TimesheetResponse, error) {
+	timesheet, err := s.timeRepo.GetTimesheet(id)
+	if err != nil {
+		return nil, err
+	}
+	return s.timesheetResponse(timesheet)
 }
 
-func (s *timeService) UpdateTimesheet(id uuid.UUID, req *domain.CreateTimesheetRequest) (*domain.Timesheet, error) {
-	today := time.Now().Truncate(24 * time.Hour)
+func (s *timeService) UpdateTimesheet(id, userID uuid.UUID, req *domain.CreateTimesheetRequest) (*domain.TimesheetResult, error) {
+	date, err := parseTimesheetDate(req.Date)
+	if err != nil {
+		return nil, err
+	}
 	timesheet, err := s.timeRepo.GetTimesheet(id)
 	if err != nil {
 		return nil, err
 	}
+	policy, err := s.GetTimesheetPolicy(timesheet.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Both the current and the requested date must be open for changes
+	if err := s.validateTimesheetDate(policy, timesheet.EmployeeID, timesheet.Date); err != nil {
+		return nil, err
+	}
+	if !date.Equal(timesheet.Date) {
+		if err := s.validateTimesheetDate(policy, timesheet.EmployeeID, date); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := ensureNotInvoiced(timesheet); err != nil {
+		return nil, err
+	}
+	if err := s.ensureTimesheetEditable(timesheet); err != nil {
+		return nil, err
+	}
+
+	previous := *timesheet
 	timesheet.ProjectID = req.ProjectID
 	timesheet.TaskID = req.TaskID
 	timesheet.Description = req.Description
-	timesheet.Date = today
-	timesheet.Hours = req.Hours
-	if err := s.timeRepo.UpdateTimesheet(timesheet); err != nil {
+	timesheet.Date = date
+	timesheet.Notes = req.Notes
+	if err := applyTimesheetTimes(policy, timesheet, req); err != nil {
+		return nil, err
+	}
+	project, err := s.validateTimesheetProject(timesheet)
+	if err != nil {
+		return nil, err
+	}
+	// Without an explicit choice the entry stays as billable as it was
+	billable := req.Billable
+	if billable == nil {
+		billable = &previous.IsBillable
+	}
+	if err := s.applyTimesheetRates(timesheet, project, billable); err != nil {
+		return nil, err
+	}
+	if timesheet.Status != domain.TimesheetStatusDraft {
+		if err := s.validateProjectBudget(timesheet); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.validateTimesheetOverlap(timesheet); err != nil {
+		return nil, err
+	}
+	warnings, err := s.validateTimesheetHours(policy, timesheet, &previous)
+	if err != nil {
+		return nil, err
+	}
+	if timesheet.Status != domain.TimesheetStatusDraft {
+		complianceWarnings, err := s.validateTimesheetCompliance(timesheet)
+		if err != nil {
+			return nil, err
+		}
+		warnings = append(warnings, complianceWarnings...)
+	}
+	if err := s.saveReconciled(policy, timesheet, s.timeRepo.UpdateTimesheet); err != nil {
 		return nil, err
 	}
-	return timesheet, nil
+	s.recordTimesheetChanges(&previous, timesheet, userID)
+	if timesheet.Status == domain.TimesheetStatusApproved {
+		s.recomputeOvertime(timesheet.OrganizationID, timesheet.EmployeeID, domain.OvertimeSourceTimesheets, previous.Date, timesheet.Date)
+	}
+	if previous.ProjectID != nil {
+		s.checkBudgetAlerts(*previous.ProjectID, previous.TaskID)
+	}
+	if timesheet.ProjectID != nil {
+		s.checkBudgetAlerts(*timesheet.ProjectID, timesheet.TaskID)
+	}
+	return s.timesheetResult(timesheet, warnings)
 }
 
-func (s *timeService) DeleteTimesheet(id uuid.UUID) error {
-	return s.timeRepo.DeleteTimesheet(id)
+// DeleteTimesheet soft-deletes an entry; it can be restored until it is
+// purged after the organization's retention window.
+func (s *timeService) DeleteTimesheet(id, userID uuid.UUID) error {
+	timesheet, err := s.timeRepo.GetTimesheet(id)
+	if err != nil {
+		return apperrors.NewNotFoundError("timesheet not found")
+	}
+	if err := ensureNotInvoiced(timesheet); err != nil {
+		return err
+	}
+	if timesheet.Status == domain.TimesheetStatusApproved {
+		return apperrors.NewInvalidStatusError("approved timesheets cannot be deleted")
+	}
+	policy, err := s.GetTimesheetPolicy(timesheet.OrganizationID)
+	if err != nil {
+		return err
+	}
+	if err := s.validatePeriodOpen(policy, timesheet.EmployeeID, timesheet.Date); err != nil {
+		return err
+	}
+
+	if err := s.timeRepo.DeleteTimesheet(id); err != nil {
+		return err
+	}
+	s.recordTimesheetDeletion(timesheet, userID, true)
+	if timesheet.ProjectID != nil {
+		s.checkBudgetAlerts(*timesheet.ProjectID, timesheet.TaskID)
+	}
+	return nil
+}
+
+// RestoreTimesheet brings back a deleted entry after checking it against
+// the entries logged since it was deleted.
+func (s *timeService) RestoreTimesheet(orgID, employeeID, id, userID uuid.UUID) (*domain.TimesheetResult, error) {
+	timesheet, err := s.timeRepo.GetDeletedTimesheet(id)
+	if err != nil || timesheet.OrganizationID != orgID || timesheet.EmployeeID != employeeID {
+		return nil, apperrors.NewNotFoundError("deleted timesheet not found")
+	}
+	policy, err := s.GetTimesheetPolicy(timesheet.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validatePeriodOpen(policy, timesheet.EmployeeID, timesheet.Date); err != nil {
+		return nil, err
+	}
+	if timesheet.Status != domain.TimesheetStatusDraft && timesheet.Status != domain.TimesheetStatusRejected {
+		if err := s.validateProjectBudget(timesheet); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.validateTimesheetOverlap(timesheet); err != nil {
+		return nil, err
+	}
+	warnings, err := s.validateTimesheetHours(policy, timesheet, nil)
+	if err != nil {
+		return nil, err
+	}
+	if timesheet.Status != domain.TimesheetStatusDraft && timesheet.Status != domain.TimesheetStatusRejected {
+		complianceWarnings, err := s.validateTimesheetCompliance(timesheet)
+		if err != nil {
+			return nil, err
+		}
+		warnings = append(warnings, complianceWarnings...)
+	}
+
+	if err := s.timeRepo.RestoreTimesheet(id); err != nil {
+		return nil, err
+	}
+	s.recordTimesheetDeletion(timesheet, userID, false)
+	timesheet.DeletedAt = gorm.DeletedAt{}
+	if timesheet.ProjectID != nil {
+		s.checkBudgetAlerts(*timesheet.ProjectID, timesheet.TaskID)
+	}
+	return s.timesheetResult(timesheet, warnings)
+}
+
+func (s *timeService) ListDeletedTimesheets(orgID, employeeID uuid.UUID, startDate, endDate time.Time) ([]domain.TimesheetResponse, error) {
+	timesheets, err := s.timeRepo.ListDeletedTimesheets(orgID, employeeID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	return s.timesheetResponses(timesheets)
+}
+
+// PurgeDeletedTimesheets permanently removes the timesheets deleted longer
+// ago than the organization's retention window.
+func (s *timeService) PurgeDeletedTimesheets(orgID uuid.UUID) (int64, error) {
+	policy, err := s.GetTimesheetPolicy(orgID)
+	if err != nil {
+		return 0, err
+	}
+	retention := policy.DeletedRetentionDays
+	if retention <= 0 {
+		retention = domain.DefaultDeletedRetentionDays
+	}
+	return s.timeRepo.PurgeDeletedTimesheets(orgID, time.Now().AddDate(0, 0, -retention))
 }
 
-func (s *timeService) ListTimesheets(orgID, employeeID uuid.UUID, startDate, endDate time.Time) ([]domain.Timesheet, error) {
-	return s.timeRepo.ListTimesheets(orgID, employeeID, startDate, endDate)
+func (s *timeService) ListTimesheets(orgID, employeeID uuid.UUID, startDate, endDate time.Time) ([]domain.TimesheetResponse, error) {
+	timesheets, err := s.timeRepo.ListTimesheets(orgID, employeeID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	return s.timesheetResponses(timesheets)
 }
 
-func (s *timeService) ApproveTimesheet(id, approverID uuid.UUID) error {
+func (s *timeService) ApproveTimesheet(orgID, employeeID, id, approverID uuid.UUID, isAdmin bool) error {
 	today := time.Now().Truncate(24 * time.Hour)
-	timesheet, err := s.timeRepo.GetTimesheet(id)
+	timesheet, err := s.reviewableTimesheet(orgID, employeeID, id, approverID, isAdmin)
 	if err != nil {
 		return err
 	}
+	if timesheet.Status != domain.TimesheetStatusPending {
+		return apperrors.NewInvalidStatusError("only pending timesheets can be approved")
+	}
+	// Freeze the rates in effect at approval
+	billable := timesheet.IsBillable
+	if err := s.applyTimesheetRates(timesheet, nil, &billable); err != nil {
+		return err
+	}
+	previous := *timesheet
 	timesheet.Status = domain.TimesheetStatusApproved
 	timesheet.ApprovedBy = &approverID
 	timesheet.ApprovedAt = &today
-	return s.timeRepo.UpdateTimesheet(timesheet)
+	if err := s.timeRepo.UpdateTimesheet(timesheet); err != nil {
+		return err
+	}
+	s.recordTimesheetChanges(&previous, timesheet, approverID)
+	s.recomputeOvertime(timesheet.OrganizationID, timesheet.EmployeeID, domain.OvertimeSourceTimesheets, timesheet.Date)
+	return nil
 }
 
-func (s *timeService) RejectTimesheet(id, approverID uuid.UUID, reason string) error {
+func (s *timeService) RejectTimesheet(orgID, employeeID, id, approverID uuid.UUID, isAdmin bool, reason string) error {
 	today := time.Now().Truncate(24 * time.Hour)
-	timesheet, err := s.timeRepo.GetTimesheet(id)
+	timesheet, err := s.reviewableTimesheet(orgID, employeeID, id, approverID, isAdmin)
 	if err != nil {
 		return err
 	}
+	if timesheet.Status != domain.TimesheetStatusPending {
+		return apperrors.NewInvalidStatusError("only pending timesheets can be rejected")
+	}
+	previous := *timesheet
 	timesheet.Status = domain.TimesheetStatusRejected
 	timesheet.ApprovedBy = &approverID
 	timesheet.ApprovedAt = &today
-	timesheet.Notes = reason
-	return s.timeRepo.UpdateTimesheet(timesheet)
+	if err := s.timeRepo.UpdateTimesheet(timesheet); err != nil {
+		return err
+	}
+	s.recordTimesheetChanges(&previous, timesheet, approverID)
+
+	// The reason goes to the entry's thread so the employee's notes are kept
+	comment := &domain.TimesheetComment{
+		OrganizationID: timesheet.OrganizationID,
+		EmployeeID:     timesheet.EmployeeID,
+		TimesheetID:    &timesheet.ID,
+		AuthorID:       approverID,
+		Kind:           domain.CommentKindRejection,
+		Body:           reason,
+	}
+	if err := s.createTimesheetComment(comment); err != nil {
+		return err
+	}
+	if timesheet.ProjectID != nil {
+		s.checkBudgetAlerts(*timesheet.ProjectID, timesheet.TaskID)
+	}
+	return nil
 }
 
 func (s *timeService) GetEmployeeQRCodes(orgID, employeeID uuid.UUID) ([]domain.QRCode, error) {
@@ -0,0 +1,259 @@
+package service
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/Axontik/comin-time-service/internal/domain"
+	apperrors "github.com/Axontik/comin-time-service/internal/errors"
+	"github.com/Axontik/comin-time-service/internal/repository"
+	"github.com/Axontik/comin-time-service/utils"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// StartTimer starts a timer on a project or task that currently accepts
+// time, so it is not only found out when the timer is stopped
+func (s *timeService) StartTimer(orgID, employeeID uuid.UUID, req *domain.StartTimerRequest) (*domain.Timer, error) {
+	if err := s.ensureNoRunningTimer(employeeID); err != nil {
+		return nil, err
+	}
+	target := &domain.Timesheet{OrganizationID: orgID, EmployeeID: employeeID, ProjectID: req.ProjectID, TaskID: req.TaskID}
+	if _, err := s.validateTimesheetProject(target); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	timer := &domain.Timer{
+		OrganizationID: orgID,
+		EmployeeID:     employeeID,
+		ProjectID:      target.ProjectID,
+		TaskID:         req.TaskID,
+		Description:    req.Description,
+		Status:         domain.TimerStatusRunning,
+		StartedAt:      now,
+		Segments:       []domain.TimerSegment{{StartedAt: now}},
+	}
+	if err := s.timeRepo.CreateTimer(timer); err != nil {
+		return nil, runningTimerConflict(err)
+	}
+	return timer, nil
+}
+
+func (s *timeService) GetTimer(orgID, employeeID, id uuid.UUID) (*domain.Timer, error) {
+	return s.getEmployeeTimer(orgID, employeeID, id)
+}
+
+func (s *timeService) ListActiveTimers(orgID, employeeID uuid.UUID) ([]domain.Timer, error) {
+	return s.timeRepo.ListActiveTimers(orgID, employeeID)
+}
+
+func (s *timeService) PauseTimer(orgID, employeeID, id uuid.UUID) (*domain.Timer, error) {
+	timer, err := s.getEmployeeTimer(orgID, employeeID, id)
+	if err != nil {
+		return nil, err
+	}
+	if timer.Status != domain.TimerStatusRunning {
+		return nil, apperrors.NewInvalidStatusError("only running timers can be paused")
+	}
+
+	if err := s.closeTimerSegment(timer, time.Now()); err != nil {
+		return nil, err
+	}
+	timer.Status = domain.TimerStatusPaused
+	if err := s.timeRepo.UpdateTimer(timer); err != nil {
+		return nil, err
+	}
+	return timer, nil
+}
+
+func (s *timeService) ResumeTimer(orgID, employeeID, id uuid.UUID) (*domain.Timer, error) {
+	timer, err := s.getEmployeeTimer(orgID, employeeID, id)
+	if err != nil {
+		return nil, err
+	}
+	if timer.Status != domain.TimerStatusPaused {
+		return nil, apperrors.NewInvalidStatusError("only paused timers can be resumed")
+	}
+	if err := s.ensureNoRunningTimer(employeeID); err != nil {
+		return nil, err
+	}
+
+	segment := domain.TimerSegment{TimerID: timer.ID, StartedAt: time.Now()}
+	err = s.timeRepo.Transaction(func(repo repository.TimeRepository) error {
+		if err := repo.CreateTimerSegment(&segment); err != nil {
+			return err
+		}
+		timer.Status = domain.TimerStatusRunning
+		return repo.UpdateTimer(timer)
+	})
+	if err != nil {
+		return nil, runningTimerConflict(err)
+	}
+	timer.Segments = append(timer.Segments, segment)
+	return timer, nil
+}
+
+// StopTimer ends the timer and logs its elapsed time as one timesheet entry
+// per day in the organization's timezone, rounded according to its policy.
+func (s *timeService) StopTimer(orgID, employeeID, id uuid.UUID) (*domain.StopTimerResponse, error) {
+	timer, err := s.getEmployeeTimer(orgID, employeeID, id)
+	if err != nil {
+		return nil, err
+	}
+	if timer.Status == domain.TimerStatusStopped || timer.Status == domain.TimerStatusDiscarded {
+		return nil, apperrors.NewInvalidStatusError("timer is already stopped")
+	}
+
+	policy, err := s.GetTimesheetPolicy(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	requests := timerTimesheetRequests(timer, policy, now)
+
+	// Each day goes through every timesheet rule, seeing the days logged
+	// before it, and the entries and the stop are saved in one transaction so
+	// a failing day never leaves half a timer logged or the timer running
+	response := &domain.StopTimerResponse{Timesheets: []domain.TimesheetResult{}}
+	err = s.inTransaction(func(tx *timeService) error {
+		for i := range requests {
+			result, err := tx.CreateTimesheet(orgID, employeeID, &requests[i])
+			if err != nil {
+				return err
+			}
+			response.Timesheets = append(response.Timesheets, *result)
+		}
+
+		if timer.Status == domain.TimerStatusRunning {
+			if err := tx.closeTimerSegment(timer, now); err != nil {
+				return err
+			}
+		}
+		timer.Status = domain.TimerStatusStopped
+		timer.StoppedAt = &now
+		return tx.timeRepo.UpdateTimer(timer)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response.Timer = timer
+	return response, nil
+}
+
+// DiscardTimer stops the timer without logging its time, for timers whose
+// time cannot or should not be logged
+func (s *timeService) DiscardTimer(orgID, employeeID, id uuid.UUID) (*domain.Timer, error) {
+	timer, err := s.getEmployeeTimer(orgID, employeeID, id)
+	if err != nil {
+		return nil, err
+	}
+	if timer.Status == domain.TimerStatusStopped || timer.Status == domain.TimerStatusDiscarded {
+		return nil, apperrors.NewInvalidStatusError("timer is already stopped")
+	}
+
+	now := time.Now()
+	err = s.inTransaction(func(tx *timeService) error {
+		if err := tx.closeTimerSegment(timer, now); err != nil {
+			return err
+		}
+		timer.Status = domain.TimerStatusDiscarded
+		timer.StoppedAt = &now
+		return tx.timeRepo.UpdateTimer(timer)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return timer, nil
+}
+
+// timerTimesheetRequests converts the timer's segments into one timesheet
+// request per day, treating an open segment as ending at now.
+func timerTimesheetRequests(timer *domain.Timer, policy *domain.TimesheetPolicy, now time.Time) []domain.CreateTimesheetRequest {
+	loc, err := time.LoadLocation(policy.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	perDay := map[string]time.Duration{}
+	for _, segment := range timer.Segments {
+		end := now
+		if segment.EndedAt != nil {
+			end = *segment.EndedAt
+		}
+		for day, d := range utils.DurationsByDay(segment.StartedAt, end, loc) {
+			perDay[day] += d
+		}
+	}
+
+	days := make([]string, 0, len(perDay))
+	for day := range perDay {
+		days = append(days, day)
+	}
+	sort.Strings(days)
+
+	requests := []domain.CreateTimesheetRequest{}
+	for _, day := range days {
+		rounded := utils.RoundDuration(perDay[day], policy.TimerRoundingMinutes, policy.TimerRoundingMode)
+		hours := math.Round(rounded.Hours()*100) / 100
+		if hours <= 0 {
+			continue
+		}
+		requests = append(requests, domain.CreateTimesheetRequest{
+			ProjectID:   timer.ProjectID,
+			TaskID:      timer.TaskID,
+			Description: timer.Description,
+			Date:        day,
+			Hours:       hours,
+		})
+	}
+	return requests
+}
+
+func (s *timeService) closeTimerSegment(timer *domain.Timer, now time.Time) error {
+	for i := range timer.Segments {
+		segment := &timer.Segments[i]
+		if segment.EndedAt == nil {
+			segment.EndedAt = &now
+			if err := s.timeRepo.UpdateTimerSegment(segment); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *timeService) ensureNoRunningTimer(employeeID uuid.UUID) error {
+	_, err := s.timeRepo.GetRunningTimer(employeeID)
+	if err == nil {
+		return apperrors.NewConflictError("another timer is already running")
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	return nil
+}
+
+// runningTimerConflict reports a concurrent start that lost the race for the
+// employee's single running timer as a conflict
+func runningTimerConflict(err error) error {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return apperrors.NewConflictError("another timer is already running")
+	}
+	return err
+}
+
+func (s *timeService) getEmployeeTimer(orgID, employeeID, id uuid.UUID) (*domain.Timer, error) {
+	timer, err := s.timeRepo.GetTimer(id)
+	if err != nil {
+		return nil, apperrors.NewNotFoundError("timer not found")
+	}
+	if timer.OrganizationID != orgID || timer.EmployeeID != employeeID {
+		return nil, apperrors.NewNotFoundError("timer not found")
+	}
+	return timer, nil
+}
@@ -0,0 +1,238 @@
+package service
+
+import (
+	"math"
+	"sort"
+
+	"github.com/Axontik/comin-time-service/internal/domain"
+	apperrors "github.com/Axontik/comin-time-service/internal/errors"
+	"github.com/google/uuid"
+)
+
+// GetUtilizationReport compares, between two dates, the hours expected of
+// the organization's employees with the hours they attended, logged as
+// regular work and logged as billable, grouped by employee, team or project.
+// Employees are those on a team or with attendance, timesheets, leave or
+// shifts in the range. Employees who logged no hours are left out of the
+// project rows, though not of the totals.
+func (s *timeService) GetUtilizationReport(orgID uuid.UUID, filter *domain.UtilizationFilter) (*domain.UtilizationReport, error) {
+	switch filter.GroupBy {
+	case "":
+		filter.GroupBy = domain.UtilizationByEmployee
+	case domain.UtilizationByEmployee, domain.UtilizationByTeam, domain.UtilizationByProject:
+	default:
+		return nil, apperrors.NewBadRequestError("group_by must be employee, team or project")
+	}
+	if filter.EndDate.Before(filter.StartDate) {
+		return nil, apperrors.NewBadRequestError("end_date must not be before start_date")
+	}
+	if filter.EndDate.Sub(filter.StartDate) > maxReportRange {
+		return nil, apperrors.NewBadRequestError("date range must not exceed two years")
+	}
+
+	policy, err := s.GetTimesheetPolicy(orgID)
+	if err != nil {
+		return nil, err
+	}
+	memberships, err := s.timeRepo.ListEmployeeTeams(orgID)
+	if err != nil {
+		return nil, err
+	}
+	attendances, err := s.timeRepo.ListAttendancesInRange(orgID, filter.EmployeeID, filter.StartDate, filter.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	logged, err := s.timeRepo.SumTimesheetHoursByProject(orgID, filter.EmployeeID, filter.StartDate, filter.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	leave, err := s.timeRepo.ListLeaveRequests(orgID, &domain.LeaveRequestFilter{
+		EmployeeID: filter.EmployeeID,
+		Status:     domain.LeaveStatusApproved,
+		StartDate:  filter.StartDate,
+		EndDate:    filter.EndDate,
+	})
+	if err != nil {
+		return nil, err
+	}
+	shifts, err := s.timeRepo.ListShifts(orgID, &domain.ShiftFilter{
+		EmployeeID:    filter.EmployeeID,
+		PublishedOnly: true,
+		StartDate:     filter.StartDate,
+		EndDate:       filter.EndDate,
+	})
+	if err != nil {
+		return nil, err
+	}
+	calendar, err := s.holidayCalendar(orgID, filter.StartDate, filter.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	workWeekOf, err := s.workWeeks(policy)
+	if err != nil {
+		return nil, err
+	}
+
+	teams := map[uuid.UUID]uuid.UUID{}
+	for _, membership := range memberships {
+		teams[membership.EmployeeID] = membership.TeamID
+	}
+	employees := map[uuid.UUID]*domain.UtilizationRow{}
+	employeeRow := func(employeeID uuid.UUID) *domain.UtilizationRow {
+		if employees[employeeID] == nil {
+			id := employeeID
+			row := &domain.UtilizationRow{EmployeeID: &id, Employees: 1}
+			if teamID, ok := teams[employeeID]; ok {
+				row.TeamID = &teamID
+			}
+			employees[employeeID] = row
+		}
+		return employees[employeeID]
+	}
+
+	for employeeID := range teams {
+		if filter.EmployeeID == nil || *filter.EmployeeID == employeeID {
+			employeeRow(employeeID)
+		}
+	}
+	for i := range attendances {
+		row := employeeRow(attendances[i].EmployeeID)
+		if hours, ok := attendanceHours(&attendances[i]); ok {
+			row.AttendedHours += hours
+		}
+	}
+	for _, total := range logged {
+		row := employeeRow(total.EmployeeID)
+		row.TimesheetHours += total.Hours
+		row.BillableHours += total.BillableHours
+	}
+	leaveOf := map[uuid.UUID][]domain.LeaveRequest{}
+	for _, request := range leave {
+		employeeRow(request.EmployeeID)
+		leaveOf[request.EmployeeID] = append(leaveOf[request.EmployeeID], request)
+	}
+	rostered := rosteredHours(shifts)
+	for employeeID := range rostered {
+		employeeRow(employeeID)
+	}
+
+	for employeeID, row := range employees {
+		row.ExpectedHours = expectedHoursIn(policy.ExpectedDailyHours, workWeekOf(employeeID), calendar.forEmployee(employeeID),
+			leaveOf[employeeID], rostered[employeeID], filter.StartDate, filter.EndDate)
+	}
+	if filter.TeamID != nil {
+		for employeeID, row := range employees {
+			if row.TeamID == nil || *row.TeamID != *filter.TeamID {
+				delete(employees, employeeID)
+			}
+		}
+	}
+
+	report := &domain.UtilizationReport{
+		StartDate: filter.StartDate,
+		EndDate:   filter.EndDate,
+		GroupBy:   filter.GroupBy,
+		Rows:      []domain.UtilizationRow{},
+	}
+	for _, row := range employees {
+		addUtilization(&report.Totals, row)
+	}
+	report.Totals.Employees = len(employees)
+
+	switch filter.GroupBy {
+	case domain.UtilizationByEmployee:
+		for _, row := range employees {
+			report.Rows = append(report.Rows, *row)
+		}
+	case domain.UtilizationByTeam:
+		byTeam := map[string]*domain.UtilizationRow{}
+		for _, row := range employees {
+			key := ""
+			if row.TeamID != nil {
+				key = row.TeamID.String()
+			}
+			if byTeam[key] == nil {
+				byTeam[key] = &domain.UtilizationRow{TeamID: row.TeamID}
+			}
+			addUtilization(byTeam[key], row)
+			byTeam[key].Employees++
+		}
+		for _, row := range byTeam {
+			report.Rows = append(report.Rows, *row)
+		}
+	case domain.UtilizationByProject:
+		byProject := map[string]*domain.UtilizationRow{}
+		for _, total := range logged {
+			employee := employees[total.EmployeeID]
+			if employee == nil {
+				continue
+			}
+			key := ""
+			if total.ProjectID != nil {
+				key = total.ProjectID.String()
+			}
+			if byProject[key] == nil {
+				byProject[key] = &domain.UtilizationRow{ProjectID: total.ProjectID}
+			}
+			byProject[key].TimesheetHours += total.Hours
+			byProject[key].BillableHours += total.BillableHours
+			byProject[key].Employees++
+			if employee.TimesheetHours > 0 {
+				share := total.Hours / employee.TimesheetHours
+				byProject[key].ExpectedHours += employee.ExpectedHours * share
+				byProject[key].AttendedHours += employee.AttendedHours * share
+			}
+		}
+		for _, row := range byProject {
+			report.Rows = append(report.Rows, *row)
+		}
+	}
+
+	for i := range report.Rows {
+		finishUtilization(&report.Rows[i])
+	}
+	finishUtilization(&report.Totals)
+	sort.Slice(report.Rows, func(i, j int) bool {
+		return utilizationKey(&report.Rows[i]) < utilizationKey(&report.Rows[j])
+	})
+	return report, nil
+}
+
+func addUtilization(total, row *domain.UtilizationRow) {
+	total.ExpectedHours += row.ExpectedHours
+	total.AttendedHours += row.AttendedHours
+	total.TimesheetHours += row.TimesheetHours
+	total.BillableHours += row.BillableHours
+}
+
+// finishUtilization rounds the row's hours and computes its rates
+func finishUtilization(row *domain.UtilizationRow) {
+	row.ExpectedHours = roundHours(row.ExpectedHours)
+	row.AttendedHours = roundHours(row.AttendedHours)
+	row.TimesheetHours = roundHours(row.TimesheetHours)
+	row.BillableHours = roundHours(row.BillableHours)
+	row.AttendanceRate = percentOf(row.AttendedHours, row.ExpectedHours)
+	row.Utilization = percentOf(row.TimesheetHours, row.ExpectedHours)
+	row.BillableUtilization = percentOf(row.BillableHours, row.ExpectedHours)
+	row.BillableRatio = percentOf(row.BillableHours, row.TimesheetHours)
+}
+
+// percentOf returns part as a percentage of whole, nil when whole is zero
+func percentOf(part, whole float64) *float64 {
+	if whole <= 0 {
+		return nil
+	}
+	percent := math.Round(part/whole*10000) / 100
+	return &percent
+}
+
+// utilizationKey orders rows by the ID they are grouped by; rows without
+// a team or project sort last
+func utilizationKey(row *domain.UtilizationRow) string {
+	for _, id := range []*uuid.UUID{row.EmployeeID, row.TeamID, row.ProjectID} {
+		if id != nil {
+			return id.String()
+		}
+	}
+	return "~"
+}
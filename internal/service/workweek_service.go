@@ -0,0 +1,142 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Axontik/comin-time-service/internal/domain"
+	apperrors "github.com/Axontik/comin-time-service/internal/errors"
+	"github.com/Axontik/comin-time-service/utils"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SetEmployeeWorkWeek overrides the organization's work week for the
+// employee; a request without fields removes the override.
+func (s *timeService) SetEmployeeWorkWeek(orgID, employeeID uuid.UUID, req *domain.EmployeeWorkWeekRequest) (*domain.WorkWeek, error) {
+	if req.WeekStart == nil && req.WeekendDays == nil {
+		if err := s.timeRepo.DeleteEmployeeWorkWeek(orgID, employeeID); err != nil {
+			return nil, err
+		}
+		return s.GetEmployeeWorkWeek(orgID, employeeID)
+	}
+	if err := validateWeekdays(req.WeekendDays); err != nil {
+		return nil, err
+	}
+
+	workWeek, err := s.timeRepo.GetEmployeeWorkWeek(orgID, employeeID)
+	if err != nil {
+		workWeek = &domain.EmployeeWorkWeek{OrganizationID: orgID, EmployeeID: employeeID}
+	}
+	workWeek.WeekStart = req.WeekStart
+	workWeek.WeekendDays = nil
+	if req.WeekendDays != nil {
+		workWeek.WeekendDays = domain.Weekdays(req.WeekendDays)
+	}
+	if err := s.timeRepo.SaveEmployeeWorkWeek(workWeek); err != nil {
+		return nil, err
+	}
+	return s.GetEmployeeWorkWeek(orgID, employeeID)
+}
+
+// GetEmployeeWorkWeek returns the work week in effect for the employee
+func (s *timeService) GetEmployeeWorkWeek(orgID, employeeID uuid.UUID) (*domain.WorkWeek, error) {
+	policy, err := s.GetTimesheetPolicy(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.WorkWeek{
+		EmployeeID:  employeeID,
+		WeekStart:   policy.WeekStart,
+		WeekendDays: policy.WeekendDays,
+		Source:      domain.WorkWeekSourceOrganization,
+	}
+	override, err := s.timeRepo.GetEmployeeWorkWeek(orgID, employeeID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		return result, nil
+	}
+
+	result.Source = domain.WorkWeekSourceEmployee
+	if override.WeekStart != nil {
+		result.WeekStart = *override.WeekStart
+	}
+	if override.WeekendDays != nil {
+		result.WeekendDays = override.WeekendDays
+	}
+	return result, nil
+}
+
+// policyWorkWeek returns the organization's work week
+func policyWorkWeek(policy *domain.TimesheetPolicy) utils.WorkWeek {
+	week := utils.WorkWeek{Start: time.Weekday(policy.WeekStart), Weekend: []time.Weekday{}}
+	for _, day := range policy.WeekendDays {
+		week.Weekend = append(week.Weekend, time.Weekday(day))
+	}
+	return week
+}
+
+// applyWorkWeekOverride returns the organization's work week with the
+// employee's overrides applied
+func applyWorkWeekOverride(week utils.WorkWeek, override *domain.EmployeeWorkWeek) utils.WorkWeek {
+	if override.WeekStart != nil {
+		week.Start = time.Weekday(*override.WeekStart)
+	}
+	if override.WeekendDays != nil {
+		week.Weekend = []time.Weekday{}
+		for _, day := range override.WeekendDays {
+			week.Weekend = append(week.Weekend, time.Weekday(day))
+		}
+	}
+	return week
+}
+
+// employeeWorkWeek returns the work week of an employee under the policy.
+// Lookup failures fall back to the organization's work week.
+func (s *timeService) employeeWorkWeek(policy *domain.TimesheetPolicy, employeeID uuid.UUID) utils.WorkWeek {
+	week := policyWorkWeek(policy)
+	override, err := s.timeRepo.GetEmployeeWorkWeek(policy.OrganizationID, employeeID)
+	if err != nil {
+		return week
+	}
+	return applyWorkWeekOverride(week, override)
+}
+
+// workWeeks returns a lookup of the work week of every employee of the
+// policy's organization
+func (s *timeService) workWeeks(policy *domain.TimesheetPolicy) (func(uuid.UUID) utils.WorkWeek, error) {
+	overrides, err := s.timeRepo.ListEmployeeWorkWeeks(policy.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	week := policyWorkWeek(policy)
+	byEmployee := map[uuid.UUID]utils.WorkWeek{}
+	for i := range overrides {
+		byEmployee[overrides[i].EmployeeID] = applyWorkWeekOverride(week, &overrides[i])
+	}
+	return func(employeeID uuid.UUID) utils.WorkWeek {
+		if employeeWeek, ok := byEmployee[employeeID]; ok {
+			return employeeWeek
+		}
+		return week
+	}, nil
+}
+
+// validateWeekdays rejects weekend definitions that repeat a day
+func validateWeekdays(days []int) error {
+	seen := map[int]bool{}
+	for _, day := range days {
+		if day < 0 || day > 6 {
+			return apperrors.NewBadRequestError("weekdays must be between 0 (Sunday) and 6 (Saturday)")
+		}
+		if seen[day] {
+			return apperrors.NewBadRequestError("weekend days must not repeat")
+		}
+		seen[day] = true
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+-- migrations/000002_create_approval_sla.up.sql
+
+-- Holidays (non-working days per organization)
+CREATE TABLE holidays (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL,
+    date DATE NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (organization_id, date)
+);
+
+-- Approval SLA rules
+CREATE TABLE approval_sla_rules (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL,
+    level INTEGER NOT NULL,
+    business_days INTEGER NOT NULL,
+    escalation_target VARCHAR(20) NOT NULL, -- manager, org_admin
+    escalation_user_id UUID,
+    action VARCHAR(20) NOT NULL DEFAULT 'add_approver', -- reassign, add_approver
+    is_active BOOLEAN DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (organization_id, level)
+);
+
+-- Timesheet approvers
+CREATE TABLE timesheet_approvers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    timesheet_id UUID NOT NULL REFERENCES timesheets(id) ON DELETE CASCADE,
+    approver_id UUID NOT NULL,
+    level INTEGER NOT NULL DEFAULT 0,
+    is_active BOOLEAN DEFAULT true,
+    assigned_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Notification events (outbox)
+CREATE TABLE notification_events (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL,
+    recipient_id UUID,
+    type VARCHAR(100) NOT NULL,
+    subject_type VARCHAR(50),
+    subject_id UUID,
+    payload JSONB,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending', -- pending, sent
+    processed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes
+CREATE INDEX idx_holidays_organization_date ON holidays(organization_id, date);
+CREATE INDEX idx_timesheet_approvers_timesheet ON timesheet_approvers(timesheet_id);
+CREATE INDEX idx_timesheet_approvers_approver ON timesheet_approvers(approver_id);
+CREATE INDEX idx_timesheets_status ON timesheets(status);
+CREATE INDEX idx_notification_events_status ON notification_events(status);
@@ -0,0 +1,15 @@
+-- migrations/000003_create_timesheet_policies.up.sql
+
+-- Timesheet policies (one per organization)
+CREATE TABLE timesheet_policies (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL UNIQUE,
+    max_backdate_days INTEGER NOT NULL DEFAULT 30, -- 0 means only today can be logged
+    allow_future_dates BOOLEAN NOT NULL DEFAULT false,
+    lock_approved_periods BOOLEAN NOT NULL DEFAULT true,
+    locked_until DATE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_timesheets_employee_date ON timesheets(employee_id, date);
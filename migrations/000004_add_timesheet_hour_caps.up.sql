@@ -0,0 +1,9 @@
+-- migrations/000004_add_timesheet_hour_caps.up.sql
+
+-- Hour caps on timesheet policies (0 means no limit)
+ALTER TABLE timesheet_policies
+    ADD COLUMN max_daily_hours DECIMAL(5,2) NOT NULL DEFAULT 24,
+    ADD COLUMN max_weekly_hours DECIMAL(6,2) NOT NULL DEFAULT 0,
+    ADD COLUMN require_attendance_match BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN attendance_tolerance DECIMAL(4,2) NOT NULL DEFAULT 0,
+    ADD COLUMN hours_enforcement VARCHAR(20) NOT NULL DEFAULT 'warn'; -- warn, reject
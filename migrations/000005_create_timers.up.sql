@@ -0,0 +1,37 @@
+-- migrations/000005_create_timers.up.sql
+
+-- Timers
+CREATE TABLE timers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL,
+    employee_id UUID NOT NULL,
+    project_id UUID,
+    task_id UUID,
+    description TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'running', -- running, paused, stopped
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    stopped_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Timer segments (one per start/resume)
+CREATE TABLE timer_segments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    timer_id UUID NOT NULL REFERENCES timers(id) ON DELETE CASCADE,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ended_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Timer settings on timesheet policies
+ALTER TABLE timesheet_policies
+    ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    ADD COLUMN timer_rounding_minutes INTEGER NOT NULL DEFAULT 0, -- 0 means no rounding
+    ADD COLUMN timer_rounding_mode VARCHAR(20) NOT NULL DEFAULT 'nearest'; -- nearest, up, down
+
+-- Create indexes
+CREATE UNIQUE INDEX idx_timers_one_running ON timers(employee_id) WHERE status = 'running';
+CREATE INDEX idx_timers_employee ON timers(employee_id);
+CREATE INDEX idx_timer_segments_timer ON timer_segments(timer_id);
@@ -0,0 +1,11 @@
+-- migrations/000006_add_timesheet_clock_times.up.sql
+
+-- Optional start/end times on timesheet entries
+ALTER TABLE timesheets
+    ADD COLUMN start_time TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN end_time TIMESTAMP WITH TIME ZONE,
+    ADD CONSTRAINT chk_timesheets_clock_times CHECK (
+        (start_time IS NULL AND end_time IS NULL) OR (start_time IS NOT NULL AND end_time IS NOT NULL AND end_time > start_time)
+    );
+
+CREATE INDEX idx_timesheets_employee_start_time ON timesheets(employee_id, start_time) WHERE start_time IS NOT NULL;
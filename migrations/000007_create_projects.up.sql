@@ -0,0 +1,44 @@
+-- migrations/000007_create_projects.up.sql
+
+-- Projects
+CREATE TABLE projects (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    code VARCHAR(50) NOT NULL,
+    client VARCHAR(255),
+    status VARCHAR(20) NOT NULL DEFAULT 'active', -- active, on_hold, closed
+    start_date DATE,
+    end_date DATE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (organization_id, code)
+);
+
+-- Tasks
+CREATE TABLE tasks (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    code VARCHAR(50),
+    status VARCHAR(20) NOT NULL DEFAULT 'active', -- active, closed
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Project members
+CREATE TABLE project_members (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    employee_id UUID NOT NULL,
+    role VARCHAR(100),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (project_id, employee_id)
+);
+
+-- Create indexes
+CREATE INDEX idx_projects_organization ON projects(organization_id);
+CREATE INDEX idx_tasks_project ON tasks(project_id);
+CREATE INDEX idx_project_members_employee ON project_members(employee_id);
+CREATE INDEX idx_timesheets_project ON timesheets(project_id);
@@ -0,0 +1,33 @@
+-- migrations/000008_create_rate_cards.up.sql
+
+-- Rate cards
+CREATE TABLE rate_cards (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL,
+    project_id UUID REFERENCES projects(id) ON DELETE CASCADE,
+    role VARCHAR(100),
+    employee_id UUID,
+    bill_rate DECIMAL(10,2) NOT NULL DEFAULT 0,
+    cost_rate DECIMAL(10,2) NOT NULL DEFAULT 0,
+    currency CHAR(3) NOT NULL,
+    effective_from DATE NOT NULL,
+    effective_to DATE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Billability defaults on projects
+ALTER TABLE projects
+    ADD COLUMN is_billable BOOLEAN NOT NULL DEFAULT true;
+
+-- Billability and amounts on timesheet entries (frozen at approval)
+ALTER TABLE timesheets
+    ADD COLUMN is_billable BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN rate_card_id UUID,
+    ADD COLUMN bill_rate DECIMAL(10,2) NOT NULL DEFAULT 0,
+    ADD COLUMN cost_rate DECIMAL(10,2) NOT NULL DEFAULT 0,
+    ADD COLUMN billable_amount DECIMAL(12,2) NOT NULL DEFAULT 0,
+    ADD COLUMN cost_amount DECIMAL(12,2) NOT NULL DEFAULT 0,
+    ADD COLUMN currency CHAR(3);
+
+CREATE INDEX idx_rate_cards_organization ON rate_cards(organization_id, effective_from);
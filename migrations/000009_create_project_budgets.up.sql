@@ -0,0 +1,19 @@
+-- migrations/000009_create_project_budgets.up.sql
+
+-- Project budgets (task_id set for task-level budgets)
+CREATE TABLE project_budgets (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    task_id UUID REFERENCES tasks(id) ON DELETE CASCADE,
+    budget_hours DECIMAL(10,2) NOT NULL DEFAULT 0, -- 0 means no hour budget
+    budget_amount DECIMAL(12,2) NOT NULL DEFAULT 0, -- 0 means no money budget
+    currency CHAR(3),
+    hard_cap BOOLEAN NOT NULL DEFAULT false,
+    hours_alert_level INTEGER NOT NULL DEFAULT 0, -- last threshold alerted (50, 80, 100)
+    amount_alert_level INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_project_budgets_project ON project_budgets(project_id) WHERE task_id IS NULL;
+CREATE UNIQUE INDEX idx_project_budgets_task ON project_budgets(task_id) WHERE task_id IS NOT NULL;
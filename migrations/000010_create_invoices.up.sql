@@ -0,0 +1,46 @@
+-- migrations/000010_create_invoices.up.sql
+
+-- Invoice drafts
+CREATE TABLE invoices (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL,
+    number VARCHAR(50) NOT NULL,
+    project_id UUID REFERENCES projects(id),
+    client VARCHAR(255),
+    period_start DATE NOT NULL,
+    period_end DATE NOT NULL,
+    group_by VARCHAR(20) NOT NULL,
+    currency CHAR(3) NOT NULL,
+    subtotal DECIMAL(12,2) NOT NULL DEFAULT 0,
+    tax_rate DECIMAL(5,2) NOT NULL DEFAULT 0,
+    tax_amount DECIMAL(12,2) NOT NULL DEFAULT 0,
+    total DECIMAL(12,2) NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL DEFAULT 'draft',
+    notes TEXT,
+    created_by UUID,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (organization_id, number)
+);
+
+CREATE TABLE invoice_lines (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    invoice_id UUID NOT NULL REFERENCES invoices(id) ON DELETE CASCADE,
+    project_id UUID,
+    task_id UUID,
+    employee_id UUID,
+    description TEXT NOT NULL,
+    hours DECIMAL(10,2) NOT NULL DEFAULT 0,
+    rate DECIMAL(10,2) NOT NULL DEFAULT 0,
+    amount DECIMAL(12,2) NOT NULL DEFAULT 0,
+    entry_count INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Entries included in an invoice cannot be billed twice
+ALTER TABLE timesheets
+    ADD COLUMN invoice_id UUID REFERENCES invoices(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_invoices_organization ON invoices(organization_id, created_at);
+CREATE INDEX idx_timesheets_invoice ON timesheets(invoice_id);
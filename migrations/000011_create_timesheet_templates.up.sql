@@ -0,0 +1,29 @@
+-- migrations/000011_create_timesheet_templates.up.sql
+
+-- Recurring timesheet templates
+CREATE TABLE timesheet_templates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL,
+    employee_id UUID NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE timesheet_template_lines (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    template_id UUID NOT NULL REFERENCES timesheet_templates(id) ON DELETE CASCADE,
+    weekday SMALLINT NOT NULL CHECK (weekday BETWEEN 0 AND 6),
+    project_id UUID REFERENCES projects(id),
+    task_id UUID REFERENCES tasks(id),
+    description TEXT NOT NULL,
+    hours DECIMAL(5,2) NOT NULL DEFAULT 0,
+    start_time VARCHAR(8),
+    end_time VARCHAR(8),
+    billable BOOLEAN,
+    notes TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_timesheet_templates_employee ON timesheet_templates(organization_id, employee_id);
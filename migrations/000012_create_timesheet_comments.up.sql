@@ -0,0 +1,31 @@
+-- migrations/000012_create_timesheet_comments.up.sql
+
+-- Comment threads on timesheet entries and periods
+CREATE TABLE timesheet_comments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL,
+    employee_id UUID NOT NULL,
+    timesheet_id UUID REFERENCES timesheets(id) ON DELETE CASCADE,
+    period_start DATE,
+    author_id UUID NOT NULL,
+    kind VARCHAR(20) NOT NULL DEFAULT 'comment',
+    body TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (timesheet_id IS NOT NULL OR period_start IS NOT NULL)
+);
+
+-- Field-level change history of timesheet entries
+CREATE TABLE timesheet_changes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    timesheet_id UUID NOT NULL REFERENCES timesheets(id) ON DELETE CASCADE,
+    changed_by UUID,
+    field VARCHAR(50) NOT NULL,
+    old_value TEXT,
+    new_value TEXT,
+    changed_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_timesheet_comments_timesheet ON timesheet_comments(timesheet_id, created_at);
+CREATE INDEX idx_timesheet_comments_period ON timesheet_comments(organization_id, employee_id, period_start);
+CREATE INDEX idx_timesheet_changes_timesheet ON timesheet_changes(timesheet_id, changed_at);
@@ -0,0 +1,11 @@
+-- migrations/000013_add_timesheet_soft_delete.up.sql
+
+-- Soft deletes on timesheets
+ALTER TABLE timesheets
+    ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_timesheets_deleted_at ON timesheets(deleted_at);
+
+-- Retention of deleted timesheets before they can be purged
+ALTER TABLE timesheet_policies
+    ADD COLUMN deleted_retention_days INTEGER NOT NULL DEFAULT 30;
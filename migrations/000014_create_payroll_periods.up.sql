@@ -0,0 +1,32 @@
+-- migrations/000014_create_payroll_periods.up.sql
+
+-- Payroll periods closed by an organization
+CREATE TABLE payroll_periods (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'closed', -- closed, reopened
+    closed_by UUID NOT NULL,
+    closed_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    reopened_by UUID,
+    reopened_at TIMESTAMP WITH TIME ZONE,
+    reopen_reason TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (end_date >= start_date)
+);
+
+-- Audit trail of period closes and reopens
+CREATE TABLE payroll_period_events (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    period_id UUID NOT NULL REFERENCES payroll_periods(id) ON DELETE CASCADE,
+    action VARCHAR(20) NOT NULL, -- close, reopen
+    actor_id UUID NOT NULL,
+    reason TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_payroll_periods_organization ON payroll_periods(organization_id, start_date, end_date);
+CREATE INDEX idx_payroll_period_events_period ON payroll_period_events(period_id, created_at);
@@ -0,0 +1,5 @@
+-- migrations/000015_add_attendance_reconciliation.up.sql
+
+-- Block submitting drafts whose hours do not match attendance
+ALTER TABLE timesheet_policies
+    ADD COLUMN block_unreconciled_submission BOOLEAN NOT NULL DEFAULT false;
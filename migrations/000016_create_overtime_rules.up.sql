@@ -0,0 +1,38 @@
+-- migrations/000016_create_overtime_rules.up.sql
+
+-- Overtime rules of an organization
+CREATE TABLE overtime_rules (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    kind VARCHAR(20) NOT NULL, -- daily, weekly, weekend, holiday, night
+    threshold_hours DECIMAL(5,2) NOT NULL DEFAULT 0,
+    multiplier DECIMAL(4,2) NOT NULL,
+    night_start VARCHAR(5),
+    night_end VARCHAR(5),
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (multiplier > 1)
+);
+
+-- Worked time per employee, day and pay multiplier
+CREATE TABLE overtime_entries (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL,
+    employee_id UUID NOT NULL,
+    date DATE NOT NULL,
+    multiplier DECIMAL(4,2) NOT NULL,
+    hours DECIMAL(5,2) NOT NULL,
+    source VARCHAR(20) NOT NULL, -- attendance, timesheets
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (employee_id, date, multiplier)
+);
+
+-- Whether overtime is computed from attendance or approved timesheets
+ALTER TABLE timesheet_policies
+    ADD COLUMN overtime_source VARCHAR(20) NOT NULL DEFAULT 'attendance';
+
+CREATE INDEX idx_overtime_rules_organization ON overtime_rules(organization_id);
+CREATE INDEX idx_overtime_entries_organization ON overtime_entries(organization_id, date);
@@ -0,0 +1,37 @@
+-- migrations/000017_create_compliance_rules.up.sql
+
+-- Labor-law rule sets per organization and jurisdiction
+CREATE TABLE compliance_rule_sets (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    jurisdiction VARCHAR(50) NOT NULL DEFAULT '',
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (organization_id, jurisdiction)
+);
+
+CREATE TABLE compliance_rules (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    rule_set_id UUID NOT NULL REFERENCES compliance_rule_sets(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL, -- min_rest, max_weekly_hours, break
+    "limit" DECIMAL(6,2) NOT NULL,
+    break_minutes INTEGER NOT NULL DEFAULT 0,
+    severity VARCHAR(20) NOT NULL DEFAULT 'warning', -- warning, critical
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Jurisdiction of each employee; unassigned employees use the default set
+CREATE TABLE employee_jurisdictions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL,
+    employee_id UUID NOT NULL,
+    jurisdiction VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (organization_id, employee_id)
+);
+
+CREATE INDEX idx_compliance_rules_rule_set ON compliance_rules(rule_set_id);
@@ -0,0 +1,17 @@
+-- migrations/000018_add_attendance_rounding.up.sql
+
+-- Rounded punches, breaks and lunch deduction; check_in and check_out stay raw
+ALTER TABLE attendances
+    ADD COLUMN rounded_check_in TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN rounded_check_out TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN break_started_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN break_minutes INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN lunch_deduction_minutes INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN worked_hours DECIMAL(5,2) NOT NULL DEFAULT 0;
+
+-- Punch rounding and automatic lunch deduction
+ALTER TABLE timesheet_policies
+    ADD COLUMN punch_rounding_minutes INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN punch_rounding_mode VARCHAR(20) NOT NULL DEFAULT 'nearest', -- nearest, favor_employee
+    ADD COLUMN lunch_deduction_minutes INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN lunch_threshold_hours DECIMAL(4,2) NOT NULL DEFAULT 6;
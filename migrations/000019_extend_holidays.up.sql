@@ -0,0 +1,25 @@
+-- migrations/000019_extend_holidays.up.sql
+
+-- Site, recurring, half-day and imported holidays
+ALTER TABLE holidays
+    ADD COLUMN site VARCHAR(100) NOT NULL DEFAULT '',
+    ADD COLUMN recurring BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN half_day BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN source VARCHAR(20) NOT NULL DEFAULT 'manual', -- manual, ics
+    ADD COLUMN external_uid VARCHAR(255);
+
+ALTER TABLE holidays DROP CONSTRAINT holidays_organization_id_date_key;
+ALTER TABLE holidays ADD CONSTRAINT holidays_organization_id_site_date_key UNIQUE (organization_id, site, date);
+
+-- Site of each employee; employees without one only get organization-wide holidays
+CREATE TABLE employee_sites (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL,
+    employee_id UUID NOT NULL,
+    site VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (organization_id, employee_id)
+);
+
+CREATE INDEX idx_holidays_recurring ON holidays(organization_id) WHERE recurring;
@@ -0,0 +1,18 @@
+-- migrations/000020_add_work_week.up.sql
+
+-- Week start (0 = Sunday) and comma-separated weekend days of the organization
+ALTER TABLE timesheet_policies
+    ADD COLUMN week_start SMALLINT NOT NULL DEFAULT 0,
+    ADD COLUMN weekend_days VARCHAR(20) NOT NULL DEFAULT '6,0';
+
+-- Per-employee overrides; NULL columns follow the organization
+CREATE TABLE employee_work_weeks (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL,
+    employee_id UUID NOT NULL,
+    week_start SMALLINT,
+    weekend_days VARCHAR(20),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (organization_id, employee_id)
+);
@@ -0,0 +1,61 @@
+-- migrations/000021_create_leave.up.sql
+
+-- Leave types
+CREATE TABLE leave_types (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    kind VARCHAR(20) NOT NULL, -- annual, sick, unpaid
+    paid BOOLEAN NOT NULL DEFAULT true,
+    track_balance BOOLEAN NOT NULL DEFAULT true,
+    accrual_days_per_month DECIMAL(5,2) NOT NULL DEFAULT 0,
+    carry_over_cap_days DECIMAL(5,2),
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Yearly balances per employee and leave type
+CREATE TABLE leave_balances (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL,
+    employee_id UUID NOT NULL,
+    leave_type_id UUID NOT NULL REFERENCES leave_types(id) ON DELETE CASCADE,
+    year INTEGER NOT NULL,
+    carried_over DECIMAL(6,2) NOT NULL DEFAULT 0,
+    accrued DECIMAL(6,2) NOT NULL DEFAULT 0,
+    adjustment DECIMAL(6,2) NOT NULL DEFAULT 0,
+    used DECIMAL(6,2) NOT NULL DEFAULT 0,
+    accrued_months INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (employee_id, leave_type_id, year)
+);
+
+-- Leave requests
+CREATE TABLE leave_requests (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL,
+    employee_id UUID NOT NULL,
+    leave_type_id UUID NOT NULL REFERENCES leave_types(id),
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    half_day BOOLEAN NOT NULL DEFAULT false,
+    half_day_period VARCHAR(20), -- morning, afternoon
+    days DECIMAL(5,2) NOT NULL,
+    reason TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending', -- pending, approved, rejected, cancelled
+    reviewed_by UUID,
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    review_note TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_leave_requests_employee ON leave_requests(employee_id, start_date);
+CREATE INDEX idx_leave_requests_organization ON leave_requests(organization_id, status);
+
+-- Attendance records created by approved leave
+ALTER TABLE attendances ADD COLUMN leave_request_id UUID REFERENCES leave_requests(id) ON DELETE SET NULL;
+
+ALTER TABLE timesheet_policies ADD COLUMN expected_daily_hours DECIMAL(4,2) NOT NULL DEFAULT 8;
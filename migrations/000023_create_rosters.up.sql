@@ -0,0 +1,61 @@
+-- migrations/000023_create_rosters.up.sql
+
+-- Weekly rosters of shifts
+CREATE TABLE rosters (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL,
+    team_id UUID REFERENCES teams(id) ON DELETE SET NULL,
+    name VARCHAR(100) NOT NULL,
+    week_start DATE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'draft', -- draft, published
+    published_at TIMESTAMP WITH TIME ZONE,
+    published_by UUID,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_rosters_organization_week ON rosters(organization_id, week_start);
+
+-- Shifts; a NULL employee marks an open shift
+CREATE TABLE shifts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL,
+    roster_id UUID NOT NULL REFERENCES rosters(id) ON DELETE CASCADE,
+    employee_id UUID,
+    date DATE NOT NULL,
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ends_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    role VARCHAR(100),
+    notes TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX idx_shifts_employee_starts_at ON shifts(employee_id, starts_at);
+CREATE INDEX idx_shifts_organization_date ON shifts(organization_id, date);
+
+-- Shift swaps awaiting or given manager approval
+CREATE TABLE shift_swap_requests (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL,
+    shift_id UUID NOT NULL REFERENCES shifts(id) ON DELETE CASCADE,
+    requester_id UUID NOT NULL,
+    target_employee_id UUID NOT NULL,
+    target_shift_id UUID REFERENCES shifts(id) ON DELETE CASCADE,
+    reason TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending', -- pending, approved, rejected, cancelled
+    reviewed_by UUID,
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    review_note TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_shift_swap_requests_organization ON shift_swap_requests(organization_id, status);
+
+-- Rostered shift a check-in was held to
+ALTER TABLE attendances ADD COLUMN shift_id UUID REFERENCES shifts(id) ON DELETE SET NULL;
+
+-- Grace period before a check-in after the shift start is late
+ALTER TABLE timesheet_policies ADD COLUMN late_grace_minutes INTEGER NOT NULL DEFAULT 0;
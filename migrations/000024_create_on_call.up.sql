@@ -0,0 +1,52 @@
+-- migrations/000024_create_on_call.up.sql
+
+-- On-call rotations per team
+CREATE TABLE on_call_rotations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL,
+    team_id UUID NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    start_date DATE NOT NULL,
+    handover_time VARCHAR(5) NOT NULL, -- HH:MM in the policy timezone
+    period_days INTEGER NOT NULL DEFAULT 7,
+    is_active BOOLEAN DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (period_days > 0)
+);
+
+CREATE INDEX idx_on_call_rotations_organization ON on_call_rotations(organization_id, team_id);
+
+-- Members in the order they take turns
+CREATE TABLE on_call_rotation_members (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    rotation_id UUID NOT NULL REFERENCES on_call_rotations(id) ON DELETE CASCADE,
+    employee_id UUID NOT NULL,
+    position INTEGER NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(rotation_id, position)
+);
+
+-- Call-outs worked while on call
+CREATE TABLE on_call_incidents (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL,
+    rotation_id UUID NOT NULL REFERENCES on_call_rotations(id),
+    employee_id UUID NOT NULL,
+    project_id UUID REFERENCES projects(id),
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ended_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    description TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (ended_at > started_at)
+);
+
+CREATE INDEX idx_on_call_incidents_employee_started_at ON on_call_incidents(employee_id, started_at);
+CREATE INDEX idx_on_call_incidents_organization_started_at ON on_call_incidents(organization_id, started_at);
+
+-- Timesheet entry type; call-out entries come from on-call incidents
+ALTER TABLE timesheets
+    ADD COLUMN entry_type VARCHAR(20) NOT NULL DEFAULT 'work', -- work, call_out
+    ADD COLUMN on_call_incident_id UUID REFERENCES on_call_incidents(id) ON DELETE SET NULL;
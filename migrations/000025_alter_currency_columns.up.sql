@@ -0,0 +1,6 @@
+-- migrations/000025_alter_currency_columns.up.sql
+
+-- CHAR(3) padded an empty currency with blanks; VARCHAR(3) stores it as is
+-- and the blanks already stored are cleared to NULL
+ALTER TABLE timesheets ALTER COLUMN currency TYPE VARCHAR(3) USING NULLIF(TRIM(currency), '');
+ALTER TABLE project_budgets ALTER COLUMN currency TYPE VARCHAR(3) USING NULLIF(TRIM(currency), '');
@@ -0,0 +1,4 @@
+-- migrations/000026_unlock_approved_periods_by_default.up.sql
+
+-- Approved weeks are only locked when an organization opts in
+ALTER TABLE timesheet_policies ALTER COLUMN lock_approved_periods SET DEFAULT false;
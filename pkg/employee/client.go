@@ -0,0 +1,84 @@
+package employee
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EmployeeClient struct {
+	baseURL      string
+	serviceToken string
+	httpClient   *http.Client
+}
+
+type EmployeeResponse struct {
+	ID             string  `json:"id"`
+	OrganizationID string  `json:"organization_id"`
+	FirstName      string  `json:"first_name"`
+	LastName       string  `json:"last_name"`
+	Email          string  `json:"email"`
+	ManagerID      *string `json:"manager_id"`
+	Status         string  `json:"status"`
+}
+
+// NewEmployeeClient creates a client for the employee service. The service
+// token is used for calls made outside of a user request, e.g. by background jobs.
+func NewEmployeeClient(baseURL, serviceToken string) *EmployeeClient {
+	return &EmployeeClient{
+		baseURL:      baseURL,
+		serviceToken: serviceToken,
+		httpClient: &http.Client{
+			Timeout: time.Second * 10,
+		},
+	}
+}
+
+func (c *EmployeeClient) GetEmployee(token string, orgID string, employeeID string) (*EmployeeResponse, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/organizations/%s/employees/%s", c.baseURL, orgID, employeeID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get employee: status %d", resp.StatusCode)
+	}
+
+	var employee EmployeeResponse
+	if err := json.NewDecoder(resp.Body).Decode(&employee); err != nil {
+		return nil, err
+	}
+
+	return &employee, nil
+}
+
+// GetManagerID returns the line manager of the employee, or nil when the
+// employee has none.
+func (c *EmployeeClient) GetManagerID(orgID, employeeID uuid.UUID) (*uuid.UUID, error) {
+	employee, err := c.GetEmployee(c.serviceToken, orgID.String(), employeeID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if employee.ManagerID == nil || *employee.ManagerID == "" {
+		return nil, nil
+	}
+
+	managerID, err := uuid.Parse(*employee.ManagerID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid manager id: %w", err)
+	}
+
+	return &managerID, nil
+}
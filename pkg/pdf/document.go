@@ -0,0 +1,123 @@
+// Package pdf writes simple text-only PDF documents. It uses the built-in
+// Courier font so that callers can lay out columns with fixed-width padding.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+const (
+	pageWidth  = 595.0 // A4 in points
+	pageHeight = 842.0
+	margin     = 50.0
+)
+
+type line struct {
+	text string
+	size float64
+}
+
+// Document collects lines of text and lays them out top to bottom,
+// starting a new page when the current one is full.
+type Document struct {
+	pages [][]line
+	y     float64
+}
+
+func NewDocument() *Document {
+	return &Document{}
+}
+
+// AddLine appends a line of text in the given font size. Empty text adds
+// vertical space.
+func (d *Document) AddLine(text string, size float64) {
+	height := size * 1.4
+	if len(d.pages) == 0 || d.y-height < margin {
+		d.pages = append(d.pages, []line{})
+		d.y = pageHeight - margin
+	}
+	d.y -= height
+
+	page := len(d.pages) - 1
+	d.pages[page] = append(d.pages[page], line{text: text, size: size})
+}
+
+// Bytes renders the document
+func (d *Document) Bytes() []byte {
+	pages := d.pages
+	if len(pages) == 0 {
+		pages = [][]line{{}}
+	}
+
+	// Object layout: 1 catalog, 2 page tree, 3 font, then a page and a
+	// content stream object for every page.
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>",
+	}
+
+	kids := []string{}
+	for _, page := range pages {
+		pageObject := len(objects) + 1
+		kids = append(kids, fmt.Sprintf("%d 0 R", pageObject))
+
+		content := renderPage(page)
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>", pageWidth, pageHeight, pageObject+1),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content),
+		)
+	}
+	objects[1] = fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(kids))
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+
+	return buf.Bytes()
+}
+
+func renderPage(lines []line) string {
+	var buf strings.Builder
+	y := pageHeight - margin
+	for _, l := range lines {
+		y -= l.size * 1.4
+		if l.text == "" {
+			continue
+		}
+		fmt.Fprintf(&buf, "BT /F1 %.1f Tf %.1f %.1f Td (%s) Tj ET\n", l.size, margin, y, escape(l.text))
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// escape quotes PDF string delimiters and replaces characters outside of
+// printable ASCII, which the standard fonts cannot encode reliably.
+func escape(text string) string {
+	var buf strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			buf.WriteRune('\\')
+			buf.WriteRune(r)
+		case r < 32 || r > 126:
+			buf.WriteRune('?')
+		default:
+			buf.WriteRune(r)
+		}
+	}
+	return buf.String()
+}
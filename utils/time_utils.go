@@ -45,10 +45,54 @@ func FormatDate(t time.Time) string {
 	return t.Format("2006-01-02")
 }
 
+// WorkWeek describes the first day of the week and the weekend days
+type WorkWeek struct {
+	Start   time.Weekday
+	Weekend []time.Weekday
+}
+
+// DefaultWorkWeek starts on Sunday with a Saturday/Sunday weekend
+var DefaultWorkWeek = WorkWeek{Start: time.Sunday, Weekend: []time.Weekday{time.Saturday, time.Sunday}}
+
+// IsWeekend checks if the given date falls on one of the weekend days
+func (w WorkWeek) IsWeekend(date time.Time) bool {
+	weekday := date.Weekday()
+	for _, day := range w.Weekend {
+		if day == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// StartOfWeek returns the first day of the week containing the date
+func (w WorkWeek) StartOfWeek(date time.Time) time.Time {
+	offset := (int(date.Weekday()) - int(w.Start) + 7) % 7
+	return date.AddDate(0, 0, -offset)
+}
+
+// EndOfWeek returns the last day of the week containing the date
+func (w WorkWeek) EndOfWeek(date time.Time) time.Time {
+	return w.StartOfWeek(date).AddDate(0, 0, 6)
+}
+
+// CountBusinessDays counts the working days after start up to and including end.
+// Weekends are skipped, as are days for which isHoliday returns true (isHoliday may be nil).
+func (w WorkWeek) CountBusinessDays(start, end time.Time, isHoliday func(time.Time) bool) int {
+	days := 0
+	day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location()).AddDate(0, 0, 1)
+	for !day.After(end) {
+		if !w.IsWeekend(day) && (isHoliday == nil || !isHoliday(day)) {
+			days++
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return days
+}
+
 // IsWeekend checks if the given date is a weekend (Saturday or Sunday)
 func IsWeekend(date time.Time) bool {
-	weekday := date.Weekday()
-	return weekday == time.Saturday || weekday == time.Sunday
+	return DefaultWorkWeek.IsWeekend(date)
 }
 
 // GetStartOfMonth returns the start date of the month for the given date
@@ -63,11 +107,53 @@ func GetEndOfMonth(date time.Time) time.Time {
 
 // GetStartOfWeek returns the start date of the week (Sunday) for the given date
 func GetStartOfWeek(date time.Time) time.Time {
-	offset := int(date.Weekday())
-	return date.AddDate(0, 0, -offset)
+	return DefaultWorkWeek.StartOfWeek(date)
 }
 
 // GetEndOfWeek returns the end date of the week (Saturday) for the given date
 func GetEndOfWeek(date time.Time) time.Time {
 	return GetStartOfWeek(date).AddDate(0, 0, 6)
 }
+
+// CountBusinessDays counts the working days after start up to and including end
+// in the default work week
+func CountBusinessDays(start, end time.Time, isHoliday func(time.Time) bool) int {
+	return DefaultWorkWeek.CountBusinessDays(start, end, isHoliday)
+}
+
+// RoundDuration rounds d to a multiple of the given number of minutes.
+// mode is "up", "down" or "nearest" (the default); minutes <= 0 disables rounding.
+func RoundDuration(d time.Duration, minutes int, mode string) time.Duration {
+	if minutes <= 0 {
+		return d
+	}
+	step := time.Duration(minutes) * time.Minute
+	switch mode {
+	case "up":
+		if rem := d % step; rem != 0 {
+			return d - rem + step
+		}
+		return d
+	case "down":
+		return d - d%step
+	default:
+		return d.Round(step)
+	}
+}
+
+// DurationsByDay splits the interval between start and end at midnight in loc
+// and returns the time falling on each day, keyed by date ("2006-01-02").
+func DurationsByDay(start, end time.Time, loc *time.Location) map[string]time.Duration {
+	days := map[string]time.Duration{}
+	current := start.In(loc)
+	end = end.In(loc)
+	for current.Before(end) {
+		midnight := time.Date(current.Year(), current.Month(), current.Day(), 0, 0, 0, 0, loc).AddDate(0, 0, 1)
+		if midnight.After(end) {
+			midnight = end
+		}
+		days[FormatDate(current)] += midnight.Sub(current)
+		current = midnight
+	}
+	return days
+}